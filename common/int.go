@@ -72,3 +72,8 @@ func (mi *modInt) i() *big.Int {
 func IsInInterval(b *big.Int, bound *big.Int) bool {
 	return b.Cmp(bound) == -1 && b.Cmp(zero) >= 0
 }
+
+// IsInGroup reports whether v is in the multiplicative group of the integers modulo n: 0 < v < n and gcd(v, n) = 1
+func IsInGroup(v *big.Int, n *big.Int) bool {
+	return v != nil && 0 < v.Sign() && v.Cmp(n) < 0 && new(big.Int).GCD(nil, nil, v, n).Cmp(one) == 0
+}
@@ -0,0 +1,49 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"math/big"
+)
+
+// Helpers to overwrite secrets once a party is done with them, instead of leaving them on the heap until they are collected.
+// Only the memory that a value holds now is overwritten: copies left behind by earlier arithmetic that grew it are not reachable.
+
+// WipeBigInt overwrites the words of x with zeros and sets it to 0. A nil x is ignored.
+// It must not be given a value that is still in use elsewhere, such as a field of the key data.
+func WipeBigInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+	words := x.Bits()
+	words = words[:cap(words)]
+	for k := range words {
+		words[k] = 0
+	}
+	x.SetInt64(0)
+}
+
+// WipeBigInts calls WipeBigInt on each of xs.
+func WipeBigInts(xs ...*big.Int) {
+	for _, x := range xs {
+		WipeBigInt(x)
+	}
+}
+
+// WipeBytes overwrites bz with zeros.
+func WipeBytes(bz []byte) {
+	for k := range bz {
+		bz[k] = 0
+	}
+}
+
+// WipeByteSlices calls WipeBytes on each of bzs.
+func WipeByteSlices(bzs ...[]byte) {
+	for _, bz := range bzs {
+		WipeBytes(bz)
+	}
+}
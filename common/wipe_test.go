@@ -0,0 +1,39 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeta-chain/tss-lib/common"
+)
+
+func TestWipeBigInt(t *testing.T) {
+	x := common.GetRandomPositiveInt(new(big.Int).Lsh(big.NewInt(1), 512))
+	// shrinking x leaves the high words in its memory, past its length
+	x.Rsh(x, 256)
+	words := x.Bits()
+	words = words[:cap(words)]
+
+	common.WipeBigInt(x)
+	assert.Zero(t, x.Sign())
+	for _, word := range words {
+		assert.Zero(t, word)
+	}
+	assert.Equal(t, 0, x.Cmp(big.NewInt(0)), "a wiped value is still usable as 0")
+	assert.NotPanics(t, func() { common.WipeBigInt(nil) })
+}
+
+func TestWipeBytes(t *testing.T) {
+	bz, other := []byte{1, 2, 3}, []byte{4}
+	common.WipeByteSlices(bz, nil, other)
+	assert.Equal(t, []byte{0, 0, 0}, bz)
+	assert.Equal(t, []byte{0}, other)
+}
@@ -0,0 +1,257 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Zero-knowledge proof of a Paillier affine operation with a group commitment: D = C^x * enc_N0(y; rho) mod N0^2 and
+// Y = enc_N1(y; rhoY), where X = x * g, x < 2^l and y < 2^l' for l the bit length of the curve order and l' = 5l.
+// The proof is made for the Ring-Pedersen parameters (NCap, s, t) of the verifier, who owns the Paillier key N0.
+// Canetti, R., Gennaro, R., Goldfeder, S., Makriyannis, N., Peled, U.: UC Non-Interactive, Proactive, Threshold ECDSA
+// with Identifiable Aborts (CGGMP21), Fig. 15.
+
+package affgproof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+const (
+	ProofAffGBytesParts = 14
+
+	// LPrimeFactor is l'/l, the ratio of the bit length of y to that of the curve order
+	LPrimeFactor = 5
+)
+
+type (
+	ProofAffG struct {
+		S, T, A        *big.Int
+		Bx             *crypto.ECPoint
+		By, E, F       *big.Int
+		Z1, Z2, Z3, Z4 *big.Int
+		W, Wy          *big.Int
+	}
+)
+
+var (
+	one = big.NewInt(1)
+)
+
+// NewProof implements proofAffG. pk0 is the Paillier key of the verifier, under which C and D are encrypted, and pk1 is
+// the Paillier key of the prover, under which Y is encrypted.
+// The session binds the proof to the prover and its protocol session; see tss.ProofSession.
+func NewProof(
+	session *big.Int,
+	pk0, pk1 *paillier.PublicKey,
+	C, D, Y *big.Int,
+	X, g *crypto.ECPoint,
+	NCap, s, t,
+	x, y, rho, rhoY *big.Int,
+) (*ProofAffG, error) {
+	if session == nil || pk0 == nil || pk1 == nil || C == nil || D == nil || Y == nil || X == nil || g == nil ||
+		NCap == nil || s == nil || t == nil || x == nil || y == nil || rho == nil || rhoY == nil {
+		return nil, errors.New("ProveAffG constructor received nil value(s)")
+	}
+	q := g.Curve().Params().N
+	l, lPrime, eps := q.BitLen(), LPrimeFactor*q.BitLen(), 2*q.BitLen()
+	N0, N0Sq, N1, N1Sq := pk0.N, pk0.NSquare(), pk1.N, pk1.NSquare()
+
+	// Fig 15.1 sample
+	alpha := common.GetRandomPositiveInt(new(big.Int).Lsh(one, uint(l+eps)))
+	beta := common.GetRandomPositiveInt(new(big.Int).Lsh(one, uint(lPrime+eps)))
+	r := common.GetRandomPositiveRelativelyPrimeInt(N0)
+	rY := common.GetRandomPositiveRelativelyPrimeInt(N1)
+	gamma := common.GetRandomPositiveInt(new(big.Int).Lsh(NCap, uint(l+eps)))
+	m := common.GetRandomPositiveInt(new(big.Int).Lsh(NCap, uint(l)))
+	delta := common.GetRandomPositiveInt(new(big.Int).Lsh(NCap, uint(l+eps)))
+	mu := common.GetRandomPositiveInt(new(big.Int).Lsh(NCap, uint(l)))
+
+	// Fig 15.1 compute
+	modNCap, modN0Sq, modN1Sq := common.ModInt(NCap), common.ModInt(N0Sq), common.ModInt(N1Sq)
+	A := modN0Sq.Mul(modN0Sq.Exp(C, alpha), modN0Sq.Mul(modN0Sq.Exp(pk0.Gamma(), beta), modN0Sq.Exp(r, N0)))
+	Bx := g.ScalarMult(new(big.Int).Mod(alpha, q))
+	if Bx == nil {
+		return nil, errors.New("ProveAffG constructor sampled alpha = 0 mod q")
+	}
+	By := modN1Sq.Mul(modN1Sq.Exp(pk1.Gamma(), beta), modN1Sq.Exp(rY, N1))
+	E := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+	S := modNCap.Mul(modNCap.Exp(s, x), modNCap.Exp(t, m))
+	F := modNCap.Mul(modNCap.Exp(s, beta), modNCap.Exp(t, delta))
+	T := modNCap.Mul(modNCap.Exp(s, y), modNCap.Exp(t, mu))
+
+	// Fig 15.2 e
+	e := challenge(q, session, N0, N1, C, D, Y, X, g, NCap, s, t, S, T, A, Bx, By, E, F)
+
+	// Fig 15.3
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	z2 := new(big.Int).Add(beta, new(big.Int).Mul(e, y))
+	z3 := new(big.Int).Add(gamma, new(big.Int).Mul(e, m))
+	z4 := new(big.Int).Add(delta, new(big.Int).Mul(e, mu))
+	w := common.ModInt(N0).Mul(r, common.ModInt(N0).Exp(rho, e))
+	wY := common.ModInt(N1).Mul(rY, common.ModInt(N1).Exp(rhoY, e))
+
+	return &ProofAffG{S: S, T: T, A: A, Bx: Bx, By: By, E: E, F: F, Z1: z1, Z2: z2, Z3: z3, Z4: z4, W: w, Wy: wY}, nil
+}
+
+func NewProofFromBytes(bzs [][]byte) (*ProofAffG, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofAffGBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofAffG", ProofAffGBytesParts)
+	}
+	Bx, err := crypto.NewECPoint(tss.EC(), new(big.Int).SetBytes(bzs[3]), new(big.Int).SetBytes(bzs[4]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofAffG{
+		S:  new(big.Int).SetBytes(bzs[0]),
+		T:  new(big.Int).SetBytes(bzs[1]),
+		A:  new(big.Int).SetBytes(bzs[2]),
+		Bx: Bx,
+		By: new(big.Int).SetBytes(bzs[5]),
+		E:  new(big.Int).SetBytes(bzs[6]),
+		F:  new(big.Int).SetBytes(bzs[7]),
+		Z1: new(big.Int).SetBytes(bzs[8]),
+		Z2: new(big.Int).SetBytes(bzs[9]),
+		Z3: new(big.Int).SetBytes(bzs[10]),
+		Z4: new(big.Int).SetBytes(bzs[11]),
+		W:  new(big.Int).SetBytes(bzs[12]),
+		Wy: new(big.Int).SetBytes(bzs[13]),
+	}, nil
+}
+
+func (pf *ProofAffG) Verify(
+	session *big.Int,
+	pk0, pk1 *paillier.PublicKey,
+	C, D, Y *big.Int,
+	X, g *crypto.ECPoint,
+	NCap, s, t *big.Int,
+) bool {
+	if pf == nil || !pf.ValidateBasic() || session == nil || pk0 == nil || pk0.N == nil || pk1 == nil || pk1.N == nil ||
+		C == nil || D == nil || Y == nil || X == nil || g == nil || NCap == nil || s == nil || t == nil {
+		return false
+	}
+	if pk0.N.Sign() != 1 || pk1.N.Sign() != 1 || NCap.Sign() != 1 {
+		return false
+	}
+	q := g.Curve().Params().N
+	l, lPrime, eps := q.BitLen(), LPrimeFactor*q.BitLen(), 2*q.BitLen()
+	N0, N0Sq, N1, N1Sq := pk0.N, pk0.NSquare(), pk1.N, pk1.NSquare()
+
+	if !pf.Bx.IsOnCurve() || !X.IsOnCurve() {
+		return false
+	}
+	if !common.IsInGroup(C, N0Sq) || !common.IsInGroup(D, N0Sq) || !common.IsInGroup(pf.A, N0Sq) || !common.IsInGroup(pf.W, N0) {
+		return false
+	}
+	if !common.IsInGroup(Y, N1Sq) || !common.IsInGroup(pf.By, N1Sq) || !common.IsInGroup(pf.Wy, N1) {
+		return false
+	}
+	if !common.IsInGroup(pf.S, NCap) || !common.IsInGroup(pf.T, NCap) || !common.IsInGroup(pf.E, NCap) || !common.IsInGroup(pf.F, NCap) {
+		return false
+	}
+
+	// Fig 15. Range Check; the slack of one bit covers e * x and e * y
+	if !common.IsInInterval(pf.Z1, new(big.Int).Lsh(one, uint(l+eps+1))) {
+		return false
+	}
+	if !common.IsInInterval(pf.Z2, new(big.Int).Lsh(one, uint(lPrime+eps+1))) {
+		return false
+	}
+
+	e := challenge(q, session, N0, N1, C, D, Y, X, g, NCap, s, t, pf.S, pf.T, pf.A, pf.Bx, pf.By, pf.E, pf.F)
+
+	// Fig 15. Equality Check
+	modNCap, modN0Sq, modN1Sq := common.ModInt(NCap), common.ModInt(N0Sq), common.ModInt(N1Sq)
+	{
+		LHS := modN0Sq.Mul(modN0Sq.Exp(C, pf.Z1), modN0Sq.Mul(modN0Sq.Exp(pk0.Gamma(), pf.Z2), modN0Sq.Exp(pf.W, N0)))
+		RHS := modN0Sq.Mul(pf.A, modN0Sq.Exp(D, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	{
+		LHS, eX := g.ScalarMult(new(big.Int).Mod(pf.Z1, q)), X.ScalarMult(e)
+		if LHS == nil || eX == nil {
+			return false
+		}
+		RHS, err := pf.Bx.Add(eX)
+		if err != nil || !LHS.Equals(RHS) {
+			return false
+		}
+	}
+	{
+		LHS := modN1Sq.Mul(modN1Sq.Exp(pk1.Gamma(), pf.Z2), modN1Sq.Exp(pf.Wy, N1))
+		RHS := modN1Sq.Mul(pf.By, modN1Sq.Exp(Y, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	{
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.E, modNCap.Exp(pf.S, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	{
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z2), modNCap.Exp(t, pf.Z4))
+		RHS := modNCap.Mul(pf.F, modNCap.Exp(pf.T, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofAffG) ValidateBasic() bool {
+	return pf.S != nil &&
+		pf.T != nil &&
+		pf.A != nil &&
+		pf.Bx != nil &&
+		pf.By != nil &&
+		pf.E != nil &&
+		pf.F != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil &&
+		pf.Z4 != nil &&
+		pf.W != nil &&
+		pf.Wy != nil
+}
+
+func (pf *ProofAffG) Bytes() [ProofAffGBytesParts][]byte {
+	return [...][]byte{
+		pf.S.Bytes(),
+		pf.T.Bytes(),
+		pf.A.Bytes(),
+		pf.Bx.X().Bytes(),
+		pf.Bx.Y().Bytes(),
+		pf.By.Bytes(),
+		pf.E.Bytes(),
+		pf.F.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+		pf.Z3.Bytes(),
+		pf.Z4.Bytes(),
+		pf.W.Bytes(),
+		pf.Wy.Bytes(),
+	}
+}
+
+// challenge derives e from the session, the statement and the first messages of the proof (Fiat-Shamir)
+func challenge(
+	q, session, N0, N1, C, D, Y *big.Int,
+	X, g *crypto.ECPoint,
+	NCap, s, t, S, T, A *big.Int,
+	Bx *crypto.ECPoint,
+	By, E, F *big.Int,
+) *big.Int {
+	eHash := common.SHA512_256i(session, N0, N1, C, D, Y, X.X(), X.Y(), g.X(), g.Y(), NCap, s, t, S, T, A, Bx.X(), Bx.Y(), By, E, F)
+	return common.RejectionSample(q, eHash)
+}
@@ -0,0 +1,79 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package affgproof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Using a modulus length of 2048 is recommended in the GG18 spec
+const (
+	testPrimeBits = 1024
+)
+
+var (
+	testSession = big.NewInt(42)
+)
+
+func TestAffG(test *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+
+	// the verifier owns pk0 and the Ring-Pedersen parameters, the prover owns pk1
+	pk0 := &paillier.PublicKey{N: new(big.Int).Mul(common.GetRandomPrimeInt(testPrimeBits), common.GetRandomPrimeInt(testPrimeBits))}
+	pk1 := &paillier.PublicKey{N: new(big.Int).Mul(common.GetRandomPrimeInt(testPrimeBits), common.GetRandomPrimeInt(testPrimeBits))}
+	primes := [2]*big.Int{common.GetRandomPrimeInt(testPrimeBits), common.GetRandomPrimeInt(testPrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(primes)
+	assert.NoError(test, err)
+
+	// D = C^x * enc_N0(y), Y = enc_N1(y), X = x * G
+	C, err := pk0.Encrypt(common.GetRandomPositiveInt(q))
+	assert.NoError(test, err)
+	x := common.GetRandomPositiveInt(q)
+	y := common.GetRandomPositiveInt(new(big.Int).Lsh(big.NewInt(1), uint(LPrimeFactor*q.BitLen())))
+	X := crypto.ScalarBaseMult(ec, x)
+	Cx, err := pk0.HomoMult(x, C)
+	assert.NoError(test, err)
+	encY, rho, err := pk0.EncryptAndReturnRandomness(y)
+	assert.NoError(test, err)
+	D, err := pk0.HomoAdd(Cx, encY)
+	assert.NoError(test, err)
+	Y, rhoY, err := pk1.EncryptAndReturnRandomness(y)
+	assert.NoError(test, err)
+	g := crypto.ScalarBaseMult(ec, big.NewInt(1))
+
+	proof, err := NewProof(testSession, pk0, pk1, C, D, Y, X, g, NCap, s, t, x, y, rho, rhoY)
+	assert.NoError(test, err)
+	assert.True(test, proof.Verify(testSession, pk0, pk1, C, D, Y, X, g, NCap, s, t), "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(bzs[:])
+	assert.NoError(test, err)
+	assert.True(test, proof2.Verify(testSession, pk0, pk1, C, D, Y, X, g, NCap, s, t), "proof must verify after a round trip")
+
+	// another session
+	assert.False(test, proof.Verify(big.NewInt(43), pk0, pk1, C, D, Y, X, g, NCap, s, t), "proof must not verify for another session")
+
+	// X does not match x
+	X2 := crypto.ScalarBaseMult(ec, new(big.Int).Add(x, big.NewInt(1)))
+	assert.False(test, proof.Verify(testSession, pk0, pk1, C, D, Y, X2, g, NCap, s, t), "proof must not verify for another X")
+
+	// Y encrypts another value than the one added to D
+	Y2, rhoY2, err := pk1.EncryptAndReturnRandomness(new(big.Int).Add(y, big.NewInt(1)))
+	assert.NoError(test, err)
+	proof, err = NewProof(testSession, pk0, pk1, C, D, Y2, X, g, NCap, s, t, x, y, rho, rhoY2)
+	assert.NoError(test, err)
+	assert.False(test, proof.Verify(testSession, pk0, pk1, C, D, Y2, X, g, NCap, s, t), "proof must not verify for another Y")
+}
@@ -0,0 +1,83 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Base58 and Base58Check encoding with the Bitcoin alphabet
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Radix = big.NewInt(58)
+
+func base58Encode(bz []byte) string {
+	zeros := 0
+	for zeros < len(bz) && bz[zeros] == 0 {
+		zeros++
+	}
+	n, mod := new(big.Int).SetBytes(bz), new(big.Int)
+	res := make([]byte, 0, len(bz)*138/100+1)
+	for n.Sign() > 0 {
+		n.DivMod(n, base58Radix, mod)
+		res = append(res, base58Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		res = append(res, base58Alphabet[0])
+	}
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return string(res)
+}
+
+func base58Decode(s string) ([]byte, error) {
+	n := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base58Alphabet, s[i])
+		if d < 0 {
+			return nil, fmt.Errorf("base58: invalid character %q", s[i])
+		}
+		n.Mul(n, base58Radix).Add(n, big.NewInt(int64(d)))
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+func base58CheckEncode(version byte, payload []byte) string {
+	bz := append([]byte{version}, payload...)
+	return base58Encode(append(bz, base58Checksum(bz)...))
+}
+
+func base58CheckDecode(s string) (version byte, payload []byte, err error) {
+	bz, err := base58Decode(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(bz) < 5 {
+		return 0, nil, errors.New("base58: invalid length")
+	}
+	if !bytes.Equal(base58Checksum(bz[:len(bz)-4]), bz[len(bz)-4:]) {
+		return 0, nil, errors.New("base58: invalid checksum")
+	}
+	return bz[0], bz[1 : len(bz)-4], nil
+}
+
+func base58Checksum(bz []byte) []byte {
+	h := sha256.Sum256(bz)
+	h = sha256.Sum256(h[:])
+	return h[:4]
+}
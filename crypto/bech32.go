@@ -0,0 +1,138 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Bech32 (BIP-173) and bech32m (BIP-350) encoding of a human-readable part and 5-bit data
+
+const (
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, g := range bech32Generator {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	res := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, hrp[i]>>5)
+	}
+	res = append(res, 0)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, hrp[i]&31)
+	}
+	return res
+}
+
+// bech32Encode encodes the 5-bit data with the checksum constant of bech32 or bech32m
+func bech32Encode(hrp string, data []byte, checksumConst uint32) (string, error) {
+	if len(hrp) == 0 || len(hrp)+len(data)+7 > 90 {
+		return "", errors.New("bech32: invalid length")
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 || (hrp[i] >= 'A' && hrp[i] <= 'Z') {
+			return "", fmt.Errorf("bech32: invalid character in the human-readable part: %q", hrp[i])
+		}
+	}
+	values := append(bech32HRPExpand(hrp), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ checksumConst
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range data {
+		if d > 31 {
+			return "", errors.New("bech32: data is not 5-bit")
+		}
+		sb.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return sb.String(), nil
+}
+
+// bech32Decode returns the human-readable part and the 5-bit data of a bech32 or bech32m string, with the checksum constant it was encoded with
+func bech32Decode(s string) (hrp string, data []byte, checksumConst uint32, err error) {
+	if len(s) < 8 || len(s) > 90 {
+		return "", nil, 0, errors.New("bech32: invalid length")
+	}
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, 0, errors.New("bech32: mixed case")
+	}
+	s = strings.ToLower(s)
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, 0, errors.New("bech32: invalid separator position")
+	}
+	hrp = s[:pos]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, fmt.Errorf("bech32: invalid character in the human-readable part: %q", hrp[i])
+		}
+	}
+	data = make([]byte, 0, len(s)-pos-1)
+	for i := pos + 1; i < len(s); i++ {
+		d := strings.IndexByte(bech32Charset, s[i])
+		if d < 0 {
+			return "", nil, 0, fmt.Errorf("bech32: invalid character in the data part: %q", s[i])
+		}
+		data = append(data, byte(d))
+	}
+	switch checksumConst = bech32Polymod(append(bech32HRPExpand(hrp), data...)); checksumConst {
+	case bech32Const, bech32mConst:
+	default:
+		return "", nil, 0, errors.New("bech32: invalid checksum")
+	}
+	return hrp, data[:len(data)-6], checksumConst, nil
+}
+
+// bech32ConvertBits regroups the bits of data from fromBits to toBits per element
+func bech32ConvertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	acc, bits := uint32(0), uint(0)
+	maxV := uint32(1)<<toBits - 1
+	res := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, d := range data {
+		if uint32(d)>>fromBits != 0 {
+			return nil, errors.New("bech32: invalid data range")
+		}
+		acc = acc<<fromBits | uint32(d)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			res = append(res, byte(acc>>bits&maxV))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			res = append(res, byte(acc<<(toBits-bits)&maxV))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxV != 0 {
+		return nil, errors.New("bech32: invalid padding")
+	}
+	return res, nil
+}
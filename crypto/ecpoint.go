@@ -58,6 +58,10 @@ func NewECPointFromProtobuf(p *common.ECPoint) (*ECPoint, error) {
 	return NewECPoint(tss.EC(), new(big.Int).SetBytes(p.GetX()), new(big.Int).SetBytes(p.GetY()))
 }
 
+func (p *ECPoint) Curve() elliptic.Curve {
+	return p.curve
+}
+
 func (p *ECPoint) X() *big.Int {
 	return new(big.Int).Set(p.coords[0])
 }
@@ -351,14 +355,25 @@ func (p *ECPoint) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// The optional curve name written by the upstream bnb-chain tss-lib is also accepted, but it must name tss.EC()
 func (p *ECPoint) UnmarshalJSON(payload []byte) error {
 	aux := &struct {
+		Curve  string
 		Coords [2]*big.Int
 	}{}
 	if err := json.Unmarshal(payload, &aux); err != nil {
 		return err
 	}
 	p.curve = tss.EC()
+	if aux.Curve != "" {
+		curve, ok := tss.GetCurveByName(tss.CurveName(aux.Curve))
+		if !ok {
+			return fmt.Errorf("ECPoint.UnmarshalJSON: unknown curve %q", aux.Curve)
+		}
+		if !tss.SameCurve(curve, p.curve) {
+			return fmt.Errorf("ECPoint.UnmarshalJSON: the point is on curve %q, not on tss.EC()", aux.Curve)
+		}
+	}
 	p.coords = [2]*big.Int{aux.Coords[0], aux.Coords[1]}
 	if !p.IsOnCurve() {
 		return errors.New("ECPoint.UnmarshalJSON: the point is not on the elliptic curve")
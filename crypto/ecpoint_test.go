@@ -7,6 +7,9 @@
 package crypto_test
 
 import (
+	"crypto/elliptic"
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
@@ -113,3 +116,39 @@ func TestUnFlattenECPoints(t *testing.T) {
 		})
 	}
 }
+
+func TestECPointUnmarshalJSONCurve(t *testing.T) {
+	p256 := ScalarBaseMult(elliptic.P256(), big.NewInt(1))
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{{
+		name: "no curve name (happy)",
+		json: fmt.Sprintf(`{"Coords":[%s,%s]}`, tss.EC().Params().Gx, tss.EC().Params().Gy),
+	}, {
+		name: "curve name of tss.EC() (happy)",
+		json: fmt.Sprintf(`{"Curve":"secp256k1","Coords":[%s,%s]}`, tss.EC().Params().Gx, tss.EC().Params().Gy),
+	}, {
+		name:    "point of another curve (expects err)",
+		json:    fmt.Sprintf(`{"Curve":"nist256p1","Coords":[%s,%s]}`, p256.X(), p256.Y()),
+		wantErr: true,
+	}, {
+		name:    "unknown curve name (expects err)",
+		json:    fmt.Sprintf(`{"Curve":"foo","Coords":[%s,%s]}`, tss.EC().Params().Gx, tss.EC().Params().Gy),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p ECPoint
+			err := json.Unmarshal([]byte(tt.json), &p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ECPoint.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !tss.SameCurve(p.Curve(), tss.EC()) {
+				t.Errorf("ECPoint.UnmarshalJSON() curve = %v, want tss.EC()", p.Curve().Params().Name)
+			}
+		})
+	}
+}
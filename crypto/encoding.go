@@ -0,0 +1,343 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Public key and address encodings used by blockchains.
+// Addresses made from a hash of the key (Ethereum, P2PKH, P2WPKH, Cosmos) cannot be parsed back into a point;
+// their parsers return the hash, which may be compared to the Hash160 or EthereumAddress of a known point.
+
+const (
+	BitcoinMainNetP2PKHVersion byte = 0x00
+	BitcoinTestNetP2PKHVersion byte = 0x6f
+)
+
+// ----- SEC1
+
+// SEC1Compressed returns the 33-byte SEC1 encoding 0x02/0x03 || X of a secp256k1 or P-256 point
+func (p *ECPoint) SEC1Compressed() ([]byte, error) {
+	if err := p.checkWeierstrass(); err != nil {
+		return nil, err
+	}
+	return append([]byte{0x02 | byte(p.coords[1].Bit(0))}, p.paddedCoord(0)...), nil
+}
+
+// SEC1Uncompressed returns the 65-byte SEC1 encoding 0x04 || X || Y of a secp256k1 or P-256 point
+func (p *ECPoint) SEC1Uncompressed() ([]byte, error) {
+	if err := p.checkWeierstrass(); err != nil {
+		return nil, err
+	}
+	return append([]byte{0x04}, p.Bytes()...), nil
+}
+
+// NewECPointFromSEC1 parses a compressed or uncompressed SEC1 encoding of a point on a secp256k1 or P-256 curve
+func NewECPointFromSEC1(curve elliptic.Curve, bz []byte) (*ECPoint, error) {
+	byteSize := (curve.Params().BitSize + 7) / 8
+	switch {
+	case len(bz) == 1+byteSize && (bz[0] == 0x02 || bz[0] == 0x03):
+		x := new(big.Int).SetBytes(bz[1:])
+		if x.Cmp(curve.Params().P) >= 0 {
+			return nil, errors.New("NewECPointFromSEC1: x is out of range")
+		}
+		p, err := DecompressPoint(curve, x, bz[0])
+		if err != nil {
+			return nil, err
+		}
+		return NewECPoint(curve, p.X(), p.Y())
+	case len(bz) == 1+2*byteSize && bz[0] == 0x04:
+		return NewECPoint(curve, new(big.Int).SetBytes(bz[1:1+byteSize]), new(big.Int).SetBytes(bz[1+byteSize:]))
+	default:
+		return nil, errors.New("NewECPointFromSEC1: invalid encoding")
+	}
+}
+
+// ----- secp256k1 hashes and addresses
+
+// Hash160 returns RIPEMD160(SHA256(SEC1Compressed)) of a secp256k1 point, as used by P2PKH, P2WPKH and Cosmos addresses
+func (p *ECPoint) Hash160() ([]byte, error) {
+	if err := p.checkSecp256k1(); err != nil {
+		return nil, err
+	}
+	bz, _ := p.SEC1Compressed()
+	sha := sha256.Sum256(bz)
+	h := ripemd160.New()
+	_, _ = h.Write(sha[:])
+	return h.Sum(nil), nil
+}
+
+// EthereumAddress returns the EIP-55 checksummed address of a secp256k1 point, the last 20 bytes of Keccak-256(X || Y)
+func (p *ECPoint) EthereumAddress() (string, error) {
+	if err := p.checkSecp256k1(); err != nil {
+		return "", err
+	}
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write(p.Bytes())
+	return ethereumChecksumAddress(h.Sum(nil)[12:]), nil
+}
+
+// ParseEthereumAddress returns the 20 bytes of an Ethereum address. The EIP-55 checksum is verified if the address is mixed case.
+func ParseEthereumAddress(addr string) ([]byte, error) {
+	if !strings.HasPrefix(addr, "0x") || len(addr) != 42 {
+		return nil, errors.New("ParseEthereumAddress: expected 0x followed by 40 hex characters")
+	}
+	bz, err := hex.DecodeString(addr[2:])
+	if err != nil {
+		return nil, fmt.Errorf("ParseEthereumAddress: %v", err)
+	}
+	hexPart := addr[2:]
+	if strings.ToLower(hexPart) != hexPart && strings.ToUpper(hexPart) != hexPart && ethereumChecksumAddress(bz) != addr {
+		return nil, errors.New("ParseEthereumAddress: invalid EIP-55 checksum")
+	}
+	return bz, nil
+}
+
+// BitcoinP2PKHAddress returns the Base58Check P2PKH address of a secp256k1 point with the compressed key, e.g. with BitcoinMainNetP2PKHVersion
+func (p *ECPoint) BitcoinP2PKHAddress(version byte) (string, error) {
+	h, err := p.Hash160()
+	if err != nil {
+		return "", err
+	}
+	return base58CheckEncode(version, h), nil
+}
+
+// ParseBitcoinP2PKHAddress returns the version byte and the 20-byte key hash of a P2PKH address
+func ParseBitcoinP2PKHAddress(addr string) (version byte, hash []byte, err error) {
+	if version, hash, err = base58CheckDecode(addr); err != nil {
+		return 0, nil, err
+	}
+	if len(hash) != ripemd160.Size {
+		return 0, nil, errors.New("ParseBitcoinP2PKHAddress: invalid key hash length")
+	}
+	return version, hash, nil
+}
+
+// BitcoinP2WPKHAddress returns the bech32 segwit v0 address of a secp256k1 point, with an hrp such as "bc" or "tb"
+func (p *ECPoint) BitcoinP2WPKHAddress(hrp string) (string, error) {
+	h, err := p.Hash160()
+	if err != nil {
+		return "", err
+	}
+	return segwitEncode(hrp, 0, h)
+}
+
+// ParseBitcoinP2WPKHAddress returns the hrp and the 20-byte key hash of a P2WPKH address
+func ParseBitcoinP2WPKHAddress(addr string) (hrp string, hash []byte, err error) {
+	hrp, version, program, err := segwitDecode(addr)
+	if err != nil {
+		return "", nil, err
+	}
+	if version != 0 || len(program) != ripemd160.Size {
+		return "", nil, errors.New("ParseBitcoinP2WPKHAddress: not a P2WPKH address")
+	}
+	return hrp, program, nil
+}
+
+// XOnly returns the 32-byte x-only encoding of a secp256k1 point used by BIP-340 and P2TR
+func (p *ECPoint) XOnly() ([]byte, error) {
+	if err := p.checkSecp256k1(); err != nil {
+		return nil, err
+	}
+	return p.paddedCoord(0), nil
+}
+
+// NewECPointFromXOnly parses a 32-byte x-only key as the secp256k1 point with an even Y
+func NewECPointFromXOnly(bz []byte) (*ECPoint, error) {
+	if len(bz) != 32 {
+		return nil, errors.New("NewECPointFromXOnly: expected 32 bytes")
+	}
+	return NewECPointFromSEC1(btcec.S256(), append([]byte{0x02}, bz...))
+}
+
+// BitcoinP2TRAddressUntweaked returns the bech32m segwit v1 address that uses the x-only key of a secp256k1 point as the
+// output key as is, with an hrp such as "bc" or "tb". No taproot tweak is applied: a BIP86 wallet shows another address for
+// the same internal key. Pass the output key of schnorr/signing.TaprootOutputKey for the address that
+// NewTaprootLocalParty signs for, or the threshold key itself for the address that NewLocalParty signs for.
+func (p *ECPoint) BitcoinP2TRAddressUntweaked(hrp string) (string, error) {
+	x, err := p.XOnly()
+	if err != nil {
+		return "", err
+	}
+	return segwitEncode(hrp, 1, x)
+}
+
+// ParseBitcoinP2TRAddress returns the hrp and the output key of a P2TR address
+func ParseBitcoinP2TRAddress(addr string) (hrp string, key *ECPoint, err error) {
+	hrp, version, program, err := segwitDecode(addr)
+	if err != nil {
+		return "", nil, err
+	}
+	if version != 1 || len(program) != 32 {
+		return "", nil, errors.New("ParseBitcoinP2TRAddress: not a P2TR address")
+	}
+	if key, err = NewECPointFromXOnly(program); err != nil {
+		return "", nil, err
+	}
+	return hrp, key, nil
+}
+
+// CosmosAddress returns the bech32 account address of a secp256k1 point with an hrp such as "cosmos"
+func (p *ECPoint) CosmosAddress(hrp string) (string, error) {
+	h, err := p.Hash160()
+	if err != nil {
+		return "", err
+	}
+	data, err := bech32ConvertBits(h, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32Encode(hrp, data, bech32Const)
+}
+
+// ParseCosmosAddress returns the hrp and the 20-byte key hash of a Cosmos account address
+func ParseCosmosAddress(addr string) (hrp string, hash []byte, err error) {
+	hrp, data, checksumConst, err := bech32Decode(addr)
+	if err != nil {
+		return "", nil, err
+	}
+	if checksumConst != bech32Const {
+		return "", nil, errors.New("ParseCosmosAddress: expected a bech32 checksum")
+	}
+	if hash, err = bech32ConvertBits(data, 5, 8, false); err != nil {
+		return "", nil, err
+	}
+	if len(hash) != ripemd160.Size {
+		return "", nil, errors.New("ParseCosmosAddress: invalid key hash length")
+	}
+	return hrp, hash, nil
+}
+
+// ----- Ed25519
+
+// Ed25519Bytes returns the 32-byte RFC 8032 encoding of an Ed25519 point
+func (p *ECPoint) Ed25519Bytes() ([]byte, error) {
+	if !tss.SameCurve(p.curve, edwards.Edwards()) {
+		return nil, errors.New("Ed25519Bytes: the point is not on the Ed25519 curve")
+	}
+	return edwards.PublicKey{Curve: p.curve, X: p.X(), Y: p.Y()}.Serialize(), nil
+}
+
+// NewECPointFromEd25519 parses the 32-byte RFC 8032 encoding of an Ed25519 point
+func NewECPointFromEd25519(bz []byte) (*ECPoint, error) {
+	if len(bz) != edwards.PubKeyBytesLen {
+		return nil, fmt.Errorf("NewECPointFromEd25519: expected %d bytes", edwards.PubKeyBytesLen)
+	}
+	pk, err := edwards.ParsePubKey(bz)
+	if err != nil {
+		return nil, err
+	}
+	return NewECPoint(edwards.Edwards(), pk.X, pk.Y)
+}
+
+// SolanaAddress returns the base58 encoding of the Ed25519 bytes of a point
+func (p *ECPoint) SolanaAddress() (string, error) {
+	bz, err := p.Ed25519Bytes()
+	if err != nil {
+		return "", err
+	}
+	return base58Encode(bz), nil
+}
+
+// ParseSolanaAddress parses a Solana address into an Ed25519 point
+func ParseSolanaAddress(addr string) (*ECPoint, error) {
+	bz, err := base58Decode(addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewECPointFromEd25519(bz)
+}
+
+// ----- utils
+
+func (p *ECPoint) checkWeierstrass() error {
+	if !tss.SameCurve(p.curve, btcec.S256()) && !tss.SameCurve(p.curve, elliptic.P256()) {
+		return errors.New("SEC1 encodings are only supported for secp256k1 and P-256 points")
+	}
+	return nil
+}
+
+func (p *ECPoint) checkSecp256k1() error {
+	if !tss.SameCurve(p.curve, btcec.S256()) {
+		return errors.New("the point is not on the secp256k1 curve")
+	}
+	return nil
+}
+
+// paddedCoord returns the big-endian coordinate i padded to the byte size of the curve
+func (p *ECPoint) paddedCoord(i int) []byte {
+	byteSize := (p.curve.Params().BitSize + 7) / 8
+	bz := p.coords[i].Bytes()
+	return append(make([]byte, byteSize-len(bz), byteSize), bz...)
+}
+
+func ethereumChecksumAddress(addr []byte) string {
+	lower := hex.EncodeToString(addr)
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write([]byte(lower))
+	hash := h.Sum(nil)
+	res := []byte(lower)
+	for i := range res {
+		// upper-case a letter if the matching nibble of the hash is 8 or more
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if res[i] >= 'a' && nibble >= 8 {
+			res[i] -= 'a' - 'A'
+		}
+	}
+	return "0x" + string(res)
+}
+
+// segwitEncode encodes a segwit address, with bech32 for version 0 and bech32m for later versions (BIP-350)
+func segwitEncode(hrp string, version byte, program []byte) (string, error) {
+	data, err := bech32ConvertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	checksumConst := uint32(bech32mConst)
+	if version == 0 {
+		checksumConst = bech32Const
+	}
+	return bech32Encode(hrp, append([]byte{version}, data...), checksumConst)
+}
+
+func segwitDecode(addr string) (hrp string, version byte, program []byte, err error) {
+	hrp, data, checksumConst, err := bech32Decode(addr)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(data) < 1 || data[0] > 16 {
+		return "", 0, nil, errors.New("segwit: invalid witness version")
+	}
+	version = data[0]
+	if (version == 0) != (checksumConst == bech32Const) {
+		return "", 0, nil, errors.New("segwit: the checksum does not match the witness version")
+	}
+	if program, err = bech32ConvertBits(data[1:], 5, 8, false); err != nil {
+		return "", 0, nil, err
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return "", 0, nil, errors.New("segwit: invalid program length")
+	}
+	return hrp, version, program, nil
+}
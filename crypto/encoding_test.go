@@ -0,0 +1,212 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustHex(s string) []byte {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func TestSecp256k1Encodings(t *testing.T) {
+	// the public key of the private key 1 is the generator
+	G := ScalarBaseMult(btcec.S256(), big.NewInt(1))
+
+	compressed, err := G.SEC1Compressed()
+	assert.NoError(t, err)
+	assert.Equal(t, "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", hex.EncodeToString(compressed))
+	uncompressed, err := G.SEC1Uncompressed()
+	assert.NoError(t, err)
+	assert.Equal(t, "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"+
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", hex.EncodeToString(uncompressed))
+	for _, bz := range [][]byte{compressed, uncompressed} {
+		p, err := NewECPointFromSEC1(btcec.S256(), bz)
+		assert.NoError(t, err)
+		assert.True(t, p.Equals(G))
+	}
+
+	addr, err := G.EthereumAddress()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", addr)
+
+	h160, err := G.Hash160()
+	assert.NoError(t, err)
+	assert.Equal(t, "751e76e8199196d454941c45d1b3a323f1433bd6", hex.EncodeToString(h160))
+
+	addr, err = G.BitcoinP2PKHAddress(BitcoinMainNetP2PKHVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", addr)
+	version, hash, err := ParseBitcoinP2PKHAddress(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, BitcoinMainNetP2PKHVersion, version)
+	assert.Equal(t, h160, hash)
+
+	addr, err = G.BitcoinP2WPKHAddress("bc")
+	assert.NoError(t, err)
+	assert.Equal(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", addr)
+	hrp, hash, err := ParseBitcoinP2WPKHAddress(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, "bc", hrp)
+	assert.Equal(t, h160, hash)
+
+	addr, err = G.BitcoinP2TRAddressUntweaked("bc")
+	assert.NoError(t, err)
+	assert.Equal(t, "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", addr)
+	hrp, key, err := ParseBitcoinP2TRAddress(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, "bc", hrp)
+	assert.True(t, key.Equals(G))
+
+	addr, err = G.CosmosAddress("cosmos")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(addr, "cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k"))
+	hrp, hash, err = ParseCosmosAddress(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, "cosmos", hrp)
+	assert.Equal(t, h160, hash)
+
+	// a segwit address of the wrong kind is rejected
+	_, _, err = ParseBitcoinP2WPKHAddress("bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0")
+	assert.Error(t, err)
+	_, _, err = ParseBitcoinP2TRAddress("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4")
+	assert.Error(t, err)
+}
+
+func TestEthereumAddress(t *testing.T) {
+	x, _ := new(big.Int).SetString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318", 16)
+	addr, err := ScalarBaseMult(btcec.S256(), x).EthereumAddress()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23", addr)
+
+	// EIP-55 test vectors
+	for _, addr := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		bz, err := ParseEthereumAddress(addr)
+		assert.NoError(t, err, addr)
+		assert.Equal(t, addr, ethereumChecksumAddress(bz))
+		_, err = ParseEthereumAddress(strings.ToLower(addr))
+		assert.NoError(t, err, "an all lower case address has no checksum")
+		_, err = ParseEthereumAddress(strings.Replace(addr, "a", "A", 1))
+		assert.Error(t, err, "a bad checksum should be rejected")
+	}
+}
+
+func TestP256SEC1(t *testing.T) {
+	p := ScalarBaseMult(elliptic.P256(), big.NewInt(12345))
+	for _, encode := range []func() ([]byte, error){p.SEC1Compressed, p.SEC1Uncompressed} {
+		bz, err := encode()
+		assert.NoError(t, err)
+		p2, err := NewECPointFromSEC1(elliptic.P256(), bz)
+		assert.NoError(t, err)
+		assert.True(t, p2.Equals(p))
+	}
+	_, err := p.EthereumAddress()
+	assert.Error(t, err, "Ethereum addresses are only defined for secp256k1")
+}
+
+func TestEd25519Encodings(t *testing.T) {
+	// RFC 8032 7.1 test 1
+	_, pk := edwards.PrivKeyFromSecret(mustHex("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"))
+	p, err := NewECPoint(edwards.Edwards(), pk.X, pk.Y)
+	assert.NoError(t, err)
+	bz, err := p.Ed25519Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a", hex.EncodeToString(bz))
+	p2, err := NewECPointFromEd25519(bz)
+	assert.NoError(t, err)
+	assert.True(t, p2.Equals(p))
+
+	addr, err := p.SolanaAddress()
+	assert.NoError(t, err)
+	assert.Equal(t, base58Encode(bz), addr)
+	p3, err := ParseSolanaAddress(addr)
+	assert.NoError(t, err)
+	assert.True(t, p3.Equals(p))
+
+	_, err = p.SEC1Compressed()
+	assert.Error(t, err, "SEC1 is not defined for Ed25519")
+}
+
+func TestBase58(t *testing.T) {
+	// from the Bitcoin Core test vectors
+	vectors := [][2]string{
+		{"", ""},
+		{"61", "2g"},
+		{"626262", "a3gV"},
+		{"636363", "aPEr"},
+		{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+		{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+		{"516b6fcd0f", "ABnLTmg"},
+		{"bf4f89001e670274dd", "3SEo3LWLoPntC"},
+		{"572e4794", "3EFU7m"},
+		{"ecac89cad93923c02321", "EJDM8drfXA6uyA"},
+		{"10c8511e", "Rt5zm"},
+		{"00000000000000000000", "1111111111"},
+	}
+	for _, v := range vectors {
+		assert.Equal(t, v[1], base58Encode(mustHex(v[0])))
+		bz, err := base58Decode(v[1])
+		assert.NoError(t, err)
+		assert.Equal(t, v[0], hex.EncodeToString(bz))
+	}
+	_, err := base58Decode("0OIl")
+	assert.Error(t, err)
+}
+
+func TestBech32(t *testing.T) {
+	// from BIP-173 and BIP-350
+	for _, s := range []string{
+		"A12UEL5L",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+	} {
+		hrp, data, checksumConst, err := bech32Decode(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, uint32(bech32Const), checksumConst)
+		encoded, err := bech32Encode(hrp, data, bech32Const)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.ToLower(s), encoded)
+	}
+	for _, s := range []string{
+		"A1LQFN3A",
+		"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx",
+		"split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+	} {
+		hrp, data, checksumConst, err := bech32Decode(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, uint32(bech32mConst), checksumConst)
+		encoded, err := bech32Encode(hrp, data, bech32mConst)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.ToLower(s), encoded)
+	}
+	for _, s := range []string{
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e2w", // bad checksum
+		"A12uEL5L",                               // mixed case
+		"1pzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", // empty hrp
+	} {
+		_, _, _, err := bech32Decode(s)
+		assert.Error(t, err, s)
+	}
+}
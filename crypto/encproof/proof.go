@@ -0,0 +1,153 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Zero-knowledge proof that a Paillier ciphertext K = enc_N0(k; rho) encrypts a small plaintext k < 2^l, where l is the
+// bit length of the curve order. The proof is made for the Ring-Pedersen parameters (NCap, s, t) of the verifier.
+// Canetti, R., Gennaro, R., Goldfeder, S., Makriyannis, N., Peled, U.: UC Non-Interactive, Proactive, Threshold ECDSA
+// with Identifiable Aborts (CGGMP21), Fig. 14.
+
+package encproof
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+)
+
+const (
+	ProofEncBytesParts = 6
+)
+
+type (
+	ProofEnc struct {
+		S, A, C, Z1, Z2, Z3 *big.Int
+	}
+)
+
+var (
+	one = big.NewInt(1)
+)
+
+// NewProof implements proofEnc for K = (1 + N0)^k * rho^N0 mod N0^2, where pk is the Paillier key with modulus N0.
+// The session binds the proof to the prover and its protocol session; see tss.ProofSession.
+func NewProof(session *big.Int, ec elliptic.Curve, pk *paillier.PublicKey, K, NCap, s, t, k, rho *big.Int) (*ProofEnc, error) {
+	if session == nil || ec == nil || pk == nil || K == nil || NCap == nil || s == nil || t == nil || k == nil || rho == nil {
+		return nil, errors.New("ProveEnc constructor received nil value(s)")
+	}
+	q := ec.Params().N
+	l, eps := q.BitLen(), 2*q.BitLen()
+	N0, NSq := pk.N, pk.NSquare()
+
+	// Fig 14.1 sample
+	alpha := common.GetRandomPositiveInt(new(big.Int).Lsh(one, uint(l+eps)))
+	mu := common.GetRandomPositiveInt(new(big.Int).Lsh(NCap, uint(l)))
+	r := common.GetRandomPositiveRelativelyPrimeInt(N0)
+	gamma := common.GetRandomPositiveInt(new(big.Int).Lsh(NCap, uint(l+eps)))
+
+	// Fig 14.1 compute
+	modNCap, modNSq := common.ModInt(NCap), common.ModInt(NSq)
+	S := modNCap.Mul(modNCap.Exp(s, k), modNCap.Exp(t, mu))
+	A := modNSq.Mul(modNSq.Exp(pk.Gamma(), alpha), modNSq.Exp(r, N0))
+	C := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+
+	// Fig 14.2 e
+	e := challenge(q, session, N0, K, NCap, s, t, S, A, C)
+
+	// Fig 14.3
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, k))
+	z2 := common.ModInt(N0).Mul(r, common.ModInt(N0).Exp(rho, e))
+	z3 := new(big.Int).Add(gamma, new(big.Int).Mul(e, mu))
+
+	return &ProofEnc{S: S, A: A, C: C, Z1: z1, Z2: z2, Z3: z3}, nil
+}
+
+func NewProofFromBytes(bzs [][]byte) (*ProofEnc, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofEncBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofEnc", ProofEncBytesParts)
+	}
+	return &ProofEnc{
+		S:  new(big.Int).SetBytes(bzs[0]),
+		A:  new(big.Int).SetBytes(bzs[1]),
+		C:  new(big.Int).SetBytes(bzs[2]),
+		Z1: new(big.Int).SetBytes(bzs[3]),
+		Z2: new(big.Int).SetBytes(bzs[4]),
+		Z3: new(big.Int).SetBytes(bzs[5]),
+	}, nil
+}
+
+func (pf *ProofEnc) Verify(session *big.Int, ec elliptic.Curve, pk *paillier.PublicKey, K, NCap, s, t *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || session == nil || ec == nil || pk == nil || pk.N == nil || K == nil || NCap == nil || s == nil || t == nil {
+		return false
+	}
+	if pk.N.Sign() != 1 || NCap.Sign() != 1 {
+		return false
+	}
+	q := ec.Params().N
+	l, eps := q.BitLen(), 2*q.BitLen()
+	N0, NSq := pk.N, pk.NSquare()
+
+	if !common.IsInGroup(K, NSq) || !common.IsInGroup(pf.A, NSq) || !common.IsInGroup(pf.Z2, N0) {
+		return false
+	}
+	if !common.IsInGroup(pf.S, NCap) || !common.IsInGroup(pf.C, NCap) {
+		return false
+	}
+
+	// Fig 14. Range Check; the slack of one bit covers e * k
+	if !common.IsInInterval(pf.Z1, new(big.Int).Lsh(one, uint(l+eps+1))) {
+		return false
+	}
+
+	e := challenge(q, session, N0, K, NCap, s, t, pf.S, pf.A, pf.C)
+
+	// Fig 14. Equality Check
+	modNCap, modNSq := common.ModInt(NCap), common.ModInt(NSq)
+	{
+		LHS := modNSq.Mul(modNSq.Exp(pk.Gamma(), pf.Z1), modNSq.Exp(pf.Z2, N0))
+		RHS := modNSq.Mul(pf.A, modNSq.Exp(K, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	{
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.C, modNCap.Exp(pf.S, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofEnc) ValidateBasic() bool {
+	return pf.S != nil &&
+		pf.A != nil &&
+		pf.C != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil
+}
+
+func (pf *ProofEnc) Bytes() [ProofEncBytesParts][]byte {
+	return [...][]byte{
+		pf.S.Bytes(),
+		pf.A.Bytes(),
+		pf.C.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+		pf.Z3.Bytes(),
+	}
+}
+
+// challenge derives e from the session, the statement and the first messages of the proof (Fiat-Shamir)
+func challenge(q *big.Int, in ...*big.Int) *big.Int {
+	eHash := common.SHA512_256i(in...)
+	return common.RejectionSample(q, eHash)
+}
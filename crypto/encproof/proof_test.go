@@ -0,0 +1,67 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package encproof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Using a modulus length of 2048 is recommended in the GG18 spec
+const (
+	testPrimeBits = 1024
+)
+
+var (
+	testSession = big.NewInt(42)
+)
+
+func TestEnc(test *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+
+	pk := &paillier.PublicKey{N: new(big.Int).Mul(common.GetRandomPrimeInt(testPrimeBits), common.GetRandomPrimeInt(testPrimeBits))}
+	primes := [2]*big.Int{common.GetRandomPrimeInt(testPrimeBits), common.GetRandomPrimeInt(testPrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(primes)
+	assert.NoError(test, err)
+
+	k := common.GetRandomPositiveInt(q)
+	K, rho, err := pk.EncryptAndReturnRandomness(k)
+	assert.NoError(test, err)
+
+	proof, err := NewProof(testSession, ec, pk, K, NCap, s, t, k, rho)
+	assert.NoError(test, err)
+	assert.True(test, proof.Verify(testSession, ec, pk, K, NCap, s, t), "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(bzs[:])
+	assert.NoError(test, err)
+	assert.True(test, proof2.Verify(testSession, ec, pk, K, NCap, s, t), "proof must verify after a round trip")
+
+	// another session
+	assert.False(test, proof.Verify(big.NewInt(43), ec, pk, K, NCap, s, t), "proof must not verify for another session")
+
+	// another ciphertext
+	K2, err := pk.Encrypt(k)
+	assert.NoError(test, err)
+	assert.False(test, proof.Verify(testSession, ec, pk, K2, NCap, s, t), "proof must not verify for another ciphertext")
+
+	// a plaintext that is too large
+	big1 := new(big.Int).Lsh(big.NewInt(1), 1000)
+	K3, rho3, err := pk.EncryptAndReturnRandomness(big1)
+	assert.NoError(test, err)
+	proof, err = NewProof(testSession, ec, pk, K3, NCap, s, t, big1, rho3)
+	assert.NoError(test, err)
+	assert.False(test, proof.Verify(testSession, ec, pk, K3, NCap, s, t), "proof must not verify for a large plaintext")
+}
@@ -0,0 +1,168 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	Version = 1
+
+	KDFScrypt    = "scrypt"
+	CipherAESGCM = "aes-256-gcm"
+
+	// shares are decrypted rarely so a high scrypt cost is used by default
+	DefaultScryptN = 1 << 18
+	DefaultScryptR = 8
+	DefaultScryptP = 1
+
+	maxScryptMemory = 1 << 30 // bounds the memory used when opening an untrusted keystore
+	saltLen         = 32
+	keyLen          = 32
+)
+
+type (
+	// Header holds the public fields of a share; it is stored in the clear and authenticated by the cipher.
+	Header struct {
+		Version    int        `json:"version"`
+		Scheme     string     `json:"scheme"` // "ecdsa" or "eddsa"
+		ShareID    *big.Int   `json:"share_id"`
+		Ks         []*big.Int `json:"ks"`
+		PublicKeyX *big.Int   `json:"public_key_x"`
+		PublicKeyY *big.Int   `json:"public_key_y"`
+	}
+
+	CryptoParams struct {
+		KDF        string `json:"kdf"`
+		Salt       []byte `json:"salt"`
+		N          int    `json:"n"`
+		R          int    `json:"r"`
+		P          int    `json:"p"`
+		Cipher     string `json:"cipher"`
+		Nonce      []byte `json:"nonce"`
+		Ciphertext []byte `json:"ciphertext"`
+	}
+
+	// Keystore is the serialized form of an encrypted share.
+	Keystore struct {
+		Header Header       `json:"header"`
+		Crypto CryptoParams `json:"crypto"`
+	}
+)
+
+var (
+	ErrDecrypt = errors.New("keystore: could not decrypt; wrong passphrase or corrupt data")
+)
+
+// Seal encrypts the secret bytes of a share with a key derived from the passphrase and returns the keystore JSON.
+func Seal(header Header, secret, passphrase []byte) ([]byte, error) {
+	return SealWithScryptParams(header, secret, passphrase, DefaultScryptN, DefaultScryptR, DefaultScryptP)
+}
+
+// SealWithScryptParams works like Seal but allows a custom scrypt cost, i.e. for tests.
+func SealWithScryptParams(header Header, secret, passphrase []byte, N, r, p int) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("keystore: passphrase must not be empty")
+	}
+	header.Version = Version
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	params := CryptoParams{KDF: KDFScrypt, Salt: salt, N: N, R: r, P: p, Cipher: CipherAESGCM}
+	aead, err := params.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	params.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(params.Nonce); err != nil {
+		return nil, err
+	}
+	ad, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	params.Ciphertext = aead.Seal(nil, params.Nonce, secret, ad)
+	return json.Marshal(&Keystore{Header: header, Crypto: params})
+}
+
+// Open decrypts a keystore created by Seal and returns its header along with the secret bytes.
+func Open(blob, passphrase []byte) (*Header, []byte, error) {
+	ks, err := parse(blob)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := ks.Crypto.aead(passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ks.Crypto.Nonce) != aead.NonceSize() {
+		return nil, nil, errors.New("keystore: invalid nonce length")
+	}
+	ad, err := json.Marshal(ks.Header)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret, err := aead.Open(nil, ks.Crypto.Nonce, ks.Crypto.Ciphertext, ad)
+	if err != nil {
+		return nil, nil, ErrDecrypt
+	}
+	return &ks.Header, secret, nil
+}
+
+// ReadHeader returns the public header of a keystore without decrypting it.
+func ReadHeader(blob []byte) (*Header, error) {
+	ks, err := parse(blob)
+	if err != nil {
+		return nil, err
+	}
+	return &ks.Header, nil
+}
+
+func parse(blob []byte) (*Keystore, error) {
+	ks := new(Keystore)
+	if err := json.Unmarshal(blob, ks); err != nil {
+		return nil, fmt.Errorf("keystore: %v", err)
+	}
+	if ks.Header.Version != Version {
+		return nil, fmt.Errorf("keystore: unsupported version %d", ks.Header.Version)
+	}
+	return ks, nil
+}
+
+func (params CryptoParams) aead(passphrase []byte) (cipher.AEAD, error) {
+	if params.KDF != KDFScrypt {
+		return nil, fmt.Errorf("keystore: unsupported kdf %q", params.KDF)
+	}
+	if params.Cipher != CipherAESGCM {
+		return nil, fmt.Errorf("keystore: unsupported cipher %q", params.Cipher)
+	}
+	if params.N < 1 || params.R < 1 || params.P < 1 || maxScryptMemory/128/params.R < params.N {
+		return nil, errors.New("keystore: invalid or too costly scrypt parameters")
+	}
+	if len(params.Salt) != saltLen {
+		return nil, errors.New("keystore: invalid salt length")
+	}
+	key, err := scrypt.Key(passphrase, params.Salt, params.N, params.R, params.P, keyLen)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
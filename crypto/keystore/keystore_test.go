@@ -0,0 +1,89 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keystore_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeta-chain/tss-lib/crypto/keystore"
+)
+
+const (
+	// a low scrypt cost keeps the tests fast
+	testScryptN = 1 << 10
+)
+
+func testHeader() keystore.Header {
+	return keystore.Header{
+		Scheme:     "ecdsa",
+		ShareID:    big.NewInt(42),
+		Ks:         []*big.Int{big.NewInt(41), big.NewInt(42), big.NewInt(43)},
+		PublicKeyX: big.NewInt(7),
+		PublicKeyY: big.NewInt(8),
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	secret := []byte("the secret share")
+	blob, err := keystore.SealWithScryptParams(testHeader(), secret, []byte("passphrase"), testScryptN, 8, 1)
+	assert.NoError(t, err)
+	assert.False(t, bytes.Contains(blob, secret), "the secret must not appear in the clear")
+
+	header, err := keystore.ReadHeader(blob)
+	assert.NoError(t, err)
+	assert.Equal(t, keystore.Version, header.Version)
+	assert.Equal(t, "ecdsa", header.Scheme)
+	assert.Equal(t, 0, header.ShareID.Cmp(big.NewInt(42)))
+	assert.Equal(t, 3, len(header.Ks))
+
+	header, opened, err := keystore.Open(blob, []byte("passphrase"))
+	assert.NoError(t, err)
+	assert.Equal(t, secret, opened)
+	assert.Equal(t, 0, header.PublicKeyX.Cmp(big.NewInt(7)))
+
+	_, _, err = keystore.Open(blob, []byte("wrong passphrase"))
+	assert.Equal(t, keystore.ErrDecrypt, err)
+}
+
+func TestOpenRejectsTamperedHeader(t *testing.T) {
+	blob, err := keystore.SealWithScryptParams(testHeader(), []byte("the secret share"), []byte("passphrase"), testScryptN, 8, 1)
+	assert.NoError(t, err)
+
+	var ks keystore.Keystore
+	assert.NoError(t, json.Unmarshal(blob, &ks))
+	ks.Header.ShareID = big.NewInt(43)
+	tampered, err := json.Marshal(&ks)
+	assert.NoError(t, err)
+
+	_, _, err = keystore.Open(tampered, []byte("passphrase"))
+	assert.Equal(t, keystore.ErrDecrypt, err)
+}
+
+func TestOpenRejectsCostlyScryptParams(t *testing.T) {
+	blob, err := keystore.SealWithScryptParams(testHeader(), []byte("the secret share"), []byte("passphrase"), testScryptN, 8, 1)
+	assert.NoError(t, err)
+
+	var ks keystore.Keystore
+	assert.NoError(t, json.Unmarshal(blob, &ks))
+	ks.Crypto.N = 1 << 30
+	costly, err := json.Marshal(&ks)
+	assert.NoError(t, err)
+
+	_, _, err = keystore.Open(costly, []byte("passphrase"))
+	assert.Error(t, err)
+	assert.NotEqual(t, keystore.ErrDecrypt, err)
+}
+
+func TestSealRejectsEmptyPassphrase(t *testing.T) {
+	_, err := keystore.Seal(testHeader(), []byte("the secret share"), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,183 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Zero-knowledge proof that a Paillier ciphertext C = enc_N0(x; rho) and a curve point X = x * g share the same small
+// x < 2^l, where l is the bit length of the curve order. The proof is made for the Ring-Pedersen parameters
+// (NCap, s, t) of the verifier.
+// Canetti, R., Gennaro, R., Goldfeder, S., Makriyannis, N., Peled, U.: UC Non-Interactive, Proactive, Threshold ECDSA
+// with Identifiable Aborts (CGGMP21), Fig. 25.
+
+package logstarproof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+const (
+	ProofLogStarBytesParts = 8
+)
+
+type (
+	ProofLogStar struct {
+		S, A       *big.Int
+		Y          *crypto.ECPoint
+		D          *big.Int
+		Z1, Z2, Z3 *big.Int
+	}
+)
+
+var (
+	one = big.NewInt(1)
+)
+
+// NewProof implements proofLogStar for C = (1 + N0)^x * rho^N0 mod N0^2 and X = x * g, where pk is the Paillier key with modulus N0.
+// The session binds the proof to the prover and its protocol session; see tss.ProofSession.
+func NewProof(session *big.Int, pk *paillier.PublicKey, C *big.Int, X, g *crypto.ECPoint, NCap, s, t, x, rho *big.Int) (*ProofLogStar, error) {
+	if session == nil || pk == nil || C == nil || X == nil || g == nil || NCap == nil || s == nil || t == nil || x == nil || rho == nil {
+		return nil, errors.New("ProveLogStar constructor received nil value(s)")
+	}
+	q := g.Curve().Params().N
+	l, eps := q.BitLen(), 2*q.BitLen()
+	N0, NSq := pk.N, pk.NSquare()
+
+	// Fig 25.1 sample
+	alpha := common.GetRandomPositiveInt(new(big.Int).Lsh(one, uint(l+eps)))
+	mu := common.GetRandomPositiveInt(new(big.Int).Lsh(NCap, uint(l)))
+	r := common.GetRandomPositiveRelativelyPrimeInt(N0)
+	gamma := common.GetRandomPositiveInt(new(big.Int).Lsh(NCap, uint(l+eps)))
+
+	// Fig 25.1 compute
+	modNCap, modNSq := common.ModInt(NCap), common.ModInt(NSq)
+	S := modNCap.Mul(modNCap.Exp(s, x), modNCap.Exp(t, mu))
+	A := modNSq.Mul(modNSq.Exp(pk.Gamma(), alpha), modNSq.Exp(r, N0))
+	Y := g.ScalarMult(new(big.Int).Mod(alpha, q))
+	if Y == nil {
+		return nil, errors.New("ProveLogStar constructor sampled alpha = 0 mod q")
+	}
+	D := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+
+	// Fig 25.2 e
+	e := challenge(q, session, N0, C, X, g, NCap, s, t, S, A, Y, D)
+
+	// Fig 25.3
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	z2 := common.ModInt(N0).Mul(r, common.ModInt(N0).Exp(rho, e))
+	z3 := new(big.Int).Add(gamma, new(big.Int).Mul(e, mu))
+
+	return &ProofLogStar{S: S, A: A, Y: Y, D: D, Z1: z1, Z2: z2, Z3: z3}, nil
+}
+
+func NewProofFromBytes(bzs [][]byte) (*ProofLogStar, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofLogStarBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofLogStar", ProofLogStarBytesParts)
+	}
+	Y, err := crypto.NewECPoint(tss.EC(), new(big.Int).SetBytes(bzs[2]), new(big.Int).SetBytes(bzs[3]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofLogStar{
+		S:  new(big.Int).SetBytes(bzs[0]),
+		A:  new(big.Int).SetBytes(bzs[1]),
+		Y:  Y,
+		D:  new(big.Int).SetBytes(bzs[4]),
+		Z1: new(big.Int).SetBytes(bzs[5]),
+		Z2: new(big.Int).SetBytes(bzs[6]),
+		Z3: new(big.Int).SetBytes(bzs[7]),
+	}, nil
+}
+
+func (pf *ProofLogStar) Verify(session *big.Int, pk *paillier.PublicKey, C *big.Int, X, g *crypto.ECPoint, NCap, s, t *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || session == nil || pk == nil || pk.N == nil || C == nil || X == nil || g == nil || NCap == nil || s == nil || t == nil {
+		return false
+	}
+	if pk.N.Sign() != 1 || NCap.Sign() != 1 {
+		return false
+	}
+	q := g.Curve().Params().N
+	l, eps := q.BitLen(), 2*q.BitLen()
+	N0, NSq := pk.N, pk.NSquare()
+
+	if !pf.Y.IsOnCurve() || !X.IsOnCurve() {
+		return false
+	}
+	if !common.IsInGroup(C, NSq) || !common.IsInGroup(pf.A, NSq) || !common.IsInGroup(pf.Z2, N0) {
+		return false
+	}
+	if !common.IsInGroup(pf.S, NCap) || !common.IsInGroup(pf.D, NCap) {
+		return false
+	}
+
+	// Fig 25. Range Check; the slack of one bit covers e * x
+	if !common.IsInInterval(pf.Z1, new(big.Int).Lsh(one, uint(l+eps+1))) {
+		return false
+	}
+
+	e := challenge(q, session, N0, C, X, g, NCap, s, t, pf.S, pf.A, pf.Y, pf.D)
+
+	// Fig 25. Equality Check
+	modNCap, modNSq := common.ModInt(NCap), common.ModInt(NSq)
+	{
+		LHS := modNSq.Mul(modNSq.Exp(pk.Gamma(), pf.Z1), modNSq.Exp(pf.Z2, N0))
+		RHS := modNSq.Mul(pf.A, modNSq.Exp(C, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	{
+		LHS, eX := g.ScalarMult(new(big.Int).Mod(pf.Z1, q)), X.ScalarMult(e)
+		if LHS == nil || eX == nil {
+			return false
+		}
+		RHS, err := pf.Y.Add(eX)
+		if err != nil || !LHS.Equals(RHS) {
+			return false
+		}
+	}
+	{
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.D, modNCap.Exp(pf.S, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofLogStar) ValidateBasic() bool {
+	return pf.S != nil &&
+		pf.A != nil &&
+		pf.Y != nil &&
+		pf.D != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil
+}
+
+func (pf *ProofLogStar) Bytes() [ProofLogStarBytesParts][]byte {
+	return [...][]byte{
+		pf.S.Bytes(),
+		pf.A.Bytes(),
+		pf.Y.X().Bytes(),
+		pf.Y.Y().Bytes(),
+		pf.D.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+		pf.Z3.Bytes(),
+	}
+}
+
+// challenge derives e from the session, the statement and the first messages of the proof (Fiat-Shamir)
+func challenge(q, session, N0, C *big.Int, X, g *crypto.ECPoint, NCap, s, t, S, A *big.Int, Y *crypto.ECPoint, D *big.Int) *big.Int {
+	eHash := common.SHA512_256i(session, N0, C, X.X(), X.Y(), g.X(), g.Y(), NCap, s, t, S, A, Y.X(), Y.Y(), D)
+	return common.RejectionSample(q, eHash)
+}
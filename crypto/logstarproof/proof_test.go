@@ -0,0 +1,68 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package logstarproof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Using a modulus length of 2048 is recommended in the GG18 spec
+const (
+	testPrimeBits = 1024
+)
+
+var (
+	testSession = big.NewInt(42)
+)
+
+func TestLogStar(test *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+
+	pk := &paillier.PublicKey{N: new(big.Int).Mul(common.GetRandomPrimeInt(testPrimeBits), common.GetRandomPrimeInt(testPrimeBits))}
+	primes := [2]*big.Int{common.GetRandomPrimeInt(testPrimeBits), common.GetRandomPrimeInt(testPrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(primes)
+	assert.NoError(test, err)
+
+	// a base point other than the generator
+	g := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(q))
+	x := common.GetRandomPositiveInt(q)
+	X := g.ScalarMult(x)
+	C, rho, err := pk.EncryptAndReturnRandomness(x)
+	assert.NoError(test, err)
+
+	proof, err := NewProof(testSession, pk, C, X, g, NCap, s, t, x, rho)
+	assert.NoError(test, err)
+	assert.True(test, proof.Verify(testSession, pk, C, X, g, NCap, s, t), "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(bzs[:])
+	assert.NoError(test, err)
+	assert.True(test, proof2.Verify(testSession, pk, C, X, g, NCap, s, t), "proof must verify after a round trip")
+
+	// another session
+	assert.False(test, proof.Verify(big.NewInt(43), pk, C, X, g, NCap, s, t), "proof must not verify for another session")
+
+	// X for another base point
+	X2 := crypto.ScalarBaseMult(ec, x)
+	assert.False(test, proof.Verify(testSession, pk, C, X2, g, NCap, s, t), "proof must not verify for another point")
+
+	// a ciphertext of another plaintext
+	C2, rho2, err := pk.EncryptAndReturnRandomness(new(big.Int).Add(x, big.NewInt(1)))
+	assert.NoError(test, err)
+	proof, err = NewProof(testSession, pk, C2, X, g, NCap, s, t, x, rho2)
+	assert.NoError(test, err)
+	assert.False(test, proof.Verify(testSession, pk, C2, X, g, NCap, s, t), "proof must not verify for another plaintext")
+}
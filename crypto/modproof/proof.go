@@ -0,0 +1,223 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Zero-knowledge proof that a modulus N is a Paillier-Blum modulus: gcd(N, phi(N)) = 1 and N = pq with p, q = 3 mod 4.
+// Canetti, R., Gennaro, R., Goldfeder, S., Makriyannis, N., Peled, U.: UC Non-Interactive, Proactive, Threshold ECDSA
+// with Identifiable Aborts (CGGMP21), Fig. 16.
+
+package modproof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+)
+
+const (
+	// Iterations is the number of challenges; each one halves a cheating prover's chance of success
+	Iterations = 80
+
+	ProofModBytesParts = 3 + 2*Iterations
+)
+
+type (
+	ProofMod struct {
+		W    *big.Int
+		A, B [Iterations]bool
+		X, Z [Iterations]*big.Int
+	}
+)
+
+var (
+	one   = big.NewInt(1)
+	three = big.NewInt(3)
+	four  = big.NewInt(4)
+)
+
+// NewProof implements proofMod for N = PQ, where P and Q are primes congruent to 3 mod 4 (i.e. safe primes).
+// The session binds the proof to the prover and its protocol session; see tss.ProofSession.
+func NewProof(session, N, P, Q *big.Int) (*ProofMod, error) {
+	if session == nil || N == nil || P == nil || Q == nil {
+		return nil, errors.New("ProveMod constructor received nil value(s)")
+	}
+	if new(big.Int).Mul(P, Q).Cmp(N) != 0 {
+		return nil, errors.New("ProveMod constructor received N != PQ")
+	}
+	if new(big.Int).Mod(P, four).Cmp(three) != 0 || new(big.Int).Mod(Q, four).Cmp(three) != 0 {
+		return nil, errors.New("ProveMod constructor requires P = Q = 3 mod 4")
+	}
+	phiN := new(big.Int).Mul(new(big.Int).Sub(P, one), new(big.Int).Sub(Q, one))
+	invN := new(big.Int).ModInverse(N, phiN)
+	if invN == nil {
+		return nil, errors.New("ProveMod constructor requires gcd(N, phi(N)) = 1")
+	}
+
+	// Fig 16.1 sample w with Jacobi symbol -1
+	var w *big.Int
+	for {
+		w = common.GetRandomPositiveRelativelyPrimeInt(N)
+		if big.Jacobi(w, N) == -1 {
+			break
+		}
+	}
+
+	modN := common.ModInt(N)
+	pf := &ProofMod{W: w}
+	ys := generateYs(session, N, w)
+	for i, y := range ys {
+		// Fig 16.2 x = y'^(1/4) where y' = (-1)^a * w^b * y is a quadratic residue mod N
+		for _, ab := range [4][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+			yi := adjust(N, w, y, ab[0], ab[1])
+			if big.Jacobi(yi, P) != 1 || big.Jacobi(yi, Q) != 1 {
+				continue
+			}
+			pf.A[i], pf.B[i] = ab[0], ab[1]
+			pf.X[i] = fourthRoot(yi, N, P, Q)
+			break
+		}
+		if pf.X[i] == nil {
+			return nil, errors.New("ProveMod could not find a quadratic residue")
+		}
+		// Fig 16.2 z = y^(N^-1 mod phi(N))
+		pf.Z[i] = modN.Exp(y, invN)
+	}
+	return pf, nil
+}
+
+func NewProofFromBytes(bzs [][]byte) (*ProofMod, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofModBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofMod", ProofModBytesParts)
+	}
+	if len(bzs[1]) != Iterations || len(bzs[2]) != Iterations {
+		return nil, fmt.Errorf("expected %d bytes in each of the a and b parts of ProofMod", Iterations)
+	}
+	pf := &ProofMod{W: new(big.Int).SetBytes(bzs[0])}
+	for i := 0; i < Iterations; i++ {
+		pf.A[i], pf.B[i] = bzs[1][i] == 1, bzs[2][i] == 1
+		pf.X[i] = new(big.Int).SetBytes(bzs[3+i])
+		pf.Z[i] = new(big.Int).SetBytes(bzs[3+Iterations+i])
+	}
+	return pf, nil
+}
+
+func (pf *ProofMod) Verify(session, N *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || session == nil || N == nil {
+		return false
+	}
+	// N must be an odd composite
+	if N.Sign() != 1 || N.Bit(0) != 1 || N.ProbablyPrime(30) {
+		return false
+	}
+	if !common.IsNumberInMultiplicativeGroup(N, pf.W) || big.Jacobi(pf.W, N) != -1 {
+		return false
+	}
+	for i := range pf.X {
+		if !common.IsNumberInMultiplicativeGroup(N, pf.X[i]) || !common.IsNumberInMultiplicativeGroup(N, pf.Z[i]) {
+			return false
+		}
+	}
+
+	modN := common.ModInt(N)
+	ys := generateYs(session, N, pf.W)
+	chs := make(chan bool, Iterations)
+	for i := range ys {
+		go func(i int) {
+			// Fig 16.3 z^N = y mod N
+			if modN.Exp(pf.Z[i], N).Cmp(ys[i]) != 0 {
+				chs <- false
+				return
+			}
+			// Fig 16.3 x^4 = (-1)^a * w^b * y mod N
+			yi := adjust(N, pf.W, ys[i], pf.A[i], pf.B[i])
+			chs <- modN.Exp(pf.X[i], four).Cmp(yi) == 0
+		}(i)
+	}
+	ok := true
+	for range ys {
+		ok = <-chs && ok
+	}
+	return ok
+}
+
+func (pf *ProofMod) ValidateBasic() bool {
+	if pf.W == nil {
+		return false
+	}
+	for i := range pf.X {
+		if pf.X[i] == nil || pf.Z[i] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofMod) Bytes() [ProofModBytesParts][]byte {
+	var bzs [ProofModBytesParts][]byte
+	bzs[0] = pf.W.Bytes()
+	bzs[1], bzs[2] = make([]byte, Iterations), make([]byte, Iterations)
+	for i := 0; i < Iterations; i++ {
+		if pf.A[i] {
+			bzs[1][i] = 1
+		}
+		if pf.B[i] {
+			bzs[2][i] = 1
+		}
+		bzs[3+i] = pf.X[i].Bytes()
+		bzs[3+Iterations+i] = pf.Z[i].Bytes()
+	}
+	return bzs
+}
+
+// ----- utils
+
+// generateYs derives the challenges y_i in Z_N* from the session, N and w (Fiat-Shamir)
+func generateYs(session, N, w *big.Int) []*big.Int {
+	ys := make([]*big.Int, Iterations)
+	blocks := (N.BitLen() + 255) / 256
+	for i, n := 0, int64(0); i < Iterations; n++ {
+		y := new(big.Int)
+		for j := 0; j < blocks; j++ {
+			h := common.SHA512_256i(session, N, w, big.NewInt(int64(i)), big.NewInt(n), big.NewInt(int64(j)))
+			y.Lsh(y, 256).Or(y, h)
+		}
+		y.Mod(y, N)
+		if common.IsNumberInMultiplicativeGroup(N, y) {
+			ys[i] = y
+			i++
+		}
+	}
+	return ys
+}
+
+// adjust returns (-1)^a * w^b * y mod N
+func adjust(N, w, y *big.Int, a, b bool) *big.Int {
+	res := new(big.Int).Set(y)
+	if a {
+		res.Sub(N, res)
+	}
+	if b {
+		res.Mul(res, w).Mod(res, N)
+	}
+	return res
+}
+
+// fourthRoot returns the fourth root of a quadratic residue y mod N = PQ which is itself a quadratic residue.
+// Square roots mod a prime P = 3 mod 4 are computed as y^((P+1)/4), which keeps the root a quadratic residue.
+func fourthRoot(y, N, P, Q *big.Int) *big.Int {
+	rootMod := func(p *big.Int) *big.Int {
+		e := new(big.Int).Rsh(new(big.Int).Add(p, one), 2)
+		r := new(big.Int).Exp(y, e, p)
+		return r.Exp(r, e, p)
+	}
+	rP, rQ := rootMod(P), rootMod(Q)
+	// CRT: x = rP * Q * (Q^-1 mod P) + rQ * P * (P^-1 mod Q) mod N
+	modN := common.ModInt(N)
+	cP := modN.Mul(Q, new(big.Int).ModInverse(Q, P))
+	cQ := modN.Mul(P, new(big.Int).ModInverse(P, Q))
+	return modN.Add(modN.Mul(rP, cP), modN.Mul(rQ, cQ))
+}
@@ -0,0 +1,112 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package modproof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeta-chain/tss-lib/common"
+)
+
+const (
+	testPrimeBits = 512
+)
+
+var (
+	testSession = big.NewInt(42)
+)
+
+// blumPrime returns a random prime congruent to 3 mod 4
+func blumPrime(bits int) *big.Int {
+	for {
+		p := common.GetRandomPrimeInt(bits)
+		if new(big.Int).Mod(p, four).Cmp(three) == 0 {
+			return p
+		}
+	}
+}
+
+// nonBlumPrime returns a random prime congruent to 1 mod 4
+func nonBlumPrime(bits int) *big.Int {
+	for {
+		p := common.GetRandomPrimeInt(bits)
+		if new(big.Int).Mod(p, four).Cmp(one) == 0 {
+			return p
+		}
+	}
+}
+
+func TestMod(test *testing.T) {
+	P, Q := blumPrime(testPrimeBits), blumPrime(testPrimeBits)
+	N := new(big.Int).Mul(P, Q)
+
+	proof, err := NewProof(testSession, N, P, Q)
+	assert.NoError(test, err)
+	assert.True(test, proof.Verify(testSession, N), "proof must verify")
+
+	// another modulus
+	P2, Q2 := blumPrime(testPrimeBits), blumPrime(testPrimeBits)
+	N2 := new(big.Int).Mul(P2, Q2)
+	assert.False(test, proof.Verify(testSession, N2), "proof must not verify for another modulus")
+
+	// a prime modulus
+	assert.False(test, proof.Verify(testSession, P), "proof must not verify for a prime")
+
+	// another session
+	assert.False(test, proof.Verify(big.NewInt(43), N), "proof must not verify for another session")
+}
+
+func TestModRejectsNonBlum(test *testing.T) {
+	P, Q := nonBlumPrime(testPrimeBits), blumPrime(testPrimeBits)
+	N := new(big.Int).Mul(P, Q)
+
+	_, err := NewProof(testSession, N, P, Q)
+	assert.Error(test, err)
+
+	_, err = NewProof(testSession, N, Q, Q)
+	assert.Error(test, err, "N != PQ must be rejected")
+}
+
+func TestModTampered(test *testing.T) {
+	P, Q := blumPrime(testPrimeBits), blumPrime(testPrimeBits)
+	N := new(big.Int).Mul(P, Q)
+
+	proof, err := NewProof(testSession, N, P, Q)
+	assert.NoError(test, err)
+
+	bad := *proof
+	bad.A[0] = !bad.A[0]
+	assert.False(test, bad.Verify(testSession, N), "proof with a flipped a must not verify")
+
+	bad = *proof
+	bad.Z[1] = new(big.Int).Add(bad.Z[1], one)
+	assert.False(test, bad.Verify(testSession, N), "proof with a changed z must not verify")
+
+	bad = *proof
+	bad.W = new(big.Int).Sub(N, bad.W)
+	assert.False(test, bad.Verify(testSession, N), "proof with a changed w must not verify")
+}
+
+func TestModBytes(test *testing.T) {
+	P, Q := blumPrime(testPrimeBits), blumPrime(testPrimeBits)
+	N := new(big.Int).Mul(P, Q)
+
+	proof, err := NewProof(testSession, N, P, Q)
+	assert.NoError(test, err)
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(bzs[:])
+	assert.NoError(test, err)
+	assert.Equal(test, proof, proof2)
+	assert.True(test, proof2.Verify(testSession, N), "proof must verify")
+
+	_, err = NewProofFromBytes(bzs[:ProofModBytesParts-1])
+	assert.Error(test, err)
+}
@@ -43,3 +43,22 @@ func TestProveRangeAlice(t *testing.T) {
 	ok := proof.Verify(pk, NTildei, h1i, h2i, c)
 	assert.True(t, ok, "proof must verify")
 }
+
+func TestProveRangeAliceLargeModulus(t *testing.T) {
+	q := tss.EC().Params().N
+
+	sk, pk := testPaillierKeyPairFromPrimes(3072)
+
+	m := common.GetRandomPositiveInt(q)
+	c, r, err := sk.EncryptAndReturnRandomness(m)
+	assert.NoError(t, err)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(1536), common.GetRandomPrimeInt(1536)}
+	NTildei, h1i, h2i, err := crypto.GenerateNTildei(primes)
+	assert.NoError(t, err)
+	proof, err := ProveRangeAlice(pk, c, NTildei, h1i, h2i, m, r)
+	assert.NoError(t, err)
+
+	ok := proof.Verify(pk, NTildei, h1i, h2i, c)
+	assert.True(t, ok, "proof must verify")
+}
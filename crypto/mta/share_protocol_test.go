@@ -92,3 +92,56 @@ func TestShareProtocolWC(t *testing.T) {
 	aTimesBPlusBetaModQ := new(big.Int).Mod(aTimesBPlusBeta, q)
 	assert.Equal(t, 0, muIJ.Cmp(aTimesBPlusBetaModQ))
 }
+
+// the proof bounds depend only on q, N and NTilde, so the protocol must also hold with 3072-bit moduli.
+// plain primes are used instead of safe primes to keep the test fast; this does not affect correctness.
+func TestShareProtocolWCLargeModulus(t *testing.T) {
+	q := tss.EC().Params().N
+
+	sk, pk := testPaillierKeyPairFromPrimes(3072)
+	assert.Equal(t, 3072, pk.N.BitLen())
+
+	a := common.GetRandomPositiveInt(q)
+	b := common.GetRandomPositiveInt(q)
+	gBX, gBY := tss.EC().ScalarBaseMult(b.Bytes())
+
+	NTildei, h1i, h2i, err := crypto.GenerateNTildei([2]*big.Int{common.GetRandomPrimeInt(1536), common.GetRandomPrimeInt(1536)})
+	assert.NoError(t, err)
+	NTildej, h1j, h2j, err := crypto.GenerateNTildei([2]*big.Int{common.GetRandomPrimeInt(1536), common.GetRandomPrimeInt(1536)})
+	assert.NoError(t, err)
+
+	cA, rA, err := pk.EncryptAndReturnRandomness(a)
+	assert.NoError(t, err)
+	pf, err := AliceInit(pk, a, cA, rA, NTildej, h1j, h2j)
+	assert.NoError(t, err)
+
+	gBPoint, err := crypto.NewECPoint(tss.EC(), gBX, gBY)
+	assert.NoError(t, err)
+	betaPrm, cB, pfB, err := BobMidWC(pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint)
+	assert.NoError(t, err)
+
+	muIJ, _, _, err := AliceEndWC(pk, pfB, gBPoint, cA, cB, NTildei, h1i, h2i, sk)
+	assert.NoError(t, err)
+
+	// expect: muIJ = ab + betaPrm
+	aTimesB := new(big.Int).Mul(a, b)
+	aTimesBPlusBeta := new(big.Int).Add(aTimesB, betaPrm)
+	aTimesBPlusBetaModQ := new(big.Int).Mod(aTimesBPlusBeta, q)
+	assert.Equal(t, 0, muIJ.Cmp(aTimesBPlusBetaModQ))
+}
+
+func testPaillierKeyPairFromPrimes(modulusBitLen int) (*paillier.PrivateKey, *paillier.PublicKey) {
+	var P, Q, N *big.Int
+	for {
+		P, Q = common.GetRandomPrimeInt(modulusBitLen/2), common.GetRandomPrimeInt(modulusBitLen/2)
+		if N = new(big.Int).Mul(P, Q); N.BitLen() == modulusBitLen && P.Cmp(Q) != 0 {
+			break
+		}
+	}
+	PMinus1, QMinus1 := new(big.Int).Sub(P, one), new(big.Int).Sub(Q, one)
+	phiN := new(big.Int).Mul(PMinus1, QMinus1)
+	gcd := new(big.Int).GCD(nil, nil, PMinus1, QMinus1)
+	lambdaN := new(big.Int).Div(phiN, gcd)
+	pk := &paillier.PublicKey{N: N}
+	return &paillier.PrivateKey{PublicKey: *pk, LambdaN: lambdaN, PhiN: phiN, P: P, Q: Q}, pk
+}
@@ -0,0 +1,141 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Zero-knowledge proof that the Ring-Pedersen parameters (N, s, t) are well-formed, i.e. s = t^lambda mod N.
+// Canetti, R., Gennaro, R., Goldfeder, S., Makriyannis, N., Peled, U.: UC Non-Interactive, Proactive, Threshold ECDSA
+// with Identifiable Aborts (CGGMP21), Fig. 17.
+
+package prmproof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+)
+
+const (
+	// Iterations is the number of binary challenges; each one halves a cheating prover's chance of success
+	Iterations = 80
+
+	ProofPrmBytesParts = 2 * Iterations
+)
+
+type (
+	ProofPrm struct {
+		A, Z [Iterations]*big.Int
+	}
+)
+
+var (
+	one = big.NewInt(1)
+)
+
+// NewProof implements proofPrm. phi is the order of the group generated by t, or a multiple of it such as phi(N).
+// The session binds the proof to the prover and its protocol session; see tss.ProofSession.
+func NewProof(session, N, s, t, phi, lambda *big.Int) (*ProofPrm, error) {
+	if session == nil || N == nil || s == nil || t == nil || phi == nil || lambda == nil {
+		return nil, errors.New("ProvePrm constructor received nil value(s)")
+	}
+	modN, modPhi := common.ModInt(N), common.ModInt(phi)
+
+	// Fig 17.1 sample a_i in Z_phi and compute A_i = t^a_i
+	pf := new(ProofPrm)
+	as := make([]*big.Int, Iterations)
+	for i := range as {
+		as[i] = common.GetRandomPositiveInt(phi)
+		pf.A[i] = modN.Exp(t, as[i])
+	}
+
+	// Fig 17.2 z_i = a_i + e_i * lambda mod phi
+	e := challenge(session, N, s, t, pf.A[:])
+	for i := range as {
+		if e.Bit(i) == 1 {
+			pf.Z[i] = modPhi.Add(as[i], lambda)
+			continue
+		}
+		pf.Z[i] = new(big.Int).Set(as[i])
+	}
+	return pf, nil
+}
+
+func NewProofFromBytes(bzs [][]byte) (*ProofPrm, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofPrmBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofPrm", ProofPrmBytesParts)
+	}
+	pf := new(ProofPrm)
+	for i := 0; i < Iterations; i++ {
+		pf.A[i] = new(big.Int).SetBytes(bzs[i])
+		pf.Z[i] = new(big.Int).SetBytes(bzs[Iterations+i])
+	}
+	return pf, nil
+}
+
+func (pf *ProofPrm) Verify(session, N, s, t *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || session == nil || N == nil || s == nil || t == nil {
+		return false
+	}
+	if N.Sign() != 1 || N.Bit(0) != 1 {
+		return false
+	}
+	if !common.IsNumberInMultiplicativeGroup(N, s) || !common.IsNumberInMultiplicativeGroup(N, t) {
+		return false
+	}
+	if s.Cmp(one) == 0 || t.Cmp(one) == 0 || s.Cmp(t) == 0 {
+		return false
+	}
+	for i := range pf.A {
+		if !common.IsNumberInMultiplicativeGroup(N, pf.A[i]) || pf.Z[i].Sign() == -1 || pf.Z[i].Cmp(N) != -1 {
+			return false
+		}
+	}
+
+	// Fig 17.3 t^z_i = A_i * s^e_i mod N
+	modN := common.ModInt(N)
+	e := challenge(session, N, s, t, pf.A[:])
+	chs := make(chan bool, Iterations)
+	for i := range pf.A {
+		go func(i int) {
+			rhs := pf.A[i]
+			if e.Bit(i) == 1 {
+				rhs = modN.Mul(rhs, s)
+			}
+			chs <- modN.Exp(t, pf.Z[i]).Cmp(rhs) == 0
+		}(i)
+	}
+	ok := true
+	for range pf.A {
+		ok = <-chs && ok
+	}
+	return ok
+}
+
+func (pf *ProofPrm) ValidateBasic() bool {
+	for i := range pf.A {
+		if pf.A[i] == nil || pf.Z[i] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofPrm) Bytes() [ProofPrmBytesParts][]byte {
+	var bzs [ProofPrmBytesParts][]byte
+	for i := 0; i < Iterations; i++ {
+		bzs[i] = pf.A[i].Bytes()
+		bzs[Iterations+i] = pf.Z[i].Bytes()
+	}
+	return bzs
+}
+
+// ----- utils
+
+// challenge derives the challenge bits e_i from the session, the statement and the first messages A_i (Fiat-Shamir)
+func challenge(session, N, s, t *big.Int, as []*big.Int) *big.Int {
+	msg := append([]*big.Int{session, N, s, t}, as...)
+	return common.SHA512_256i(msg...)
+}
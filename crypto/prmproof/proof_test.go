@@ -0,0 +1,76 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package prmproof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeta-chain/tss-lib/common"
+)
+
+const (
+	testPrimeBits = 512
+)
+
+var (
+	testSession = big.NewInt(42)
+)
+
+func testParams() (N, s, t, phi, lambda *big.Int) {
+	p, q := common.GetRandomPrimeInt(testPrimeBits), common.GetRandomPrimeInt(testPrimeBits)
+	N = new(big.Int).Mul(p, q)
+	phi = new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+	modN := common.ModInt(N)
+	r := common.GetRandomPositiveRelativelyPrimeInt(N)
+	t = modN.Mul(r, r)
+	lambda = common.GetRandomPositiveInt(phi)
+	s = modN.Exp(t, lambda)
+	return
+}
+
+func TestPrm(test *testing.T) {
+	N, s, t, phi, lambda := testParams()
+
+	proof, err := NewProof(testSession, N, s, t, phi, lambda)
+	assert.NoError(test, err)
+	assert.True(test, proof.Verify(testSession, N, s, t), "proof must verify")
+
+	// swapped parameters
+	assert.False(test, proof.Verify(testSession, N, t, s), "proof must not verify for swapped s and t")
+
+	// another session
+	assert.False(test, proof.Verify(big.NewInt(43), N, s, t), "proof must not verify for another session")
+}
+
+func TestPrmWrongLambda(test *testing.T) {
+	N, s, t, phi, lambda := testParams()
+
+	proof, err := NewProof(testSession, N, s, t, phi, new(big.Int).Add(lambda, one))
+	assert.NoError(test, err)
+	assert.False(test, proof.Verify(testSession, N, s, t), "proof with a wrong lambda must not verify")
+}
+
+func TestPrmBytes(test *testing.T) {
+	N, s, t, phi, lambda := testParams()
+
+	proof, err := NewProof(testSession, N, s, t, phi, lambda)
+	assert.NoError(test, err)
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(bzs[:])
+	assert.NoError(test, err)
+	assert.Equal(test, proof, proof2)
+	assert.True(test, proof2.Verify(testSession, N, s, t), "proof must verify")
+
+	bzs[0] = new(big.Int).Add(proof.A[0], one).Bytes()
+	proof3, err := NewProofFromBytes(bzs[:])
+	assert.NoError(test, err)
+	assert.False(test, proof3.Verify(testSession, N, s, t), "tampered proof must not verify")
+}
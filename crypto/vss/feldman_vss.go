@@ -57,6 +57,20 @@ func CheckIndexes(ec elliptic.Curve, indexes []*big.Int) ([]*big.Int, error) {
 	return indexes, nil
 }
 
+// ExtraIndexes derives the share ids of the count additional shares held by the party with index k in a weighted sharing.
+// The ids depend only on k so that every party derives the same ones.
+func ExtraIndexes(ec elliptic.Curve, k *big.Int, count int) []*big.Int {
+	ids := make([]*big.Int, 0, count)
+	for n := int64(1); len(ids) < count; n++ {
+		id := new(big.Int).Mod(common.SHA512_256i(k, big.NewInt(n)), ec.Params().N)
+		if id.Cmp(zero) == 0 {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 // Returns a new array of secret shares created by Shamir's Secret Sharing Algorithm,
 // requiring a minimum number of shares to recreate, of length shares, from the input secret
 func Create(threshold int, secret *big.Int, indexes []*big.Int) (Vs, Shares, error) {
@@ -111,6 +125,62 @@ func (share *Share) Verify(threshold int, vs Vs) bool {
 	return sigmaGi.Equals(v)
 }
 
+// Returns a new array of shares of zero, created from a random polynomial whose constant term is 0.
+// These shares are added to existing shares to re-randomise them without changing the shared secret (proactive refresh).
+// As v0 would be the point at infinity it is omitted, so the returned Vs holds only v1..vt.
+func CreateZeroSecret(threshold int, indexes []*big.Int) (Vs, Shares, error) {
+	if indexes == nil {
+		return nil, nil, errors.New("vss indexes == nil")
+	}
+	if threshold < 1 {
+		return nil, nil, errors.New("vss threshold < 1")
+	}
+	ids, err := CheckIndexes(tss.EC(), indexes)
+	if err != nil {
+		return nil, nil, err
+	}
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, ErrNumSharesBelowThreshold
+	}
+
+	poly := samplePolynomial(threshold, zero)
+	v := make(Vs, threshold)
+	for i := 1; i <= threshold; i++ {
+		v[i-1] = crypto.ScalarBaseMult(tss.EC(), poly[i])
+	}
+
+	shares := make(Shares, num)
+	for i := 0; i < num; i++ {
+		share := evaluatePolynomial(threshold, poly, ids[i])
+		shares[i] = &Share{Threshold: threshold, ID: ids[i], Share: share}
+	}
+	return v, shares, nil
+}
+
+// VerifyZeroSecret verifies a share created by CreateZeroSecret against its commitments v1..vt.
+func (share *Share) VerifyZeroSecret(threshold int, vs Vs) bool {
+	if share.Threshold != threshold || len(vs) != threshold {
+		return false
+	}
+	var err error
+	modQ := common.ModInt(tss.EC().Params().N)
+	t := modQ.Mul(one, share.ID)
+	v := vs[0].SetCurve(tss.EC()).ScalarMult(t)
+	for j := 2; j <= threshold; j++ {
+		// t = k_i^j
+		t = modQ.Mul(t, share.ID)
+		// v = v * v_j^t
+		vjt := vs[j-1].SetCurve(tss.EC()).ScalarMult(t)
+		v, err = v.SetCurve(tss.EC()).Add(vjt)
+		if err != nil {
+			return false
+		}
+	}
+	sigmaGi := crypto.ScalarBaseMult(tss.EC(), share.Share)
+	return sigmaGi.Equals(v)
+}
+
 func (shares Shares) ReConstruct() (secret *big.Int, err error) {
 	if shares != nil && shares[0].Threshold > len(shares) {
 		return nil, ErrNumSharesBelowThreshold
@@ -143,6 +213,58 @@ func (shares Shares) ReConstruct() (secret *big.Int, err error) {
 	return secret, nil
 }
 
+// InterpolatePoints evaluates at x the polynomial in the exponent defined by the points f(ids[j])*G, using Lagrange interpolation.
+func InterpolatePoints(ec elliptic.Curve, ids []*big.Int, points []*crypto.ECPoint, x *big.Int) (*crypto.ECPoint, error) {
+	if len(ids) == 0 || len(ids) != len(points) {
+		return nil, errors.New("InterpolatePoints: expected the same non-zero number of ids and points")
+	}
+	modN := common.ModInt(ec.Params().N)
+	var result *crypto.ECPoint
+	for j, idj := range ids {
+		lambda := one
+		for m, idm := range ids {
+			if m == j {
+				continue
+			}
+			sub := modN.Sub(idj, idm)
+			if sub.Sign() == 0 {
+				return nil, errors.New("InterpolatePoints: duplicate ids")
+			}
+			lambda = modN.Mul(lambda, modN.Mul(modN.Sub(x, idm), modN.Inverse(sub)))
+		}
+		term := points[j].ScalarMult(lambda)
+		if result == nil {
+			result = term
+			continue
+		}
+		var err error
+		if result, err = result.Add(term); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// PublicSharesThreshold returns the lowest threshold t for which every point f(ids[j])*G lies on one polynomial of degree t with f(0)*G = y.
+func PublicSharesThreshold(ec elliptic.Curve, ids []*big.Int, points []*crypto.ECPoint, y *crypto.ECPoint) (int, error) {
+	if len(ids) == 0 || len(ids) != len(points) || y == nil {
+		return 0, errors.New("PublicSharesThreshold: expected the same non-zero number of ids and points")
+	}
+	for t := 0; t < len(ids); t++ {
+		if y0, err := InterpolatePoints(ec, ids[:t+1], points[:t+1], zero); err != nil || !y0.Equals(y) {
+			continue
+		}
+		for j := t + 1; j < len(ids); j++ {
+			yj, err := InterpolatePoints(ec, ids[:t+1], points[:t+1], ids[j])
+			if err != nil || !yj.Equals(points[j]) {
+				return 0, fmt.Errorf("PublicSharesThreshold: point %d does not lie on the polynomial of degree %d", j, t)
+			}
+		}
+		return t, nil
+	}
+	return 0, errors.New("PublicSharesThreshold: the points do not interpolate to y")
+}
+
 func samplePolynomial(threshold int, secret *big.Int) []*big.Int {
 	q := tss.EC().Params().N
 	v := make([]*big.Int, threshold+1)
@@ -171,3 +293,12 @@ func evaluatePolynomial(threshold int, v []*big.Int, id *big.Int) (result *big.I
 	}
 	return
 }
+
+// Wipe overwrites the secret value of each share with zeros.
+func (shares Shares) Wipe() {
+	for _, share := range shares {
+		if share != nil {
+			common.WipeBigInt(share.Share)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
 	. "github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/tss"
 )
@@ -113,3 +114,73 @@ func TestReconstruct(t *testing.T) {
 	assert.NoError(t, err4)
 	assert.NotZero(t, secret4)
 }
+
+func TestCreateZeroSecret(t *testing.T) {
+	num, threshold := 5, 3
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(tss.EC().Params().N))
+	}
+
+	vs, shares, err := CreateZeroSecret(threshold, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold, len(vs))
+
+	for i := 0; i < num; i++ {
+		assert.True(t, shares[i].VerifyZeroSecret(threshold, vs))
+	}
+
+	secret, err := shares[:threshold+1].ReConstruct()
+	assert.NoError(t, err)
+	assert.Zero(t, secret.Sign(), "the shared secret should be zero")
+
+	// a tampered share should not verify
+	badShare := *shares[0]
+	badShare.Share = new(big.Int).Add(badShare.Share, big.NewInt(1))
+	assert.False(t, badShare.VerifyZeroSecret(threshold, vs))
+}
+
+func TestPublicSharesThreshold(t *testing.T) {
+	num, threshold := 6, 3
+
+	secret := common.GetRandomPositiveInt(tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(tss.EC().Params().N))
+	}
+
+	_, shares, err := Create(threshold, secret, ids)
+	assert.NoError(t, err)
+
+	points := make([]*crypto.ECPoint, num)
+	for i, share := range shares {
+		points[i] = crypto.ScalarBaseMult(tss.EC(), share.Share)
+	}
+	y := crypto.ScalarBaseMult(tss.EC(), secret)
+
+	t2, err := PublicSharesThreshold(tss.EC(), ids, points, y)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold, t2)
+
+	// a point off the polynomial is detected
+	points[num-1] = crypto.ScalarBaseMult(tss.EC(), big.NewInt(1))
+	_, err = PublicSharesThreshold(tss.EC(), ids, points, y)
+	assert.Error(t, err)
+
+	// a wrong public key is detected
+	_, err = PublicSharesThreshold(tss.EC(), ids[:threshold+1], points[:threshold+1], points[0])
+	assert.Error(t, err)
+}
+
+func TestExtraIndexes(t *testing.T) {
+	k := common.GetRandomPositiveInt(tss.EC().Params().N)
+	ids := ExtraIndexes(tss.EC(), k, 3)
+	assert.Equal(t, 3, len(ids))
+	assert.Equal(t, ids, ExtraIndexes(tss.EC(), k, 3), "ids must be deterministic")
+	assert.Equal(t, ids[:2], ExtraIndexes(tss.EC(), k, 2), "fewer ids must be a prefix")
+
+	_, err := CheckIndexes(tss.EC(), append([]*big.Int{k}, ids...))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,183 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	cmt "github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*AuxInfoLocalParty)(nil)
+var _ fmt.Stringer = (*AuxInfoLocalParty)(nil)
+
+type (
+	// AuxInfoLocalParty runs the CGGMP21 key refresh with auxiliary info (Fig. 6 of the paper): every party re-randomises
+	// its share with a sharing of zero and replaces its Paillier key and Ring-Pedersen parameters NTilde, h1, h2,
+	// proving that the new modulus is a Paillier-Blum modulus without small factors and that h1, h2 are well formed.
+	AuxInfoLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp        auxTempData
+		input, save keygen.LocalPartySaveData
+		keyErr      error // set when the key data cannot be refreshed
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- keygen.LocalPartySaveData
+	}
+
+	auxMessageStore struct {
+		auxRound1Messages,
+		auxRound2Messages,
+		auxRound3Messages []tss.ParsedMessage
+	}
+
+	auxTempData struct {
+		auxMessageStore
+
+		// temp data (thrown away after the refresh)
+		commitments []*big.Int
+		deCommit    cmt.HashDeCommitment
+		vs          vss.Vs
+		shares      vss.Shares
+		vjs         []vss.Vs // the de-committed zero sharing polynomials of the other parties
+	}
+)
+
+// NewAuxInfoLocalParty constructs the party for the CGGMP21 key refresh with auxiliary info of `key`, which must be the save
+// data of a regular (not weighted) keygen. Every party holding a share of the key must take part. The new Paillier key and
+// NTilde, h1, h2 are taken from `optionalPreParams` when it is given and generated in round 1 otherwise, which may take minutes.
+// The `key` given here is not modified; the refreshed save data is sent through `end`, after which the old one should be deleted.
+func NewAuxInfoLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- keygen.LocalPartySaveData,
+	optionalPreParams ...keygen.LocalPreParams,
+) tss.Party {
+	partyCount := params.PartyCount()
+	p := &AuxInfoLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      auxTempData{},
+		save:      keygen.NewLocalPartySaveData(partyCount),
+		out:       out,
+		end:       end,
+	}
+	if p.keyErr = key.ValidateThreshold(params.Threshold()); p.keyErr == nil {
+		switch {
+		case len(key.Ks) != partyCount:
+			p.keyErr = errors.New("every party holding a share of the key must take part in the refresh")
+		case key.IsWeighted():
+			p.keyErr = errors.New("keys from a weighted keygen are not supported")
+		default:
+			p.input = keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+		}
+	}
+	// when `optionalPreParams` is provided we'll use the pre-computed primes instead of generating them from scratch
+	if 0 < len(optionalPreParams) {
+		if 1 < len(optionalPreParams) {
+			panic(errors.New("cggmp.NewAuxInfoLocalParty expected 0 or 1 item in `optionalPreParams`"))
+		}
+		if !optionalPreParams[0].ValidateWithProof() {
+			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+		}
+		p.save.LocalPreParams = optionalPreParams[0]
+	}
+	// msgs init
+	p.temp.auxRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.auxRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.auxRound3Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.commitments = make([]*big.Int, partyCount)
+	p.temp.vjs = make([]vss.Vs, partyCount)
+	return p
+}
+
+func (p *AuxInfoLocalParty) FirstRound() tss.Round {
+	return newAuxRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end)
+}
+
+func (p *AuxInfoLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskNameAuxInfo, func(round tss.Round) *tss.Error {
+		if p.keyErr != nil {
+			return round.WrapError(fmt.Errorf("invalid key data: %v", p.keyErr))
+		}
+		return nil
+	})
+}
+
+func (p *AuxInfoLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskNameAuxInfo)
+}
+
+// Close stops the party and overwrites the shares of zero that it made.
+// The key data given to NewAuxInfoLocalParty is left as is.
+func (p *AuxInfoLocalParty) Close() {
+	tss.BaseClose(p, p.temp.shares.Wipe)
+}
+
+func (p *AuxInfoLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *AuxInfoLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *AuxInfoLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *AuxRound1Message:
+		p.temp.auxRound1Messages[fromPIdx] = msg
+	case *AuxRound2Message:
+		p.temp.auxRound2Messages[fromPIdx] = msg
+	case *AuxRound3Message:
+		p.temp.auxRound3Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *AuxInfoLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *AuxInfoLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,122 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zeta-chain/tss-lib/crypto"
+	cmts "github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the CGGMP21 key refresh with auxiliary info
+func newAuxRound1(params *tss.Parameters, input, save *keygen.LocalPartySaveData, temp *auxTempData, out chan<- tss.Message, end chan<- keygen.LocalPartySaveData) tss.Round {
+	return &auxRound1{newBase(params, TaskNameAuxInfo, 1), input, save, temp, out, end}
+}
+
+func (round *auxRound1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	if round.input.Xi == nil || round.input.ECDSAPub == nil {
+		return round.WrapError(errors.New("the save data given to the aux info party is incomplete"), Pi)
+	}
+	if round.Threshold()+1 > len(round.input.Ks) {
+		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(round.input.Ks)), Pi)
+	}
+
+	// 1. the new Paillier key and NTilde, h1, h2; use the pre-params if they were provided to the constructor
+	if !round.save.LocalPreParams.ValidateWithProof() {
+		preParams, err := keygen.GeneratePreParamsWithOptions(round.SafePrimeGenTimeout(), keygen.PreParamsOptions{
+			PaillierModulusLen: round.MinModulusBitLen(),
+			NTildeModulusLen:   round.MinModulusBitLen(),
+			Concurrency:        3,
+		})
+		if err != nil {
+			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+		}
+		round.save.LocalPreParams = *preParams
+	}
+	preParams := &round.save.LocalPreParams
+
+	// 2. compute the vss shares of zero
+	ids := round.Parties().IDs().Keys()
+	vs, shares, err := vss.CreateZeroSecret(round.Threshold(), ids)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 3. commit to the zero sharing polynomial with the new Paillier modulus and NTilde, h1, h2 -> (C, D)
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	secrets, err := cmts.NewBuilder().
+		AddPart(pGFlat...).
+		AddPart(preParams.PaillierSK.N).
+		AddPart(preParams.NTildei, preParams.H1i, preParams.H2i).
+		Secrets()
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitment(secrets...)
+
+	// 4. carry over the public data that does not change in a refresh
+	round.save.ShareID = round.input.ShareID
+	round.save.ECDSAPub = round.input.ECDSAPub
+	copy(round.save.Ks, round.input.Ks)
+	round.save.PaillierPKs[i] = &preParams.PaillierSK.PublicKey
+	round.save.NTildej[i] = preParams.NTildei
+	round.save.H1j[i], round.save.H2j[i] = preParams.H1i, preParams.H2i
+
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.deCommit = cmt.D
+
+	// BROADCAST commitment
+	r1msg := NewAuxRound1Message(Pi, cmt.C)
+	round.temp.auxRound1Messages[i] = r1msg
+	round.out <- r1msg
+	return nil
+}
+
+func (round *auxRound1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*AuxRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *auxRound1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.auxRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.temp.commitments[j] = msg.Content().(*AuxRound1Message).UnmarshalCommitment()
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *auxRound1) NextRound() tss.Round {
+	round.started = false
+	return &auxRound2{round}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/crypto/modproof"
+	"github.com/zeta-chain/tss-lib/crypto/prmproof"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *auxRound2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	preParams := &round.save.LocalPreParams
+
+	// 1. prove that the new Paillier modulus is a Paillier-Blum modulus
+	ecdsaPub := round.save.ECDSAPub
+	session := tss.ProofSession(round.SessionID(), Pi, ecdsaPub.X(), ecdsaPub.Y())
+	modProof, err := modproof.NewProof(session, preParams.PaillierSK.N, preParams.PaillierSK.P, preParams.PaillierSK.Q)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 2. prove that h1 = h2^beta, where the order of h2 divides pq
+	prmProof, err := prmproof.NewProof(session, preParams.NTildei, preParams.H1i, preParams.H2i,
+		new(big.Int).Mul(preParams.P, preParams.Q), preParams.Beta)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// BROADCAST de-commitment with the proofs
+	r2msg := NewAuxRound2Message(Pi, round.temp.deCommit, modProof, prmProof)
+	round.temp.auxRound2Messages[i] = r2msg
+	round.out <- r2msg
+	return nil
+}
+
+func (round *auxRound2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*AuxRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *auxRound2) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.auxRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		// de-commitment and proof checks are in round 3
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *auxRound2) NextRound() tss.Round {
+	round.started = false
+	return &auxRound3{round}
+}
@@ -0,0 +1,149 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/zeta-chain/tss-lib/crypto"
+	cmts "github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/facproof"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *auxRound3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	Ps := round.Parties().IDs()
+
+	// 1. open the commitments and verify the mod & prm proofs of the other parties
+	proofFailCulprits := make([]*tss.PartyID, len(Ps))
+	wg := new(sync.WaitGroup)
+	for j, msg := range round.temp.auxRound2Messages {
+		if j == i {
+			continue
+		}
+		r2msg := msg.Content().(*AuxRound2Message)
+		cmtDeCmt := cmts.HashCommitDecommit{C: round.temp.commitments[j], D: r2msg.UnmarshalDeCommitment()}
+		ok, secrets := cmtDeCmt.DeCommit()
+		if !ok {
+			return round.WrapError(errors.New("de-commitment verify failed"), Ps[j])
+		}
+		parts, err := cmts.ParseSecrets(secrets)
+		if err != nil || len(parts) != 3 || len(parts[0]) != round.Threshold()*2 || len(parts[1]) != 1 || len(parts[2]) != 3 {
+			return round.WrapError(errors.New("the de-commitment is malformed"), Ps[j])
+		}
+		PjVs, err := crypto.UnFlattenECPoints(tss.EC(), parts[0])
+		if err != nil {
+			return round.WrapError(err, Ps[j])
+		}
+		paiPK := &paillier.PublicKey{N: parts[1][0]}
+		NTildej, H1j, H2j := parts[2][0], parts[2][1], parts[2][2]
+		if bitLen := paiPK.N.BitLen(); bitLen < round.MinModulusBitLen() || bitLen > round.MaxModulusBitLen() {
+			return round.WrapError(errors.New("got paillier modulus with a bit length out of range for this party"), Ps[j])
+		}
+		if bitLen := NTildej.BitLen(); bitLen < round.MinModulusBitLen() || bitLen > round.MaxModulusBitLen() {
+			return round.WrapError(errors.New("got NTildej with a bit length out of range for this party"), Ps[j])
+		}
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), Ps[j])
+		}
+		wg.Add(1)
+		go func(j int, r2msg *AuxRound2Message) {
+			defer wg.Done()
+			ecdsaPub := round.save.ECDSAPub
+			session := tss.ProofSession(round.SessionID(), Ps[j], ecdsaPub.X(), ecdsaPub.Y())
+			if modProof, err := r2msg.UnmarshalModProof(); err != nil || !modProof.Verify(session, paiPK.N) {
+				proofFailCulprits[j] = Ps[j]
+				return
+			}
+			if prmProof, err := r2msg.UnmarshalPrmProof(); err != nil || !prmProof.Verify(session, NTildej, H1j, H2j) {
+				proofFailCulprits[j] = Ps[j]
+			}
+		}(j, r2msg)
+		round.temp.vjs[j] = PjVs
+		round.save.PaillierPKs[j] = paiPK
+		round.save.NTildej[j] = NTildej
+		round.save.H1j[j], round.save.H2j[j] = H1j, H2j
+	}
+	wg.Wait()
+	if culprits := culpritsOf(proofFailCulprits); len(culprits) > 0 {
+		return round.WrapError(errors.New("mod or prm proof verification failed"), culprits...)
+	}
+
+	// 2. ensure uniqueness of h1j, h2j across the committee
+	// the H1, H2 dupe check is disabled during some benchmarking scenarios to allow reuse of pre-params
+	if !round.Params().UNSAFE_KGIgnoreH1H2Dupes() {
+		h1H2Map := make(map[string]struct{}, len(round.save.H1j)*2)
+		for j, Pj := range Ps {
+			h1JHex, h2JHex := hex.EncodeToString(round.save.H1j[j].Bytes()), hex.EncodeToString(round.save.H2j[j].Bytes())
+			if _, found := h1H2Map[h1JHex]; found {
+				return round.WrapError(errors.New("this h1j was already used by another party"), Pj)
+			}
+			if _, found := h1H2Map[h2JHex]; found {
+				return round.WrapError(errors.New("this h2j was already used by another party"), Pj)
+			}
+			h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+		}
+	}
+
+	// 3. p2p send share ij to Pj, encrypted under its new Paillier key, with a proof that our modulus has no small factors
+	paiSK := round.save.PaillierSK
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		ct, err := round.save.PaillierPKs[j].Encrypt(round.temp.shares[j].Share)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		facProof, err := facproof.NewProof(tss.EC(), paiSK.N, round.save.NTildej[j],
+			round.save.H1j[j], round.save.H2j[j], paiSK.P, paiSK.Q)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		round.out <- NewAuxRound3Message(Pj, Pi, ct, facProof)
+	}
+	// we keep our own share
+	round.ok[i] = true
+	return nil
+}
+
+func (round *auxRound3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*AuxRound3Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *auxRound3) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.auxRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *auxRound3) NextRound() tss.Round {
+	round.started = false
+	return &auxRound4{round}
+}
@@ -0,0 +1,134 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *auxRound4) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	Ps := round.Parties().IDs()
+	q := tss.EC().Params().N
+	modQ := common.ModInt(q)
+
+	// 1. decrypt and verify the shares of zero and the fac proofs of the other parties
+	shares := make([]*big.Int, len(Ps))
+	shares[i] = round.temp.shares[i].Share
+	proofFailCulprits := make([]*tss.PartyID, len(Ps))
+	wg := new(sync.WaitGroup)
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+			r3msg := round.temp.auxRound3Messages[j].Content().(*AuxRound3Message)
+			share, err := round.save.PaillierSK.Decrypt(r3msg.UnmarshalShareCiphertext())
+			if err != nil || share.Cmp(q) >= 0 {
+				proofFailCulprits[j] = Ps[j]
+				return
+			}
+			PjShare := vss.Share{Threshold: round.Threshold(), ID: Pi.KeyInt(), Share: share}
+			if !PjShare.VerifyZeroSecret(round.Threshold(), round.temp.vjs[j]) {
+				proofFailCulprits[j] = Ps[j]
+				return
+			}
+			facProof, err := r3msg.UnmarshalFacProof()
+			if err != nil || !facProof.Verify(tss.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
+				round.save.H1i, round.save.H2i) {
+				proofFailCulprits[j] = Ps[j]
+				return
+			}
+			shares[j] = share
+		}(j)
+	}
+	wg.Wait()
+	if culprits := culpritsOf(proofFailCulprits); len(culprits) > 0 {
+		return round.WrapError(errors.New("vss or fac proof verification failed"), culprits...)
+	}
+
+	// 2. calculate the new xi = xi + sum of the shares of zero
+	xi := new(big.Int).Set(round.input.Xi)
+	for _, share := range shares {
+		xi = modQ.Add(xi, share)
+	}
+	round.save.Xi = xi
+	for j, share := range shares {
+		if j != i {
+			common.WipeBigInt(share)
+		}
+	}
+
+	// 3. sum the zero sharing polynomials
+	Vc := make(vss.Vs, round.Threshold())
+	copy(Vc, round.temp.vs)
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		for c := range Vc {
+			var err error
+			if Vc[c], err = Vc[c].Add(round.temp.vjs[j][c]); err != nil {
+				return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), Pj)
+			}
+		}
+	}
+
+	// 4. compute the new Xj = Xj + sum(Vc * kj^c) for each Pj
+	for j, Pj := range Ps {
+		kj := Pj.KeyInt()
+		BigXj := round.input.BigXj[j]
+		z := big.NewInt(1)
+		for c := 1; c <= round.Threshold(); c++ {
+			var err error
+			z = modQ.Mul(z, kj)
+			if BigXj, err = BigXj.Add(Vc[c-1].ScalarMult(z)); err != nil {
+				return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), Pj)
+			}
+		}
+		round.save.BigXj[j] = BigXj
+	}
+
+	// 5. sanity check our refreshed share against the public data
+	if !crypto.ScalarBaseMult(tss.EC(), round.save.Xi).Equals(round.save.BigXj[i]) {
+		return round.WrapError(errors.New("assertion failed: the refreshed xi*G != Xi"), Pi)
+	}
+
+	round.end <- *round.save
+	return nil
+}
+
+func (round *auxRound4) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *auxRound4) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *auxRound4) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,1106 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.15.3
+// source: protob/ecdsa-cggmp.proto
+
+package cggmp
+
+import (
+	common "github.com/zeta-chain/tss-lib/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message sent during Round 1 of the CGGMP21 auxiliary info and key refresh protocol.
+type AuxRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Commitment []byte `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+}
+
+func (x *AuxRound1Message) Reset() {
+	*x = AuxRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuxRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuxRound1Message) ProtoMessage() {}
+
+func (x *AuxRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuxRound1Message.ProtoReflect.Descriptor instead.
+func (*AuxRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuxRound1Message) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent during Round 2 of the CGGMP21 auxiliary info and key refresh protocol.
+// The de-commitment opens the zero sharing commitments, the Paillier modulus and the Ring-Pedersen parameters.
+type AuxRound2Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeCommitment [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
+	ModProof     [][]byte `protobuf:"bytes,2,rep,name=mod_proof,json=modProof,proto3" json:"mod_proof,omitempty"`
+	PrmProof     [][]byte `protobuf:"bytes,3,rep,name=prm_proof,json=prmProof,proto3" json:"prm_proof,omitempty"`
+}
+
+func (x *AuxRound2Message) Reset() {
+	*x = AuxRound2Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuxRound2Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuxRound2Message) ProtoMessage() {}
+
+func (x *AuxRound2Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuxRound2Message.ProtoReflect.Descriptor instead.
+func (*AuxRound2Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuxRound2Message) GetDeCommitment() [][]byte {
+	if x != nil {
+		return x.DeCommitment
+	}
+	return nil
+}
+
+func (x *AuxRound2Message) GetModProof() [][]byte {
+	if x != nil {
+		return x.ModProof
+	}
+	return nil
+}
+
+func (x *AuxRound2Message) GetPrmProof() [][]byte {
+	if x != nil {
+		return x.PrmProof
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 3 of the CGGMP21 auxiliary info and key refresh protocol.
+type AuxRound3Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShareCiphertext []byte   `protobuf:"bytes,1,opt,name=share_ciphertext,json=shareCiphertext,proto3" json:"share_ciphertext,omitempty"`
+	FacProof        [][]byte `protobuf:"bytes,2,rep,name=fac_proof,json=facProof,proto3" json:"fac_proof,omitempty"`
+}
+
+func (x *AuxRound3Message) Reset() {
+	*x = AuxRound3Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuxRound3Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuxRound3Message) ProtoMessage() {}
+
+func (x *AuxRound3Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuxRound3Message.ProtoReflect.Descriptor instead.
+func (*AuxRound3Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AuxRound3Message) GetShareCiphertext() []byte {
+	if x != nil {
+		return x.ShareCiphertext
+	}
+	return nil
+}
+
+func (x *AuxRound3Message) GetFacProof() [][]byte {
+	if x != nil {
+		return x.FacProof
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 1 of the CGGMP21 presigning protocol.
+type PresignRound1Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EncProof [][]byte `protobuf:"bytes,1,rep,name=enc_proof,json=encProof,proto3" json:"enc_proof,omitempty"`
+}
+
+func (x *PresignRound1Message1) Reset() {
+	*x = PresignRound1Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound1Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound1Message1) ProtoMessage() {}
+
+func (x *PresignRound1Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound1Message1.ProtoReflect.Descriptor instead.
+func (*PresignRound1Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PresignRound1Message1) GetEncProof() [][]byte {
+	if x != nil {
+		return x.EncProof
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 1 of the CGGMP21 presigning protocol.
+type PresignRound1Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	K   []byte `protobuf:"bytes,1,opt,name=k,proto3" json:"k,omitempty"`
+	G   []byte `protobuf:"bytes,2,opt,name=g,proto3" json:"g,omitempty"`
+	Rid []byte `protobuf:"bytes,3,opt,name=rid,proto3" json:"rid,omitempty"`
+}
+
+func (x *PresignRound1Message2) Reset() {
+	*x = PresignRound1Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound1Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound1Message2) ProtoMessage() {}
+
+func (x *PresignRound1Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound1Message2.ProtoReflect.Descriptor instead.
+func (*PresignRound1Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PresignRound1Message2) GetK() []byte {
+	if x != nil {
+		return x.K
+	}
+	return nil
+}
+
+func (x *PresignRound1Message2) GetG() []byte {
+	if x != nil {
+		return x.G
+	}
+	return nil
+}
+
+func (x *PresignRound1Message2) GetRid() []byte {
+	if x != nil {
+		return x.Rid
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 2 of the CGGMP21 presigning protocol.
+type PresignRound2Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	F            []byte   `protobuf:"bytes,1,opt,name=f,proto3" json:"f,omitempty"`
+	FHat         []byte   `protobuf:"bytes,2,opt,name=f_hat,json=fHat,proto3" json:"f_hat,omitempty"`
+	AffGProof    [][]byte `protobuf:"bytes,3,rep,name=aff_g_proof,json=affGProof,proto3" json:"aff_g_proof,omitempty"`
+	AffGHatProof [][]byte `protobuf:"bytes,4,rep,name=aff_g_hat_proof,json=affGHatProof,proto3" json:"aff_g_hat_proof,omitempty"`
+	LogStarProof [][]byte `protobuf:"bytes,5,rep,name=log_star_proof,json=logStarProof,proto3" json:"log_star_proof,omitempty"`
+}
+
+func (x *PresignRound2Message1) Reset() {
+	*x = PresignRound2Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound2Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound2Message1) ProtoMessage() {}
+
+func (x *PresignRound2Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound2Message1.ProtoReflect.Descriptor instead.
+func (*PresignRound2Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PresignRound2Message1) GetF() []byte {
+	if x != nil {
+		return x.F
+	}
+	return nil
+}
+
+func (x *PresignRound2Message1) GetFHat() []byte {
+	if x != nil {
+		return x.FHat
+	}
+	return nil
+}
+
+func (x *PresignRound2Message1) GetAffGProof() [][]byte {
+	if x != nil {
+		return x.AffGProof
+	}
+	return nil
+}
+
+func (x *PresignRound2Message1) GetAffGHatProof() [][]byte {
+	if x != nil {
+		return x.AffGHatProof
+	}
+	return nil
+}
+
+func (x *PresignRound2Message1) GetLogStarProof() [][]byte {
+	if x != nil {
+		return x.LogStarProof
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 2 of the CGGMP21 presigning protocol.
+// The ciphertexts D_ji and DHat_ji for each Pj are indexed by party, so that every party can check their decryption
+// if presigning aborts.
+type PresignRound2Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BigGamma *common.ECPoint `protobuf:"bytes,1,opt,name=big_gamma,json=bigGamma,proto3" json:"big_gamma,omitempty"`
+	D        [][]byte        `protobuf:"bytes,2,rep,name=d,proto3" json:"d,omitempty"`
+	DHat     [][]byte        `protobuf:"bytes,3,rep,name=d_hat,json=dHat,proto3" json:"d_hat,omitempty"`
+}
+
+func (x *PresignRound2Message2) Reset() {
+	*x = PresignRound2Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound2Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound2Message2) ProtoMessage() {}
+
+func (x *PresignRound2Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound2Message2.ProtoReflect.Descriptor instead.
+func (*PresignRound2Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PresignRound2Message2) GetBigGamma() *common.ECPoint {
+	if x != nil {
+		return x.BigGamma
+	}
+	return nil
+}
+
+func (x *PresignRound2Message2) GetD() [][]byte {
+	if x != nil {
+		return x.D
+	}
+	return nil
+}
+
+func (x *PresignRound2Message2) GetDHat() [][]byte {
+	if x != nil {
+		return x.DHat
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 3 of the CGGMP21 presigning protocol.
+type PresignRound3Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LogStarProof [][]byte `protobuf:"bytes,1,rep,name=log_star_proof,json=logStarProof,proto3" json:"log_star_proof,omitempty"`
+}
+
+func (x *PresignRound3Message1) Reset() {
+	*x = PresignRound3Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound3Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound3Message1) ProtoMessage() {}
+
+func (x *PresignRound3Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound3Message1.ProtoReflect.Descriptor instead.
+func (*PresignRound3Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PresignRound3Message1) GetLogStarProof() [][]byte {
+	if x != nil {
+		return x.LogStarProof
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 3 of the CGGMP21 presigning protocol.
+type PresignRound3Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Delta    []byte          `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	BigDelta *common.ECPoint `protobuf:"bytes,2,opt,name=big_delta,json=bigDelta,proto3" json:"big_delta,omitempty"`
+	BigS     *common.ECPoint `protobuf:"bytes,3,opt,name=big_s,json=bigS,proto3" json:"big_s,omitempty"`
+}
+
+func (x *PresignRound3Message2) Reset() {
+	*x = PresignRound3Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound3Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound3Message2) ProtoMessage() {}
+
+func (x *PresignRound3Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound3Message2.ProtoReflect.Descriptor instead.
+func (*PresignRound3Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PresignRound3Message2) GetDelta() []byte {
+	if x != nil {
+		return x.Delta
+	}
+	return nil
+}
+
+func (x *PresignRound3Message2) GetBigDelta() *common.ECPoint {
+	if x != nil {
+		return x.BigDelta
+	}
+	return nil
+}
+
+func (x *PresignRound3Message2) GetBigS() *common.ECPoint {
+	if x != nil {
+		return x.BigS
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 4 of the CGGMP21 presigning protocol, only when the
+// delta or S_j consistency check has failed. It reveals k_i, gamma_i and the plaintexts of the D_ij and DHat_ij
+// received by the party, with the randomness of their encryption, so that the culprits can be identified.
+type PresignRound4Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KI             []byte   `protobuf:"bytes,1,opt,name=k_i,json=kI,proto3" json:"k_i,omitempty"`
+	GammaI         []byte   `protobuf:"bytes,2,opt,name=gamma_i,json=gammaI,proto3" json:"gamma_i,omitempty"`
+	AlphaIJ        [][]byte `protobuf:"bytes,3,rep,name=alpha_i_j,json=alphaIJ,proto3" json:"alpha_i_j,omitempty"`
+	AlphaRandIJ    [][]byte `protobuf:"bytes,4,rep,name=alpha_rand_i_j,json=alphaRandIJ,proto3" json:"alpha_rand_i_j,omitempty"`
+	AlphaHatIJ     [][]byte `protobuf:"bytes,5,rep,name=alpha_hat_i_j,json=alphaHatIJ,proto3" json:"alpha_hat_i_j,omitempty"`
+	AlphaHatRandIJ [][]byte `protobuf:"bytes,6,rep,name=alpha_hat_rand_i_j,json=alphaHatRandIJ,proto3" json:"alpha_hat_rand_i_j,omitempty"`
+}
+
+func (x *PresignRound4Message) Reset() {
+	*x = PresignRound4Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound4Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound4Message) ProtoMessage() {}
+
+func (x *PresignRound4Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound4Message.ProtoReflect.Descriptor instead.
+func (*PresignRound4Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PresignRound4Message) GetKI() []byte {
+	if x != nil {
+		return x.KI
+	}
+	return nil
+}
+
+func (x *PresignRound4Message) GetGammaI() []byte {
+	if x != nil {
+		return x.GammaI
+	}
+	return nil
+}
+
+func (x *PresignRound4Message) GetAlphaIJ() [][]byte {
+	if x != nil {
+		return x.AlphaIJ
+	}
+	return nil
+}
+
+func (x *PresignRound4Message) GetAlphaRandIJ() [][]byte {
+	if x != nil {
+		return x.AlphaRandIJ
+	}
+	return nil
+}
+
+func (x *PresignRound4Message) GetAlphaHatIJ() [][]byte {
+	if x != nil {
+		return x.AlphaHatIJ
+	}
+	return nil
+}
+
+func (x *PresignRound4Message) GetAlphaHatRandIJ() [][]byte {
+	if x != nil {
+		return x.AlphaHatRandIJ
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during the CGGMP21 signing protocol.
+// It is named apart from the SignRound1Message of EdDSA signing, as the messages share one protobuf namespace.
+type CGGMPSignRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sigma []byte `protobuf:"bytes,1,opt,name=sigma,proto3" json:"sigma,omitempty"`
+}
+
+func (x *CGGMPSignRound1Message) Reset() {
+	*x = CGGMPSignRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CGGMPSignRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CGGMPSignRound1Message) ProtoMessage() {}
+
+func (x *CGGMPSignRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CGGMPSignRound1Message.ProtoReflect.Descriptor instead.
+func (*CGGMPSignRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CGGMPSignRound1Message) GetSigma() []byte {
+	if x != nil {
+		return x.Sigma
+	}
+	return nil
+}
+
+// The presignature of a party, the output of the CGGMP21 presigning protocol. It must be used to sign only one message.
+type PreSignatureData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BigR *common.ECPoint `protobuf:"bytes,1,opt,name=big_r,json=bigR,proto3" json:"big_r,omitempty"`
+	// the secret shares: sum(k_i) = k and sum(chi_i) = k * x
+	KI   []byte `protobuf:"bytes,2,opt,name=k_i,json=kI,proto3" json:"k_i,omitempty"`
+	ChiI []byte `protobuf:"bytes,3,opt,name=chi_i,json=chiI,proto3" json:"chi_i,omitempty"`
+	// k_j * R and chi_j * R of every signer by party id, to identify a wrong share of the signature
+	BigRJ map[string]*common.ECPoint `protobuf:"bytes,4,rep,name=big_r_j,json=bigRJ,proto3" json:"big_r_j,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BigSJ map[string]*common.ECPoint `protobuf:"bytes,5,rep,name=big_s_j,json=bigSJ,proto3" json:"big_s_j,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *PreSignatureData) Reset() {
+	*x = PreSignatureData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignatureData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignatureData) ProtoMessage() {}
+
+func (x *PreSignatureData) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignatureData.ProtoReflect.Descriptor instead.
+func (*PreSignatureData) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PreSignatureData) GetBigR() *common.ECPoint {
+	if x != nil {
+		return x.BigR
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetKI() []byte {
+	if x != nil {
+		return x.KI
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetChiI() []byte {
+	if x != nil {
+		return x.ChiI
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetBigRJ() map[string]*common.ECPoint {
+	if x != nil {
+		return x.BigRJ
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetBigSJ() map[string]*common.ECPoint {
+	if x != nil {
+		return x.BigSJ
+	}
+	return nil
+}
+
+var File_protob_ecdsa_cggmp_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_cggmp_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x63,
+	0x67, 0x67, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x2f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x32, 0x0a, 0x10, 0x41, 0x75, 0x78, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d,
+	0x65, 0x6e, 0x74, 0x22, 0x71, 0x0a, 0x10, 0x41, 0x75, 0x78, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c,
+	0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x6d, 0x6f, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x08, 0x6d, 0x6f, 0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6d,
+	0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x72,
+	0x6d, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x5a, 0x0a, 0x10, 0x41, 0x75, 0x78, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x5f, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x43, 0x69, 0x70, 0x68, 0x65,
+	0x72, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x61, 0x63, 0x5f, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x61, 0x63, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x22, 0x34, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x1b, 0x0a, 0x09, 0x65,
+	0x6e, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08,
+	0x65, 0x6e, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x45, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x73,
+	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x32, 0x12, 0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6b, 0x12,
+	0x0c, 0x0a, 0x01, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x67, 0x12, 0x10, 0x0a,
+	0x03, 0x72, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x69, 0x64, 0x22,
+	0xa7, 0x01, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x0c, 0x0a, 0x01, 0x66, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x66, 0x12, 0x13, 0x0a, 0x05, 0x66, 0x5f, 0x68, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x48, 0x61, 0x74, 0x12, 0x1e, 0x0a, 0x0b,
+	0x61, 0x66, 0x66, 0x5f, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x09, 0x61, 0x66, 0x66, 0x47, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x25, 0x0a, 0x0f,
+	0x61, 0x66, 0x66, 0x5f, 0x67, 0x5f, 0x68, 0x61, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x61, 0x66, 0x66, 0x47, 0x48, 0x61, 0x74, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x6f, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x5f,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x6c, 0x6f, 0x67,
+	0x53, 0x74, 0x61, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x61, 0x0a, 0x15, 0x50, 0x72, 0x65,
+	0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x32, 0x12, 0x25, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x08, 0x62, 0x69, 0x67, 0x47, 0x61, 0x6d, 0x6d, 0x61, 0x12, 0x0c, 0x0a, 0x01, 0x64, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x01, 0x64, 0x12, 0x13, 0x0a, 0x05, 0x64, 0x5f, 0x68, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x48, 0x61, 0x74, 0x22, 0x3d, 0x0a, 0x15,
+	0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x6f, 0x67, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x6c,
+	0x6f, 0x67, 0x53, 0x74, 0x61, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x73, 0x0a, 0x15, 0x50,
+	0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x32, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x25, 0x0a, 0x09, 0x62, 0x69,
+	0x67, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e,
+	0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x62, 0x69, 0x67, 0x44, 0x65, 0x6c, 0x74,
+	0x61, 0x12, 0x1d, 0x0a, 0x05, 0x62, 0x69, 0x67, 0x5f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x08, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x04, 0x62, 0x69, 0x67, 0x53,
+	0x22, 0xd0, 0x01, 0x0a, 0x14, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x34, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0f, 0x0a, 0x03, 0x6b, 0x5f, 0x69,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x6b, 0x49, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61,
+	0x6d, 0x6d, 0x61, 0x5f, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x67, 0x61, 0x6d,
+	0x6d, 0x61, 0x49, 0x12, 0x1a, 0x0a, 0x09, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x69, 0x5f, 0x6a,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x49, 0x4a, 0x12,
+	0x23, 0x0a, 0x0e, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x72, 0x61, 0x6e, 0x64, 0x5f, 0x69, 0x5f,
+	0x6a, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x52, 0x61,
+	0x6e, 0x64, 0x49, 0x4a, 0x12, 0x21, 0x0a, 0x0d, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x68, 0x61,
+	0x74, 0x5f, 0x69, 0x5f, 0x6a, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x48, 0x61, 0x74, 0x49, 0x4a, 0x12, 0x2a, 0x0a, 0x12, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x5f, 0x68, 0x61, 0x74, 0x5f, 0x72, 0x61, 0x6e, 0x64, 0x5f, 0x69, 0x5f, 0x6a, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0e, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x48, 0x61, 0x74, 0x52, 0x61, 0x6e,
+	0x64, 0x49, 0x4a, 0x22, 0x2e, 0x0a, 0x16, 0x43, 0x47, 0x47, 0x4d, 0x50, 0x53, 0x69, 0x67, 0x6e,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x69,
+	0x67, 0x6d, 0x61, 0x22, 0xcb, 0x02, 0x0a, 0x10, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x0a, 0x05, 0x62, 0x69, 0x67, 0x5f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x04, 0x62, 0x69, 0x67, 0x52, 0x12, 0x0f, 0x0a, 0x03, 0x6b, 0x5f, 0x69, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x6b, 0x49, 0x12, 0x13, 0x0a, 0x05, 0x63, 0x68, 0x69, 0x5f,
+	0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x68, 0x69, 0x49, 0x12, 0x34, 0x0a,
+	0x07, 0x62, 0x69, 0x67, 0x5f, 0x72, 0x5f, 0x6a, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x2e, 0x42, 0x69, 0x67, 0x52, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x62, 0x69,
+	0x67, 0x52, 0x4a, 0x12, 0x34, 0x0a, 0x07, 0x62, 0x69, 0x67, 0x5f, 0x73, 0x5f, 0x6a, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x42, 0x69, 0x67, 0x53, 0x4a, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x05, 0x62, 0x69, 0x67, 0x53, 0x4a, 0x1a, 0x42, 0x0a, 0x0a, 0x42, 0x69, 0x67,
+	0x52, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x42, 0x0a,
+	0x0a, 0x42, 0x69, 0x67, 0x53, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1e, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x45,
+	0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x7a, 0x65, 0x74, 0x61, 0x2d, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x74, 0x73, 0x73, 0x2d, 0x6c,
+	0x69, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x63, 0x67, 0x67, 0x6d, 0x70, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_ecdsa_cggmp_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_cggmp_proto_rawDescData = file_protob_ecdsa_cggmp_proto_rawDesc
+)
+
+func file_protob_ecdsa_cggmp_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_cggmp_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_cggmp_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_cggmp_proto_rawDescData)
+	})
+	return file_protob_ecdsa_cggmp_proto_rawDescData
+}
+
+var file_protob_ecdsa_cggmp_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_protob_ecdsa_cggmp_proto_goTypes = []interface{}{
+	(*AuxRound1Message)(nil),       // 0: AuxRound1Message
+	(*AuxRound2Message)(nil),       // 1: AuxRound2Message
+	(*AuxRound3Message)(nil),       // 2: AuxRound3Message
+	(*PresignRound1Message1)(nil),  // 3: PresignRound1Message1
+	(*PresignRound1Message2)(nil),  // 4: PresignRound1Message2
+	(*PresignRound2Message1)(nil),  // 5: PresignRound2Message1
+	(*PresignRound2Message2)(nil),  // 6: PresignRound2Message2
+	(*PresignRound3Message1)(nil),  // 7: PresignRound3Message1
+	(*PresignRound3Message2)(nil),  // 8: PresignRound3Message2
+	(*PresignRound4Message)(nil),   // 9: PresignRound4Message
+	(*CGGMPSignRound1Message)(nil), // 10: CGGMPSignRound1Message
+	(*PreSignatureData)(nil),       // 11: PreSignatureData
+	nil,                            // 12: PreSignatureData.BigRJEntry
+	nil,                            // 13: PreSignatureData.BigSJEntry
+	(*common.ECPoint)(nil),         // 14: ECPoint
+}
+var file_protob_ecdsa_cggmp_proto_depIdxs = []int32{
+	14, // 0: PresignRound2Message2.big_gamma:type_name -> ECPoint
+	14, // 1: PresignRound3Message2.big_delta:type_name -> ECPoint
+	14, // 2: PresignRound3Message2.big_s:type_name -> ECPoint
+	14, // 3: PreSignatureData.big_r:type_name -> ECPoint
+	12, // 4: PreSignatureData.big_r_j:type_name -> PreSignatureData.BigRJEntry
+	13, // 5: PreSignatureData.big_s_j:type_name -> PreSignatureData.BigSJEntry
+	14, // 6: PreSignatureData.BigRJEntry.value:type_name -> ECPoint
+	14, // 7: PreSignatureData.BigSJEntry.value:type_name -> ECPoint
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_cggmp_proto_init() }
+func file_protob_ecdsa_cggmp_proto_init() {
+	if File_protob_ecdsa_cggmp_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_cggmp_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuxRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuxRound2Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuxRound3Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound1Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound1Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound2Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound2Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound3Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound3Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound4Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CGGMPSignRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignatureData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_cggmp_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_cggmp_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_cggmp_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_cggmp_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_cggmp_proto = out.File
+	file_protob_ecdsa_cggmp_proto_rawDesc = nil
+	file_protob_ecdsa_cggmp_proto_goTypes = nil
+	file_protob_ecdsa_cggmp_proto_depIdxs = nil
+}
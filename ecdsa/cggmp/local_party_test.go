@@ -0,0 +1,278 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	. "github.com/zeta-chain/tss-lib/ecdsa/cggmp"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/ecdsa/signing"
+	"github.com/zeta-chain/tss-lib/test"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	oldKeys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: key refresh with auxiliary info; each party takes the pre-params of the next one as its new Paillier key and NTilde
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, len(pIDs))
+	auxEndCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+	for j, pID := range pIDs {
+		params := tss.NewParameters(p2pCtx, pID, len(pIDs), threshold)
+		preParams := oldKeys[(j+1)%len(pIDs)].LocalPreParams
+		parties = append(parties, NewAuxInfoLocalParty(params, oldKeys[j], outCh, auxEndCh, preParams))
+	}
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	done := make(chan struct{})
+	go func() {
+		for range pIDs {
+			save := <-auxEndCh
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = save
+			done <- struct{}{}
+		}
+	}()
+	errs := runParties(parties, outCh, done)
+	if !assert.Empty(t, errs) {
+		return
+	}
+
+	// the public key is unchanged, every share has been re-randomised and the Paillier keys have been rotated
+	for j, key := range newKeys {
+		assert.True(t, key.ECDSAPub.Equals(oldKeys[0].ECDSAPub), "ensure the ECDSAPub is unchanged")
+		assert.NotEqual(t, 0, key.Xi.Cmp(oldKeys[j].Xi), "ensure xj has been refreshed")
+		for k, BigXk := range key.BigXj {
+			assert.True(t, BigXk.Equals(newKeys[k].BigXj[k]), "ensure all parties agree on BigX_k")
+			assert.Equal(t, 0, key.PaillierPKs[k].N.Cmp(oldKeys[(k+1)%len(pIDs)].PaillierSK.N), "ensure the Paillier keys are rotated")
+			assert.Equal(t, 0, key.NTildej[k].Cmp(oldKeys[(k+1)%len(pIDs)].NTildei), "ensure NTilde is rotated")
+		}
+		assert.True(t, key.BigXj[j].Equals(crypto.ScalarBaseMult(tss.EC(), key.Xi)), "ensure BigX_j == g^x_j")
+	}
+	shares := make(vss.Shares, 0, threshold+1)
+	for j := 0; j <= threshold; j++ {
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: newKeys[j].ShareID, Share: newKeys[j].Xi})
+	}
+	secret, err := shares.ReConstruct()
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.EC(), secret).Equals(oldKeys[0].ECDSAPub), "ensure the refreshed shares reconstruct the secret key")
+
+	// PHASE: presign with a subset of the refreshed keys
+	signPIDs := pIDs[:threshold+1]
+	presigs, errs := runPresigning(t, signPIDs, newKeys)
+	if !assert.Empty(t, errs) {
+		return
+	}
+	for _, presig := range presigs[1:] {
+		assert.True(t, proto.Equal(presigs[0].GetBigR(), presig.GetBigR()), "ensure all parties agree on R")
+	}
+	usedPresigs := make([]*PreSignatureData, len(presigs))
+	for j, presig := range presigs {
+		usedPresigs[j] = proto.Clone(presig).(*PreSignatureData)
+	}
+
+	// PHASE: sign in one round
+	msg := big.NewInt(42)
+	sigs, errs := runSigning(t, signPIDs, newKeys, presigs, msg)
+	if !assert.Empty(t, errs) {
+		return
+	}
+	pk := newKeys[0].ECDSAPub.ToECDSAPubKey()
+	for _, sig := range sigs {
+		r, s := new(big.Int).SetBytes(sig.GetSignature().GetR()), new(big.Int).SetBytes(sig.GetSignature().GetS())
+		assert.True(t, ecdsa.Verify(pk, msg.Bytes(), r, s), "ecdsa verify must pass")
+		assert.Equal(t, sigs[0].GetSignature().GetSignature(), sig.GetSignature().GetSignature(), "ensure all parties output the same signature")
+	}
+	for _, presig := range presigs {
+		assert.Empty(t, presig.GetKI(), "ensure the presignature shares are wiped")
+		assert.Empty(t, presig.GetChiI(), "ensure the presignature shares are wiped")
+	}
+
+	// a used presignature cannot sign again
+	_, errs = runSigning(t, signPIDs, newKeys, presigs[:1], msg)
+	assert.Len(t, errs, 1, "ensure a used presignature is refused")
+
+	// a sign party closed before it starts wipes its presignature as well
+	unused := proto.Clone(usedPresigs[1]).(*PreSignatureData)
+	kI, chiI := unused.GetKI(), unused.GetChiI()
+	params := tss.NewParameters(tss.NewPeerContext(signPIDs), signPIDs[1], len(signPIDs), threshold)
+	P := NewSignLocalParty(msg, params, newKeys[1], unused, make(chan tss.Message, len(signPIDs)), make(chan *signing.SignatureData, 1))
+	P.Close()
+	assert.True(t, test.WipedBytes(kI, chiI), "ensure the presignature shares are wiped")
+	assert.Empty(t, unused.GetKI())
+	assert.Error(t, P.Start(), "ensure a closed party cannot start")
+
+	// PHASE: a wrong share of the signature is attributed to its sender
+	usedPresigs[0].ChiI = new(big.Int).Add(new(big.Int).SetBytes(usedPresigs[0].GetChiI()), big.NewInt(1)).Bytes()
+	_, errs = runSigning(t, signPIDs, newKeys, usedPresigs, msg)
+	assert.Len(t, errs, len(signPIDs), "ensure no party outputs a signature")
+	for _, err := range errs {
+		if err.Victim().Index == 0 {
+			continue // P0 only sees that the signature does not verify
+		}
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, signPIDs[0].Id, err.Culprits()[0].Id, "ensure P0 is named as the culprit")
+		}
+	}
+}
+
+// TestE2EConcurrentPresignIdentifiedAbort runs presigning with a party whose Paillier decryption is faulty, so that it sends
+// a wrong delta_i while all of its proofs pass. The identification step names it.
+func TestE2EConcurrentPresignIdentifiedAbort(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	faultySK := *keys[0].PaillierSK
+	faultySK.LambdaN = new(big.Int).Add(faultySK.LambdaN, big.NewInt(1))
+	keys[0].PaillierSK = &faultySK
+
+	presigs, errs := runPresigning(t, signPIDs, keys)
+	assert.Empty(t, presigs, "ensure no party outputs a presignature")
+	assert.Len(t, errs, len(signPIDs), "ensure every party fails")
+	for _, err := range errs {
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, signPIDs[0].Id, err.Culprits()[0].Id, "ensure P0 is named as the culprit")
+		}
+	}
+}
+
+func TestAuxInfoRejectsPartialCommittee(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the key was made by all of the participants, but only T+1 of them take part
+	params := tss.NewParameters(tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewAuxInfoLocalParty(params, keys[0], make(chan tss.Message, len(signPIDs)), make(chan keygen.LocalPartySaveData, 1))
+	if err := P.Start(); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "every party holding a share of the key must take part")
+	}
+}
+
+// runPresigning runs the presign parties and returns the presignatures ordered by signer, and the errors of the parties that failed
+func runPresigning(t *testing.T, signPIDs tss.SortedPartyIDs, keys []keygen.LocalPartySaveData) ([]*PreSignatureData, []*tss.Error) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *PreSignatureData, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for j, signPID := range signPIDs {
+		params := tss.NewParameters(p2pCtx, signPID, len(signPIDs), testThreshold)
+		parties = append(parties, NewPresignLocalParty(params, keys[j], outCh, endCh))
+	}
+	presigs := make([]*PreSignatureData, 0, len(signPIDs))
+	done, quit := make(chan struct{}), make(chan struct{})
+	defer close(quit)
+	go func() {
+		for {
+			select {
+			case presig := <-endCh:
+				presigs = append(presigs, presig)
+				done <- struct{}{}
+			case <-quit:
+				return
+			}
+		}
+	}()
+	errs := runParties(parties, outCh, done)
+	if len(errs) > 0 {
+		return presigs, errs
+	}
+	// the outputs arrive in any order; index them by the party whose k_i * R they hold
+	ordered := make([]*PreSignatureData, len(signPIDs))
+	for _, presig := range presigs {
+		bigR, err := crypto.NewECPointFromProtobuf(presig.GetBigR())
+		assert.NoError(t, err)
+		bigRI := bigR.ScalarMult(new(big.Int).SetBytes(presig.GetKI()))
+		for j, signPID := range signPIDs {
+			if proto.Equal(bigRI.ToProtobufPoint(), presig.GetBigRJ()[signPID.Id]) {
+				ordered[j] = presig
+			}
+		}
+	}
+	return ordered, nil
+}
+
+// runSigning runs the sign parties of the first len(presigs) signers and returns the signatures and errors of those that finished
+func runSigning(t *testing.T, signPIDs tss.SortedPartyIDs, keys []keygen.LocalPartySaveData, presigs []*PreSignatureData, msg *big.Int) ([]*signing.SignatureData, []*tss.Error) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *signing.SignatureData, len(signPIDs))
+	parties := make([]tss.Party, 0, len(presigs))
+	for j, presig := range presigs {
+		params := tss.NewParameters(p2pCtx, signPIDs[j], len(signPIDs), testThreshold)
+		parties = append(parties, NewSignLocalParty(msg, params, keys[j], presig, outCh, endCh))
+	}
+	sigs := make([]*signing.SignatureData, 0, len(presigs))
+	done, quit := make(chan struct{}), make(chan struct{})
+	defer close(quit)
+	go func() {
+		for {
+			select {
+			case sig := <-endCh:
+				sigs = append(sigs, sig)
+				done <- struct{}{}
+			case <-quit:
+				return
+			}
+		}
+	}()
+	errs := runParties(parties, outCh, done)
+	return sigs, errs
+}
+
+// runParties runs the parties with test.RunParties until each has either failed or signalled on `done`
+func runParties(parties []tss.Party, outCh chan tss.Message, done <-chan struct{}) []*tss.Error {
+	errCh := make(chan *tss.Error, len(parties)*len(parties))
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, nil, stop)
+
+	errs := make([]*tss.Error, 0, len(parties))
+	for finished := 0; finished < len(parties); {
+		select {
+		case err := <-errCh:
+			common.Logger.Infof("Error: %s", err)
+			errs = append(errs, err)
+			finished++
+
+		case <-done:
+			finished++
+		}
+	}
+	return errs
+}
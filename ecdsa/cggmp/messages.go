@@ -0,0 +1,441 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/affgproof"
+	cmt "github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/encproof"
+	"github.com/zeta-chain/tss-lib/crypto/facproof"
+	"github.com/zeta-chain/tss-lib/crypto/logstarproof"
+	"github.com/zeta-chain/tss-lib/crypto/modproof"
+	"github.com/zeta-chain/tss-lib/crypto/prmproof"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-cggmp.pb.go
+
+var (
+	// Ensure that CGGMP messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*AuxRound1Message)(nil),
+		(*AuxRound2Message)(nil),
+		(*AuxRound3Message)(nil),
+		(*PresignRound1Message1)(nil),
+		(*PresignRound1Message2)(nil),
+		(*PresignRound2Message1)(nil),
+		(*PresignRound2Message2)(nil),
+		(*PresignRound3Message1)(nil),
+		(*PresignRound3Message2)(nil),
+		(*PresignRound4Message)(nil),
+		(*CGGMPSignRound1Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewAuxRound1Message(
+	from *tss.PartyID,
+	ct cmt.HashCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &AuxRound1Message{
+		Commitment: ct.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *AuxRound1Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *AuxRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewAuxRound2Message(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	modProof *modproof.ProofMod,
+	prmProof *prmproof.ProofPrm,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	modProofBzs, prmProofBzs := modProof.Bytes(), prmProof.Bytes()
+	content := &AuxRound2Message{
+		DeCommitment: common.BigIntsToBytes(deCommitment),
+		ModProof:     modProofBzs[:],
+		PrmProof:     prmProofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *AuxRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment()) &&
+		common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetPrmProof(), prmproof.ProofPrmBytesParts)
+}
+
+func (m *AuxRound2Message) UnmarshalDeCommitment() []*big.Int {
+	return cmt.NewHashDeCommitmentFromBytes(m.GetDeCommitment())
+}
+
+func (m *AuxRound2Message) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
+
+func (m *AuxRound2Message) UnmarshalPrmProof() (*prmproof.ProofPrm, error) {
+	return prmproof.NewProofFromBytes(m.GetPrmProof())
+}
+
+// ----- //
+
+func NewAuxRound3Message(
+	to, from *tss.PartyID,
+	shareCiphertext *big.Int,
+	facProof *facproof.ProofFac,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	facProofBzs := facProof.Bytes()
+	content := &AuxRound3Message{
+		ShareCiphertext: shareCiphertext.Bytes(),
+		FacProof:        facProofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *AuxRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShareCiphertext()) &&
+		common.NonEmptyMultiBytes(m.GetFacProof(), facproof.ProofFacBytesParts)
+}
+
+func (m *AuxRound3Message) UnmarshalShareCiphertext() *big.Int {
+	return new(big.Int).SetBytes(m.GetShareCiphertext())
+}
+
+func (m *AuxRound3Message) UnmarshalFacProof() (*facproof.ProofFac, error) {
+	return facproof.NewProofFromBytes(m.GetFacProof())
+}
+
+// ----- //
+
+func NewPresignRound1Message1(
+	to, from *tss.PartyID,
+	encProof *encproof.ProofEnc,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	encProofBzs := encProof.Bytes()
+	content := &PresignRound1Message1{
+		EncProof: encProofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound1Message1) ValidateBasic() bool {
+	return m != nil && common.NonEmptyMultiBytes(m.GetEncProof(), encproof.ProofEncBytesParts)
+}
+
+func (m *PresignRound1Message1) UnmarshalEncProof() (*encproof.ProofEnc, error) {
+	return encproof.NewProofFromBytes(m.GetEncProof())
+}
+
+// ----- //
+
+func NewPresignRound1Message2(
+	from *tss.PartyID,
+	K, G, rid *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound1Message2{
+		K:   K.Bytes(),
+		G:   G.Bytes(),
+		Rid: rid.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound1Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetK()) &&
+		common.NonEmptyBytes(m.GetG()) &&
+		common.NonEmptyBytes(m.GetRid())
+}
+
+func (m *PresignRound1Message2) UnmarshalK() *big.Int {
+	return new(big.Int).SetBytes(m.GetK())
+}
+
+func (m *PresignRound1Message2) UnmarshalG() *big.Int {
+	return new(big.Int).SetBytes(m.GetG())
+}
+
+func (m *PresignRound1Message2) UnmarshalRid() *big.Int {
+	return new(big.Int).SetBytes(m.GetRid())
+}
+
+// ----- //
+
+func NewPresignRound2Message1(
+	to, from *tss.PartyID,
+	F, FHat *big.Int,
+	affGProof, affGHatProof *affgproof.ProofAffG,
+	logStarProof *logstarproof.ProofLogStar,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	affGProofBzs, affGHatProofBzs, logStarProofBzs := affGProof.Bytes(), affGHatProof.Bytes(), logStarProof.Bytes()
+	content := &PresignRound2Message1{
+		F:            F.Bytes(),
+		FHat:         FHat.Bytes(),
+		AffGProof:    affGProofBzs[:],
+		AffGHatProof: affGHatProofBzs[:],
+		LogStarProof: logStarProofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetF()) &&
+		common.NonEmptyBytes(m.GetFHat()) &&
+		common.NonEmptyMultiBytes(m.GetAffGProof(), affgproof.ProofAffGBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetAffGHatProof(), affgproof.ProofAffGBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetLogStarProof(), logstarproof.ProofLogStarBytesParts)
+}
+
+func (m *PresignRound2Message1) UnmarshalF() *big.Int {
+	return new(big.Int).SetBytes(m.GetF())
+}
+
+func (m *PresignRound2Message1) UnmarshalFHat() *big.Int {
+	return new(big.Int).SetBytes(m.GetFHat())
+}
+
+func (m *PresignRound2Message1) UnmarshalAffGProof() (*affgproof.ProofAffG, error) {
+	return affgproof.NewProofFromBytes(m.GetAffGProof())
+}
+
+func (m *PresignRound2Message1) UnmarshalAffGHatProof() (*affgproof.ProofAffG, error) {
+	return affgproof.NewProofFromBytes(m.GetAffGHatProof())
+}
+
+func (m *PresignRound2Message1) UnmarshalLogStarProof() (*logstarproof.ProofLogStar, error) {
+	return logstarproof.NewProofFromBytes(m.GetLogStarProof())
+}
+
+// ----- //
+
+func NewPresignRound2Message2(
+	from *tss.PartyID,
+	bigGamma *crypto.ECPoint,
+	Ds, DHats []*big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound2Message2{
+		BigGamma: bigGamma.ToProtobufPoint(),
+		D:        common.BigIntsToBytes(Ds),
+		DHat:     common.BigIntsToBytes(DHats),
+	}
+	// this hack makes the ValidateBasic pass because the [i] index position for this P is empty in these arrays
+	content.GetD()[from.Index] = []byte{1}
+	content.GetDHat()[from.Index] = []byte{1}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		m.GetBigGamma().ValidateBasic() &&
+		common.NonEmptyMultiBytes(m.GetD()) &&
+		common.NonEmptyMultiBytes(m.GetDHat(), len(m.GetD()))
+}
+
+func (m *PresignRound2Message2) UnmarshalBigGamma() (*crypto.ECPoint, error) {
+	return crypto.NewECPointFromProtobuf(m.GetBigGamma())
+}
+
+func (m *PresignRound2Message2) UnmarshalDs() []*big.Int {
+	return common.ByteSlicesToBigInts(m.GetD())
+}
+
+func (m *PresignRound2Message2) UnmarshalDHats() []*big.Int {
+	return common.ByteSlicesToBigInts(m.GetDHat())
+}
+
+// ----- //
+
+func NewPresignRound3Message1(
+	to, from *tss.PartyID,
+	logStarProof *logstarproof.ProofLogStar,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	logStarProofBzs := logStarProof.Bytes()
+	content := &PresignRound3Message1{
+		LogStarProof: logStarProofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound3Message1) ValidateBasic() bool {
+	return m != nil && common.NonEmptyMultiBytes(m.GetLogStarProof(), logstarproof.ProofLogStarBytesParts)
+}
+
+func (m *PresignRound3Message1) UnmarshalLogStarProof() (*logstarproof.ProofLogStar, error) {
+	return logstarproof.NewProofFromBytes(m.GetLogStarProof())
+}
+
+// ----- //
+
+func NewPresignRound3Message2(
+	from *tss.PartyID,
+	delta *big.Int,
+	bigDelta, bigS *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound3Message2{
+		Delta:    delta.Bytes(),
+		BigDelta: bigDelta.ToProtobufPoint(),
+		BigS:     bigS.ToProtobufPoint(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound3Message2) ValidateBasic() bool {
+	// delta may be zero, which marshals to no bytes
+	return m != nil &&
+		m.GetBigDelta().ValidateBasic() &&
+		m.GetBigS().ValidateBasic()
+}
+
+func (m *PresignRound3Message2) UnmarshalDelta() *big.Int {
+	return new(big.Int).SetBytes(m.GetDelta())
+}
+
+func (m *PresignRound3Message2) UnmarshalBigDelta() (*crypto.ECPoint, error) {
+	return crypto.NewECPointFromProtobuf(m.GetBigDelta())
+}
+
+func (m *PresignRound3Message2) UnmarshalBigS() (*crypto.ECPoint, error) {
+	return crypto.NewECPointFromProtobuf(m.GetBigS())
+}
+
+// ----- //
+
+func NewPresignRound4Message(
+	from *tss.PartyID,
+	k, gamma *big.Int,
+	alphas, alphaRands, alphaHats, alphaHatRands []*big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound4Message{
+		KI:             k.Bytes(),
+		GammaI:         gamma.Bytes(),
+		AlphaIJ:        common.BigIntsToBytes(alphas),
+		AlphaRandIJ:    common.BigIntsToBytes(alphaRands),
+		AlphaHatIJ:     common.BigIntsToBytes(alphaHats),
+		AlphaHatRandIJ: common.BigIntsToBytes(alphaHatRands),
+	}
+	// this hack makes the ValidateBasic pass because the [i] index position for this P is empty in these arrays
+	content.GetAlphaIJ()[from.Index] = []byte{1}
+	content.GetAlphaRandIJ()[from.Index] = []byte{1}
+	content.GetAlphaHatIJ()[from.Index] = []byte{1}
+	content.GetAlphaHatRandIJ()[from.Index] = []byte{1}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound4Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetKI()) &&
+		common.NonEmptyBytes(m.GetGammaI()) &&
+		common.NonEmptyMultiBytes(m.GetAlphaIJ()) &&
+		common.NonEmptyMultiBytes(m.GetAlphaRandIJ(), len(m.GetAlphaIJ())) &&
+		common.NonEmptyMultiBytes(m.GetAlphaHatIJ(), len(m.GetAlphaIJ())) &&
+		common.NonEmptyMultiBytes(m.GetAlphaHatRandIJ(), len(m.GetAlphaIJ()))
+}
+
+func (m *PresignRound4Message) UnmarshalKI() *big.Int {
+	return new(big.Int).SetBytes(m.GetKI())
+}
+
+func (m *PresignRound4Message) UnmarshalGammaI() *big.Int {
+	return new(big.Int).SetBytes(m.GetGammaI())
+}
+
+// ----- //
+
+func NewCGGMPSignRound1Message(
+	from *tss.PartyID,
+	sigma *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &CGGMPSignRound1Message{
+		Sigma: sigma.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *CGGMPSignRound1Message) ValidateBasic() bool {
+	// sigma may be zero, which marshals to no bytes
+	return m != nil
+}
+
+func (m *CGGMPSignRound1Message) UnmarshalSigma() *big.Int {
+	return new(big.Int).SetBytes(m.GetSigma())
+}
@@ -0,0 +1,257 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/ecdsa/signing"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*PresignLocalParty)(nil)
+var _ fmt.Stringer = (*PresignLocalParty)(nil)
+
+// the bit length of the rid_i sampled by each party in round 1 of presigning
+const ridBitLen = 256
+
+type (
+	// PresignLocalParty runs the three rounds of CGGMP21 presigning (Fig. 7 of the paper) among the parties of params,
+	// before the message is known. Its PreSignatureData is then used by SignLocalParty to sign one message in one round.
+	PresignLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		key    keygen.LocalPartySaveData
+		keyErr error // set when the key data cannot be used for presigning
+		temp   presignTempData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *PreSignatureData
+	}
+
+	presignMessageStore struct {
+		presignRound1Message1s,
+		presignRound1Message2s,
+		presignRound2Message1s,
+		presignRound2Message2s,
+		presignRound3Message1s,
+		presignRound3Message2s,
+		presignRound4Messages []tss.ParsedMessage
+	}
+
+	presignTempData struct {
+		presignMessageStore
+
+		// temp data (thrown away after presigning)
+		ssid  *big.Int
+		wI    *big.Int
+		bigWs []*crypto.ECPoint
+
+		// round 1: k_i, gamma_i and their ciphertexts K_i = enc_i(k_i; rho_i), G_i = enc_i(gamma_i; nu_i), and the random
+		// rid_j of each party that makes the session of this presignature fresh
+		k, gamma, rho, nu *big.Int
+		Ks, Gs, rids      []*big.Int
+
+		// round 2: our additive shares are -beta_ij and -betaHat_ij for each Pj
+		bigGammaI       *crypto.ECPoint
+		betas, betaHats []*big.Int
+		bigGammaJs      []*crypto.ECPoint
+
+		// round 3: alpha_ij and alphaHat_ij are the plaintexts of the D_ij and DHat_ij we received; they are revealed
+		// with the randomness of their encryption if presigning aborts
+		alphas, alphaRands, alphaHats, alphaHatRands []*big.Int
+		bigGamma, bigDeltaI, bigSI                   *crypto.ECPoint
+		delta, chi                                   *big.Int
+
+		// round 4
+		deltas             []*big.Int
+		bigDeltaJs, bigSJs []*crypto.ECPoint
+	}
+)
+
+// NewPresignLocalParty constructs the party for CGGMP21 presigning among the parties of params, which must hold shares of
+// `key`. The key must have been refreshed with NewAuxInfoLocalParty so that every party's Paillier modulus is proven to be
+// a Paillier-Blum modulus without small factors. Keys from a weighted keygen are not supported.
+//
+// A party whose proof fails is named as a culprit. If the final delta*G = sum(Delta_j) or sum(S_j) = delta*ECDSAPub check
+// fails, the identification step of CGGMP21 Fig. 7 runs as a fourth round: every party reveals k_i, gamma_i and the plaintexts
+// of the MtA ciphertexts it received with the randomness of their encryption, and the parties whose delta_i or S_i do not
+// match are named as culprits.
+func NewPresignLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &PresignLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		key:       key,
+		temp:      presignTempData{},
+		out:       out,
+		end:       end,
+	}
+	if p.keyErr = key.ValidateThreshold(params.Threshold()); p.keyErr == nil {
+		if key.IsWeighted() {
+			p.keyErr = errors.New("keys from a weighted keygen are not supported")
+		} else {
+			p.key = keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+		}
+	}
+	// msgs init
+	p.temp.presignRound1Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound1Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound4Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.Ks = make([]*big.Int, partyCount)
+	p.temp.Gs = make([]*big.Int, partyCount)
+	p.temp.rids = make([]*big.Int, partyCount)
+	p.temp.betas = make([]*big.Int, partyCount)
+	p.temp.betaHats = make([]*big.Int, partyCount)
+	p.temp.bigGammaJs = make([]*crypto.ECPoint, partyCount)
+	p.temp.alphas = make([]*big.Int, partyCount)
+	p.temp.alphaRands = make([]*big.Int, partyCount)
+	p.temp.alphaHats = make([]*big.Int, partyCount)
+	p.temp.alphaHatRands = make([]*big.Int, partyCount)
+	return p
+}
+
+func (p *PresignLocalParty) FirstRound() tss.Round {
+	return newPresignRound1(p.params, &p.key, &p.temp, p.out, p.end)
+}
+
+func (p *PresignLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskNamePresign, func(round tss.Round) *tss.Error {
+		if p.keyErr != nil {
+			return round.WrapError(fmt.Errorf("invalid key data: %v", p.keyErr))
+		}
+		i, ks := p.PartyID().Index, p.key.Ks
+		if p.params.Threshold()+1 > len(ks) {
+			return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", p.params.Threshold()+1, len(ks)))
+		}
+		wI, bigWs, err := signing.PrepareForSigning(i, len(ks), p.key.Xi, ks, p.key.BigXj)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		p.temp.wI, p.temp.bigWs = wI, bigWs
+		p.temp.ssid = presignSSID(p.params, &p.key)
+		return nil
+	})
+}
+
+func (p *PresignLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskNamePresign)
+}
+
+// Close stops the party and overwrites w_i, k_i, gamma_i, the nonces of their ciphertexts, the MtA shares, delta_i and chi_i.
+// The key data given to NewPresignLocalParty and the presignature sent through `end` are left as is.
+func (p *PresignLocalParty) Close() {
+	tss.BaseClose(p, p.temp.wipe)
+}
+
+func (p *PresignLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *PresignLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *PresignLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *PresignRound1Message1:
+		p.temp.presignRound1Message1s[fromPIdx] = msg
+	case *PresignRound1Message2:
+		p.temp.presignRound1Message2s[fromPIdx] = msg
+	case *PresignRound2Message1:
+		p.temp.presignRound2Message1s[fromPIdx] = msg
+	case *PresignRound2Message2:
+		p.temp.presignRound2Message2s[fromPIdx] = msg
+	case *PresignRound3Message1:
+		p.temp.presignRound3Message1s[fromPIdx] = msg
+	case *PresignRound3Message2:
+		p.temp.presignRound3Message2s[fromPIdx] = msg
+	case *PresignRound4Message:
+		p.temp.presignRound4Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *PresignLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *PresignLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// presignSSID identifies a presigning session by the signers and their public key data. The proofs of round 1 are bound to
+// it and to the rid_i of the prover; once every rid_j is known, freshSSID binds those of the later rounds to this presignature.
+func presignSSID(params *tss.Parameters, key *keygen.LocalPartySaveData) *big.Int {
+	in := []*big.Int{params.SessionID(), key.ECDSAPub.X(), key.ECDSAPub.Y()}
+	for j := range params.Parties().IDs() {
+		in = append(in, key.BigXj[j].X(), key.BigXj[j].Y(), key.PaillierPKs[j].N, key.NTildej[j], key.H1j[j], key.H2j[j])
+	}
+	return common.SHA512_256i(in...)
+}
+
+// freshSSID hashes the rid_j of every party into ssid, so that it is unique to this presignature if one party is honest
+func freshSSID(ssid *big.Int, rids []*big.Int) *big.Int {
+	return common.SHA512_256i(append([]*big.Int{ssid}, rids...)...)
+}
+
+func (temp *presignTempData) wipe() {
+	common.WipeBigInts(temp.wI, temp.k, temp.gamma, temp.rho, temp.nu, temp.delta, temp.chi)
+	common.WipeBigInts(temp.betas...)
+	common.WipeBigInts(temp.betaHats...)
+	temp.wipeAlphas()
+}
+
+// wipeAlphas overwrites the plaintexts of the MtA ciphertexts we received, once they are no longer needed for an abort
+func (temp *presignTempData) wipeAlphas() {
+	common.WipeBigInts(temp.alphas...)
+	common.WipeBigInts(temp.alphaRands...)
+	common.WipeBigInts(temp.alphaHats...)
+	common.WipeBigInts(temp.alphaHatRands...)
+}
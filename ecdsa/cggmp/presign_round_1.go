@@ -0,0 +1,104 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto/encproof"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of CGGMP21 presigning, Fig. 7
+func newPresignRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, temp *presignTempData, out chan<- tss.Message, end chan<- *PreSignatureData) tss.Round {
+	return &presignRound1{newBase(params, TaskNamePresign, 1), key, temp, out, end}
+}
+
+func (round *presignRound1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	paiPK := &round.key.PaillierSK.PublicKey
+
+	// 1. sample k_i, gamma_i and encrypt them under our Paillier key
+	q := tss.EC().Params().N
+	k, gamma := common.GetRandomPositiveInt(q), common.GetRandomPositiveInt(q)
+	K, rho, err := paiPK.EncryptAndReturnRandomness(k)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	G, nu, err := paiPK.EncryptAndReturnRandomness(gamma)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.temp.k, round.temp.gamma, round.temp.rho, round.temp.nu = k, gamma, rho, nu
+
+	// 2. sample rid_i, which is hashed with those of the other parties into the session of the later rounds
+	rid := common.MustGetRandomInt(ridBitLen)
+	round.temp.rids[i] = rid
+
+	// 3. p2p send each Pj a proof that K_i encrypts a small k_i, made for its Ring-Pedersen parameters
+	session := tss.ProofSession(round.temp.ssid, Pi, rid)
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		encProof, err := encproof.NewProof(session, tss.EC(), paiPK, K, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], k, rho)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		round.out <- NewPresignRound1Message1(Pj, Pi, encProof)
+	}
+
+	// BROADCAST K_i, G_i, rid_i
+	r1msg2 := NewPresignRound1Message2(Pi, K, G, rid)
+	round.temp.presignRound1Message2s[i] = r1msg2
+	round.out <- r1msg2
+	return nil
+}
+
+func (round *presignRound1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound1Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound1Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *presignRound1) Update() (bool, *tss.Error) {
+	i := round.PartyID().Index
+	for j, msg2 := range round.temp.presignRound1Message2s {
+		if round.ok[j] {
+			continue
+		}
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		if msg1 := round.temp.presignRound1Message1s[j]; j != i && (msg1 == nil || !round.CanAccept(msg1)) {
+			return false, nil
+		}
+		r1msg2 := msg2.Content().(*PresignRound1Message2)
+		round.temp.Ks[j], round.temp.Gs[j], round.temp.rids[j] = r1msg2.UnmarshalK(), r1msg2.UnmarshalG(), r1msg2.UnmarshalRid()
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *presignRound1) NextRound() tss.Round {
+	round.started = false
+	return &presignRound2{round}
+}
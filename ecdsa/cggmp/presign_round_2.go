@@ -0,0 +1,195 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/affgproof"
+	"github.com/zeta-chain/tss-lib/crypto/logstarproof"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *presignRound2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	Ps := round.Parties().IDs()
+	paiPK := &round.key.PaillierSK.PublicKey
+
+	// 1. verify that each K_j encrypts a small k_j
+	proofFailCulprits := make([]*tss.PartyID, len(Ps))
+	wg := new(sync.WaitGroup)
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+			r1msg1 := round.temp.presignRound1Message1s[j].Content().(*PresignRound1Message1)
+			encProof, err := r1msg1.UnmarshalEncProof()
+			if err != nil || !encProof.Verify(tss.ProofSession(round.temp.ssid, Ps[j], round.temp.rids[j]), tss.EC(), round.key.PaillierPKs[j], round.temp.Ks[j],
+				round.key.NTildei, round.key.H1i, round.key.H2i) {
+				proofFailCulprits[j] = Ps[j]
+			}
+		}(j)
+	}
+	wg.Wait()
+	if culprits := culpritsOf(proofFailCulprits); len(culprits) > 0 {
+		return round.WrapError(errors.New("enc proof verification failed"), culprits...)
+	}
+	round.temp.ssid = freshSSID(round.temp.ssid, round.temp.rids)
+
+	// 2. Gamma_i = gamma_i * G
+	g := generator()
+	bigGammaI := crypto.ScalarBaseMult(tss.EC(), round.temp.gamma)
+	round.temp.bigGammaI = bigGammaI
+
+	// 3. for each Pj compute D_ji = K_j^gamma_i * enc_j(beta_ij) and DHat_ji = K_j^w_i * enc_j(betaHat_ij), with F_ji and
+	// FHat_ji encrypting beta_ij and betaHat_ij under our key, and prove them consistent with Gamma_i and W_i
+	betaBound := new(big.Int).Lsh(big.NewInt(1), uint(affgproof.LPrimeFactor*tss.EC().Params().N.BitLen()))
+	session := tss.ProofSession(round.temp.ssid, Pi)
+	r2msg1s := make([]tss.ParsedMessage, len(Ps))
+	Ds, DHats := make([]*big.Int, len(Ps)), make([]*big.Int, len(Ps))
+	errs := make([]error, len(Ps))
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		wg.Add(1)
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			NTildej, H1j, H2j := round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j]
+			pkj, Kj := round.key.PaillierPKs[j], round.temp.Ks[j]
+
+			beta := common.GetRandomPositiveInt(betaBound)
+			D, F, affGProof, err := affineWithProof(session, paiPK, pkj, Kj, round.temp.gamma, beta, bigGammaI, g, NTildej, H1j, H2j)
+			if err != nil {
+				errs[j] = err
+				return
+			}
+			betaHat := common.GetRandomPositiveInt(betaBound)
+			DHat, FHat, affGHatProof, err := affineWithProof(session, paiPK, pkj, Kj, round.temp.wI, betaHat, round.temp.bigWs[i], g, NTildej, H1j, H2j)
+			if err != nil {
+				errs[j] = err
+				return
+			}
+			logStarProof, err := logstarproof.NewProof(session, paiPK, round.temp.Gs[i], bigGammaI, g, NTildej, H1j, H2j, round.temp.gamma, round.temp.nu)
+			if err != nil {
+				errs[j] = err
+				return
+			}
+			round.temp.betas[j], round.temp.betaHats[j] = beta, betaHat
+			Ds[j], DHats[j] = D, DHat
+			r2msg1s[j] = NewPresignRound2Message1(Pj, Pi, F, FHat, affGProof, affGHatProof, logStarProof)
+		}(j, Pj)
+	}
+	wg.Wait()
+	var multiErr error
+	for _, err := range errs {
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+	}
+	if multiErr != nil {
+		return round.WrapError(multiErr, Pi)
+	}
+	for j, r2msg1 := range r2msg1s {
+		if j == i {
+			continue
+		}
+		round.out <- r2msg1
+	}
+
+	// BROADCAST Gamma_i and the D_ji, DHat_ji of every Pj, whose decryption is checked if presigning aborts
+	r2msg2 := NewPresignRound2Message2(Pi, bigGammaI, Ds, DHats)
+	round.temp.presignRound2Message2s[i] = r2msg2
+	round.out <- r2msg2
+	return nil
+}
+
+func (round *presignRound2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *presignRound2) Update() (bool, *tss.Error) {
+	i := round.PartyID().Index
+	for j, msg2 := range round.temp.presignRound2Message2s {
+		if round.ok[j] {
+			continue
+		}
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		if msg1 := round.temp.presignRound2Message1s[j]; j != i && (msg1 == nil || !round.CanAccept(msg1)) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *presignRound2) NextRound() tss.Round {
+	round.started = false
+	return &presignRound3{round}
+}
+
+// ----- //
+
+// affineWithProof computes D = C^x * enc_j(y) under the key pkj of Pj and F = enc_i(y) under our key pki, with the proof
+// for the Ring-Pedersen parameters of Pj that D is an affine operation on C by the x of X = x * g and the y encrypted in F
+func affineWithProof(
+	session *big.Int,
+	pki, pkj *paillier.PublicKey,
+	C, x, y *big.Int,
+	X, g *crypto.ECPoint,
+	NTildej, H1j, H2j *big.Int,
+) (D, F *big.Int, proof *affgproof.ProofAffG, err error) {
+	encY, rho, err := pkj.EncryptAndReturnRandomness(y)
+	if err != nil {
+		return
+	}
+	Cx, err := pkj.HomoMult(x, C)
+	if err != nil {
+		return
+	}
+	if D, err = pkj.HomoAdd(Cx, encY); err != nil {
+		return
+	}
+	F, rhoY, err := pki.EncryptAndReturnRandomness(y)
+	if err != nil {
+		return
+	}
+	proof, err = affgproof.NewProof(session, pkj, pki, C, D, F, X, g, NTildej, H1j, H2j, x, y, rho, rhoY)
+	return
+}
+
+// generator returns the base point of the curve
+func generator() *crypto.ECPoint {
+	params := tss.EC().Params()
+	return crypto.NewECPointNoCurveCheck(tss.EC(), params.Gx, params.Gy)
+}
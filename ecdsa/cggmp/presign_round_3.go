@@ -0,0 +1,177 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto/logstarproof"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *presignRound3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	Ps := round.Parties().IDs()
+	paiSK := round.key.PaillierSK
+	paiPK := &paiSK.PublicKey
+	modQ := common.ModInt(tss.EC().Params().N)
+	g := generator()
+
+	// 1. verify the affine operations of each Pj on our K_i and its proof that G_j encrypts the gamma_j of Gamma_j;
+	// decrypt alpha_ij = gamma_j * k_i + beta_ji and alphaHat_ij = w_j * k_i + betaHat_ji
+	round.temp.bigGammaJs[i] = round.temp.bigGammaI
+	alphas, alphaHats := round.temp.alphas, round.temp.alphaHats
+	proofFailCulprits := make([]*tss.PartyID, len(Ps))
+	wg := new(sync.WaitGroup)
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		r2msg2 := round.temp.presignRound2Message2s[j].Content().(*PresignRound2Message2)
+		bigGammaJ, err := r2msg2.UnmarshalBigGamma()
+		if err != nil {
+			return round.WrapError(err, Ps[j])
+		}
+		if len(r2msg2.GetD()) != len(Ps) {
+			return round.WrapError(errors.New("the round 2 message has the wrong number of ciphertexts"), Ps[j])
+		}
+		round.temp.bigGammaJs[j] = bigGammaJ
+		D, DHat := r2msg2.UnmarshalDs()[i], r2msg2.UnmarshalDHats()[i]
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+			r2msg1 := round.temp.presignRound2Message1s[j].Content().(*PresignRound2Message1)
+			NTildei, H1i, H2i := round.key.NTildei, round.key.H1i, round.key.H2i
+			pkj, Ki := round.key.PaillierPKs[j], round.temp.Ks[i]
+			session := tss.ProofSession(round.temp.ssid, Ps[j])
+			F, FHat := r2msg1.UnmarshalF(), r2msg1.UnmarshalFHat()
+			if affGProof, err := r2msg1.UnmarshalAffGProof(); err != nil ||
+				!affGProof.Verify(session, paiPK, pkj, Ki, D, F, bigGammaJ, g, NTildei, H1i, H2i) {
+				proofFailCulprits[j] = Ps[j]
+				return
+			}
+			if affGHatProof, err := r2msg1.UnmarshalAffGHatProof(); err != nil ||
+				!affGHatProof.Verify(session, paiPK, pkj, Ki, DHat, FHat, round.temp.bigWs[j], g, NTildei, H1i, H2i) {
+				proofFailCulprits[j] = Ps[j]
+				return
+			}
+			if logStarProof, err := r2msg1.UnmarshalLogStarProof(); err != nil ||
+				!logStarProof.Verify(session, pkj, round.temp.Gs[j], bigGammaJ, g, NTildei, H1i, H2i) {
+				proofFailCulprits[j] = Ps[j]
+				return
+			}
+			alpha, alphaRand, err := paiSK.DecryptAndRecoverRandomness(D)
+			if err != nil {
+				proofFailCulprits[j] = Ps[j]
+				return
+			}
+			alphaHat, alphaHatRand, err := paiSK.DecryptAndRecoverRandomness(DHat)
+			if err != nil {
+				proofFailCulprits[j] = Ps[j]
+				return
+			}
+			alphas[j], alphaHats[j] = alpha, alphaHat
+			round.temp.alphaRands[j], round.temp.alphaHatRands[j] = alphaRand, alphaHatRand
+		}(j)
+	}
+	wg.Wait()
+	if culprits := culpritsOf(proofFailCulprits); len(culprits) > 0 {
+		return round.WrapError(errors.New("aff-g or log* proof verification failed"), culprits...)
+	}
+
+	// 2. Gamma = sum(Gamma_j), Delta_i = k_i * Gamma
+	bigGamma := round.temp.bigGammaJs[i]
+	for j, bigGammaJ := range round.temp.bigGammaJs {
+		if j == i {
+			continue
+		}
+		var err error
+		if bigGamma, err = bigGamma.Add(bigGammaJ); err != nil {
+			return round.WrapError(errors.New("adding Gamma_j to Gamma resulted in a point not on the curve"), Ps[j])
+		}
+	}
+	bigDeltaI := bigGamma.ScalarMult(round.temp.k)
+
+	// 3. delta_i = gamma_i * k_i + sum(alpha_ij - beta_ij), chi_i = w_i * k_i + sum(alphaHat_ij - betaHat_ij)
+	delta := modQ.Mul(round.temp.gamma, round.temp.k)
+	chi := modQ.Mul(round.temp.wI, round.temp.k)
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		delta = modQ.Add(delta, modQ.Sub(alphas[j], round.temp.betas[j]))
+		chi = modQ.Add(chi, modQ.Sub(alphaHats[j], round.temp.betaHats[j]))
+	}
+	bigSI := bigGamma.ScalarMult(chi)
+	if bigDeltaI == nil || bigSI == nil {
+		return round.WrapError(errors.New("Delta_i or S_i is the point at infinity"), Pi)
+	}
+	round.temp.bigGamma, round.temp.bigDeltaI, round.temp.bigSI = bigGamma, bigDeltaI, bigSI
+	round.temp.delta, round.temp.chi = delta, chi
+
+	// 4. p2p send each Pj a proof that Delta_i = k_i * Gamma for the k_i of K_i
+	session := tss.ProofSession(round.temp.ssid, Pi)
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		logStarProof, err := logstarproof.NewProof(session, paiPK, round.temp.Ks[i], bigDeltaI, bigGamma,
+			round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.temp.k, round.temp.rho)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		round.out <- NewPresignRound3Message1(Pj, Pi, logStarProof)
+	}
+
+	// BROADCAST delta_i, Delta_i, S_i
+	r3msg2 := NewPresignRound3Message2(Pi, delta, bigDeltaI, bigSI)
+	round.temp.presignRound3Message2s[i] = r3msg2
+	round.out <- r3msg2
+	return nil
+}
+
+func (round *presignRound3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound3Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound3Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *presignRound3) Update() (bool, *tss.Error) {
+	i := round.PartyID().Index
+	for j, msg2 := range round.temp.presignRound3Message2s {
+		if round.ok[j] {
+			continue
+		}
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		if msg1 := round.temp.presignRound3Message1s[j]; j != i && (msg1 == nil || !round.CanAccept(msg1)) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *presignRound3) NextRound() tss.Round {
+	round.started = false
+	return &presignOutput{presignRound3: round}
+}
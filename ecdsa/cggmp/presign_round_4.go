@@ -0,0 +1,152 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *presignOutput) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	Ps := round.Parties().IDs()
+	modQ := common.ModInt(tss.EC().Params().N)
+	bigGamma := round.temp.bigGamma
+
+	// 1. verify that each Delta_j = k_j * Gamma for the k_j of K_j
+	deltas := make([]*big.Int, len(Ps))
+	bigDeltaJs, bigSJs := make([]*crypto.ECPoint, len(Ps)), make([]*crypto.ECPoint, len(Ps))
+	deltas[i], bigDeltaJs[i], bigSJs[i] = round.temp.delta, round.temp.bigDeltaI, round.temp.bigSI
+	proofFailCulprits := make([]*tss.PartyID, len(Ps))
+	wg := new(sync.WaitGroup)
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		r3msg2 := round.temp.presignRound3Message2s[j].Content().(*PresignRound3Message2)
+		bigDeltaJ, err := r3msg2.UnmarshalBigDelta()
+		if err != nil {
+			return round.WrapError(err, Ps[j])
+		}
+		bigSJ, err := r3msg2.UnmarshalBigS()
+		if err != nil {
+			return round.WrapError(err, Ps[j])
+		}
+		deltas[j], bigDeltaJs[j], bigSJs[j] = r3msg2.UnmarshalDelta(), bigDeltaJ, bigSJ
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+			r3msg1 := round.temp.presignRound3Message1s[j].Content().(*PresignRound3Message1)
+			if logStarProof, err := r3msg1.UnmarshalLogStarProof(); err != nil ||
+				!logStarProof.Verify(tss.ProofSession(round.temp.ssid, Ps[j]), round.key.PaillierPKs[j], round.temp.Ks[j], bigDeltaJs[j], bigGamma,
+					round.key.NTildei, round.key.H1i, round.key.H2i) {
+				proofFailCulprits[j] = Ps[j]
+			}
+		}(j)
+	}
+	wg.Wait()
+	if culprits := culpritsOf(proofFailCulprits); len(culprits) > 0 {
+		return round.WrapError(errors.New("log* proof verification failed"), culprits...)
+	}
+
+	round.temp.deltas, round.temp.bigDeltaJs, round.temp.bigSJs = deltas, bigDeltaJs, bigSJs
+
+	// 2. delta = sum(delta_j) = k * gamma; check delta * G = sum(Delta_j) and sum(S_j) = delta * ECDSAPub.
+	// A failure here means that some party sent inconsistent shares; enter the identification step of Fig. 7
+	delta := big.NewInt(0)
+	for _, deltaJ := range deltas {
+		delta = modQ.Add(delta, deltaJ)
+	}
+	sumBigDelta, sumBigS := bigDeltaJs[0], bigSJs[0]
+	for j := 1; j < len(Ps); j++ {
+		var err error
+		if sumBigDelta, err = sumBigDelta.Add(bigDeltaJs[j]); err != nil {
+			return round.WrapError(errors.New("adding Delta_j resulted in a point not on the curve"), Ps[j])
+		}
+		if sumBigS, err = sumBigS.Add(bigSJs[j]); err != nil {
+			return round.WrapError(errors.New("adding S_j resulted in a point not on the curve"), Ps[j])
+		}
+	}
+	if delta.Sign() == 0 ||
+		!crypto.ScalarBaseMult(tss.EC(), delta).Equals(sumBigDelta) ||
+		!round.key.ECDSAPub.ScalarMult(delta).Equals(sumBigS) {
+		round.aborting = true
+		common.Logger.Warnf("round 4: consistency check failed: delta * G != sum(Delta_j) or sum(S_j) != delta * ECDSAPub, entering identification")
+
+		// BROADCAST k_i, gamma_i and the plaintexts of the D_ij, DHat_ij we received with the randomness of their encryption
+		r4msg := NewPresignRound4Message(Pi, round.temp.k, round.temp.gamma,
+			round.temp.alphas, round.temp.alphaRands, round.temp.alphaHats, round.temp.alphaHatRands)
+		round.temp.presignRound4Messages[i] = r4msg
+		round.out <- r4msg
+		return nil
+	}
+	round.temp.wipeAlphas()
+
+	// 3. R = delta^-1 * Gamma = k^-1 * G; R_j = delta^-1 * Delta_j = k_j * R and S_j = delta^-1 * S_j = chi_j * R
+	deltaInv := modQ.Inverse(delta)
+	bigR := bigGamma.ScalarMult(deltaInv)
+	data := &PreSignatureData{
+		BigR:  bigR.ToProtobufPoint(),
+		KI:    round.temp.k.Bytes(),
+		ChiI:  round.temp.chi.Bytes(),
+		BigRJ: make(map[string]*common.ECPoint, len(Ps)),
+		BigSJ: make(map[string]*common.ECPoint, len(Ps)),
+	}
+	for j, Pj := range Ps {
+		data.BigRJ[Pj.Id] = bigDeltaJs[j].ScalarMult(deltaInv).ToProtobufPoint()
+		data.BigSJ[Pj.Id] = bigSJs[j].ScalarMult(deltaInv).ToProtobufPoint()
+	}
+
+	round.end <- data
+	return nil
+}
+
+func (round *presignOutput) CanAccept(msg tss.ParsedMessage) bool {
+	// only expecting the messages of the identification step
+	if _, ok := msg.Content().(*PresignRound4Message); ok {
+		return round.aborting && msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *presignOutput) Update() (bool, *tss.Error) {
+	if !round.aborting {
+		// not expecting any incoming messages in this round
+		return false, nil
+	}
+	for j, msg := range round.temp.presignRound4Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *presignOutput) NextRound() tss.Round {
+	if !round.aborting {
+		return nil // finished!
+	}
+	round.started = false
+	return &presignIdentification{round}
+}
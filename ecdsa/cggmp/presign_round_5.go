@@ -0,0 +1,144 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Start runs the identification step of Fig. 7 after a failed consistency check in round 4. Every party has revealed k_j,
+// gamma_j and the plaintexts alpha_jl, alphaHat_jl of the D_jl, DHat_jl it received, with the randomness of their
+// encryption. Re-encrypting them proves their decryption, so the delta_j and S_j of every party can be recomputed.
+func (round *presignIdentification) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	q := tss.EC().Params().N
+	modQ := common.ModInt(q)
+
+	// 1. check the revealed values of each Pj against K_j, Gamma_j and the ciphertexts broadcast in round 2
+	Ds, DHats := make([][]*big.Int, len(Ps)), make([][]*big.Int, len(Ps))
+	for l := range Ps {
+		r2msg2 := round.temp.presignRound2Message2s[l].Content().(*PresignRound2Message2)
+		Ds[l], DHats[l] = r2msg2.UnmarshalDs(), r2msg2.UnmarshalDHats()
+	}
+	ks, gammas := make([]*big.Int, len(Ps)), make([]*big.Int, len(Ps))
+	alphas, alphaHats := make([][]*big.Int, len(Ps)), make([][]*big.Int, len(Ps))
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+outer:
+	for j, Pj := range Ps {
+		r4msg := round.temp.presignRound4Messages[j].Content().(*PresignRound4Message)
+
+		// content length sanity check; the other slices have the same length by ValidateBasic()
+		if len(r4msg.GetAlphaIJ()) != len(Ps) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+
+		// gamma_j * G = Gamma_j, and k_j * Gamma = Delta_j for the k_j of K_j by the log* proof of round 3
+		kJ, gammaJ := r4msg.UnmarshalKI(), r4msg.UnmarshalGammaI()
+		if kJ.Cmp(q) != -1 || gammaJ.Cmp(q) != -1 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		if bigGammaJ := crypto.ScalarBaseMult(tss.EC(), gammaJ); bigGammaJ == nil || !bigGammaJ.Equals(round.temp.bigGammaJs[j]) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		if bigDeltaJ := round.temp.bigGamma.ScalarMult(kJ); bigDeltaJ == nil || !bigDeltaJ.Equals(round.temp.bigDeltaJs[j]) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+
+		// re-encrypt alpha_jl and alphaHat_jl to make sure they are the plaintexts of the D_jl and DHat_jl "on record"
+		paiPKJ := round.key.PaillierPKs[j]
+		alphaJs, alphaRandJs := common.ByteSlicesToBigInts(r4msg.GetAlphaIJ()), common.ByteSlicesToBigInts(r4msg.GetAlphaRandIJ())
+		alphaHatJs, alphaHatRandJs := common.ByteSlicesToBigInts(r4msg.GetAlphaHatIJ()), common.ByteSlicesToBigInts(r4msg.GetAlphaHatRandIJ())
+		for l := range Ps {
+			if l == j {
+				continue
+			}
+			D, err := paiPKJ.EncryptWithChosenRandomness(alphaJs[l], alphaRandJs[l])
+			if err != nil || !bytes.Equal(D.Bytes(), Ds[l][j].Bytes()) {
+				culprits = append(culprits, Pj)
+				continue outer
+			}
+			DHat, err := paiPKJ.EncryptWithChosenRandomness(alphaHatJs[l], alphaHatRandJs[l])
+			if err != nil || !bytes.Equal(DHat.Bytes(), DHats[l][j].Bytes()) {
+				culprits = append(culprits, Pj)
+				continue outer
+			}
+		}
+		ks[j], gammas[j], alphas[j], alphaHats[j] = kJ, gammaJ, alphaJs, alphaHatJs
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(errors.New("round 4 identification failed: a party revealed values inconsistent with its messages"), culprits...)
+	}
+
+	// 2. beta_jl = alpha_lj - k_l * gamma_j and betaHat_jl = alphaHat_lj - k_l * w_j, so with k = sum(k_j), gamma = sum(gamma_j):
+	// delta_j = k_j * gamma_j + sum(alpha_jl - alpha_lj + k_l * gamma_j) and
+	// S_j = gamma * chi_j * G = gamma * k * W_j + gamma * sum(alphaHat_jl - alphaHat_lj) * G
+	k, gamma := big.NewInt(0), big.NewInt(0)
+	for j := range Ps {
+		k, gamma = modQ.Add(k, ks[j]), modQ.Add(gamma, gammas[j])
+	}
+	gammaK := modQ.Mul(gamma, k)
+	for j, Pj := range Ps {
+		delta := modQ.Mul(ks[j], gammas[j])
+		sumAlphaHat := big.NewInt(0)
+		for l := range Ps {
+			if l == j {
+				continue
+			}
+			delta = modQ.Add(delta, modQ.Add(modQ.Sub(alphas[j][l], alphas[l][j]), modQ.Mul(ks[l], gammas[j])))
+			sumAlphaHat = modQ.Add(sumAlphaHat, modQ.Sub(alphaHats[j][l], alphaHats[l][j]))
+		}
+		if delta.Cmp(new(big.Int).Mod(round.temp.deltas[j], q)) != 0 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		bigSJ := round.temp.bigWs[j].ScalarMult(gammaK)
+		if gammaSum := modQ.Mul(gamma, sumAlphaHat); bigSJ != nil && gammaSum.Sign() != 0 {
+			var err error
+			if bigSJ, err = bigSJ.Add(crypto.ScalarBaseMult(tss.EC(), gammaSum)); err != nil {
+				bigSJ = nil
+			}
+		}
+		if bigSJ == nil || !bigSJ.Equals(round.temp.bigSJs[j]) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(errors.New("round 4 consistency check failed: delta * G != sum(Delta_j) or sum(S_j) != delta * ECDSAPub, identified abort, culprits known"), culprits...)
+	}
+	return round.WrapError(errors.New("round 4 consistency check failed, but the identification step found no culprit"))
+}
+
+func (round *presignIdentification) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *presignIdentification) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *presignIdentification) NextRound() tss.Round {
+	return nil // finished!
+}
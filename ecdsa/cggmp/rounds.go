@@ -0,0 +1,157 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/ecdsa/signing"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+const (
+	TaskNameAuxInfo = "ecdsa-cggmp-aux-info"
+	TaskNamePresign = "ecdsa-cggmp-presign"
+	TaskNameSign    = "ecdsa-cggmp-sign"
+)
+
+type (
+	// base is embedded by the rounds of each of the CGGMP protocols
+	base struct {
+		*tss.Parameters
+		task    string
+		ok      []bool // `ok` tracks parties which have been verified by Update()
+		started bool
+		number  int
+	}
+
+	auxRound1 struct {
+		*base
+		input, save *keygen.LocalPartySaveData
+		temp        *auxTempData
+		out         chan<- tss.Message
+		end         chan<- keygen.LocalPartySaveData
+	}
+	auxRound2 struct {
+		*auxRound1
+	}
+	auxRound3 struct {
+		*auxRound2
+	}
+	auxRound4 struct {
+		*auxRound3
+	}
+
+	presignRound1 struct {
+		*base
+		key  *keygen.LocalPartySaveData
+		temp *presignTempData
+		out  chan<- tss.Message
+		end  chan<- *PreSignatureData
+	}
+	presignRound2 struct {
+		*presignRound1
+	}
+	presignRound3 struct {
+		*presignRound2
+	}
+	presignOutput struct {
+		*presignRound3
+
+		aborting bool // the consistency check failed and the identification step is running
+	}
+	presignIdentification struct {
+		*presignOutput
+	}
+
+	signRound1 struct {
+		*base
+		temp *signTempData
+		out  chan<- tss.Message
+		end  chan<- *signing.SignatureData
+	}
+	signFinalization struct {
+		*signRound1
+	}
+)
+
+var (
+	_ tss.Round = (*auxRound1)(nil)
+	_ tss.Round = (*auxRound2)(nil)
+	_ tss.Round = (*auxRound3)(nil)
+	_ tss.Round = (*auxRound4)(nil)
+	_ tss.Round = (*presignRound1)(nil)
+	_ tss.Round = (*presignRound2)(nil)
+	_ tss.Round = (*presignRound3)(nil)
+	_ tss.Round = (*presignOutput)(nil)
+	_ tss.Round = (*presignIdentification)(nil)
+	_ tss.Round = (*signRound1)(nil)
+	_ tss.Round = (*signFinalization)(nil)
+)
+
+func newBase(params *tss.Parameters, task string, number int) *base {
+	return &base{params, task, make([]bool, len(params.Parties().IDs())), false, number}
+}
+
+// ----- //
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, round.task, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// culpritsOf collects the non-nil entries of a slice indexed by party
+func culpritsOf(culprits []*tss.PartyID) []*tss.PartyID {
+	out := make([]*tss.PartyID, 0, len(culprits))
+	for _, culprit := range culprits {
+		if culprit != nil {
+			out = append(out, culprit)
+		}
+	}
+	return out
+}
@@ -0,0 +1,350 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/ecdsa/signing"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*SignLocalParty)(nil)
+var _ fmt.Stringer = (*SignLocalParty)(nil)
+
+type (
+	// SignLocalParty signs a message in one round with a presignature from PresignLocalParty (Fig. 8 of the paper).
+	// A party that sends a wrong share of the signature is named in the error.
+	SignLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp signTempData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *signing.SignatureData
+	}
+
+	signTempData struct {
+		signRound1Messages []tss.ParsedMessage
+
+		msg       *big.Int
+		ecdsaPub  *ecdsa.PublicKey
+		presig    *PreSignatureData
+		bigR      *crypto.ECPoint
+		sigma     *big.Int
+		presigErr error // set when the presignature does not belong to this signing
+	}
+)
+
+// NewSignLocalParty constructs the party that signs msg with `presig`, the output of NewPresignLocalParty among the parties
+// of params. The secret shares in `presig` are wiped when the party starts, or when it is closed before that: a presignature
+// must never sign two messages, as that reveals the private key.
+func NewSignLocalParty(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	presig *PreSignatureData,
+	out chan<- tss.Message,
+	end chan<- *signing.SignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &SignLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		out:       out,
+		end:       end,
+	}
+	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.msg = msg
+	p.temp.presig = presig
+	if key.ECDSAPub != nil {
+		p.temp.ecdsaPub = key.ECDSAPub.ToECDSAPubKey()
+	}
+	p.temp.presigErr = p.checkPresignature()
+	return p
+}
+
+func (p *SignLocalParty) checkPresignature() error {
+	if p.temp.msg == nil || p.temp.msg.Sign() < 0 || p.temp.msg.Cmp(tss.EC().Params().N) >= 0 {
+		return errors.New("hashed message is not valid")
+	}
+	if p.temp.ecdsaPub == nil {
+		return errors.New("the key data has no ECDSAPub")
+	}
+	presig := p.temp.presig
+	if presig == nil || !common.NonEmptyBytes(presig.GetKI()) {
+		return errors.New("the presignature has no secret shares; it may have been used already")
+	}
+	bigR, err := crypto.NewECPointFromProtobuf(presig.GetBigR())
+	if err != nil {
+		return fmt.Errorf("the presignature R is invalid: %v", err)
+	}
+	p.temp.bigR = bigR
+	ids := make([]string, 0, len(presig.GetBigRJ()))
+	for id := range presig.GetBigRJ() {
+		if _, ok := presig.GetBigSJ()[id]; !ok {
+			return fmt.Errorf("the presignature has no S_j for %s", id)
+		}
+		ids = append(ids, id)
+	}
+	want := make([]string, 0, len(p.params.Parties().IDs()))
+	for _, Pj := range p.params.Parties().IDs() {
+		want = append(want, Pj.Id)
+	}
+	sort.Strings(ids)
+	sort.Strings(want)
+	if strings.Join(ids, "\x00") != strings.Join(want, "\x00") {
+		return fmt.Errorf("the presignature was made by the signers %v, not %v", ids, want)
+	}
+	return nil
+}
+
+func (p *SignLocalParty) FirstRound() tss.Round {
+	return &signRound1{newBase(p.params, TaskNameSign, 1), &p.temp, p.out, p.end}
+}
+
+func (p *SignLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskNameSign, func(round tss.Round) *tss.Error {
+		if p.temp.presigErr != nil {
+			return round.WrapError(p.temp.presigErr)
+		}
+		return nil
+	})
+}
+
+func (p *SignLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskNameSign)
+}
+
+// Close stops the party and wipes the secret shares of the presignature if it has not started.
+func (p *SignLocalParty) Close() {
+	tss.BaseClose(p, func() {
+		wipePresignature(p.temp.presig)
+	})
+}
+
+func (p *SignLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *SignLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *SignLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	switch msg.Content().(type) {
+	case *CGGMPSignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *SignLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *SignLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// ----- //
+
+func (round *signRound1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	modQ := common.ModInt(tss.EC().Params().N)
+
+	// 1. sigma_i = k_i * m + r * chi_i
+	presig := round.temp.presig
+	r := new(big.Int).Mod(round.temp.bigR.X(), tss.EC().Params().N)
+	kI, chiI := new(big.Int).SetBytes(presig.GetKI()), new(big.Int).SetBytes(presig.GetChiI())
+	round.temp.sigma = modQ.Add(modQ.Mul(kI, round.temp.msg), modQ.Mul(r, chiI))
+
+	// SECURITY: the shares must never sign another message
+	common.WipeBigInts(kI, chiI)
+	wipePresignature(presig)
+
+	// BROADCAST sigma_i
+	r1msg := NewCGGMPSignRound1Message(Pi, round.temp.sigma)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+	return nil
+}
+
+func (round *signRound1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*CGGMPSignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *signRound1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *signRound1) NextRound() tss.Round {
+	round.started = false
+	return &signFinalization{round}
+}
+
+// ----- //
+
+func (round *signFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	Ps := round.Parties().IDs()
+	N := tss.EC().Params().N
+	modQ := common.ModInt(N)
+	bigR, m := round.temp.bigR, round.temp.msg
+	r := new(big.Int).Mod(bigR.X(), N)
+
+	// 1. check sigma_j * R = m * R_j + r * S_j for each Pj, naming the parties whose share is wrong
+	s := new(big.Int).Set(round.temp.sigma)
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		sigmaJ := round.temp.signRound1Messages[j].Content().(*CGGMPSignRound1Message).UnmarshalSigma()
+		bigRJ, err := crypto.NewECPointFromProtobuf(round.temp.presig.GetBigRJ()[Pj.Id])
+		if err != nil {
+			return round.WrapError(err)
+		}
+		bigSJ, err := crypto.NewECPointFromProtobuf(round.temp.presig.GetBigSJ()[Pj.Id])
+		if err != nil {
+			return round.WrapError(err)
+		}
+		if !scalarMultEquals(bigR, sigmaJ, bigRJ, m, bigSJ, r) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		s = modQ.Add(s, sigmaJ)
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(errors.New("sigma_j * R != m * R_j + r * S_j"), culprits...)
+	}
+
+	// 2. the recovery id and low-S normalisation, as in signing.FinalizeGetAndVerifyFinalSig
+	recId := 0
+	if bigR.X().Cmp(N) >= 0 {
+		recId = 2
+	}
+	if bigR.Y().Bit(0) != 0 {
+		recId |= 1
+	}
+	halfN := new(big.Int).Rsh(N, 1)
+	if s.Cmp(halfN) > 0 {
+		s.Sub(N, s)
+		recId ^= 1
+	}
+	if !ecdsa.Verify(round.temp.ecdsaPub, m.Bytes(), r, s) {
+		return round.WrapError(errors.New("signature verification failed"))
+	}
+
+	signature := new(common.ECSignature)
+	signature.R, signature.S = r.Bytes(), s.Bytes()
+	signature.Signature = append(r.Bytes(), s.Bytes()...)
+	signature.SignatureRecovery = []byte{byte(recId)}
+	signature.M = m.Bytes()
+	round.end <- &signing.SignatureData{Signature: signature}
+	return nil
+}
+
+func (round *signFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *signFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *signFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// scalarMultEquals reports whether a * A = b * B + c * C, where a zero scalar gives the point at infinity
+func scalarMultEquals(A *crypto.ECPoint, a *big.Int, B *crypto.ECPoint, b *big.Int, C *crypto.ECPoint, c *big.Int) bool {
+	LHS, bB, cC := A.ScalarMult(a), B.ScalarMult(b), C.ScalarMult(c)
+	switch {
+	case bB == nil:
+		return LHS == nil && cC == nil || LHS != nil && LHS.Equals(cC)
+	case cC == nil:
+		return LHS != nil && LHS.Equals(bB)
+	}
+	RHS, err := bB.Add(cC)
+	if err != nil {
+		// b * B = -c * C sums to the point at infinity
+		return LHS == nil
+	}
+	return LHS != nil && LHS.Equals(RHS)
+}
+
+// wipePresignature overwrites the k_i and chi_i of presig and removes them
+func wipePresignature(presig *PreSignatureData) {
+	if presig == nil {
+		return
+	}
+	common.WipeByteSlices(presig.KI, presig.ChiI)
+	presig.KI, presig.ChiI = nil, nil
+}
@@ -0,0 +1,43 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+)
+
+// PubKeyBytes returns the SEC1 compressed encoding of the ECDSAPub.
+func (save LocalPartySaveData) PubKeyBytes() ([]byte, error) {
+	if save.ECDSAPub == nil {
+		return nil, errors.New("save data has no ECDSAPub")
+	}
+	return save.ECDSAPub.SEC1Compressed()
+}
+
+// EthereumAddress returns the EIP-55 checksummed Ethereum address of the ECDSAPub.
+func (save LocalPartySaveData) EthereumAddress() (string, error) {
+	if save.ECDSAPub == nil {
+		return "", errors.New("save data has no ECDSAPub")
+	}
+	return save.ECDSAPub.EthereumAddress()
+}
+
+// BitcoinP2WPKHAddress returns the native segwit address of the ECDSAPub for the given hrp, e.g. "bc" or "tb".
+func (save LocalPartySaveData) BitcoinP2WPKHAddress(hrp string) (string, error) {
+	if save.ECDSAPub == nil {
+		return "", errors.New("save data has no ECDSAPub")
+	}
+	return save.ECDSAPub.BitcoinP2WPKHAddress(hrp)
+}
+
+// CosmosAddress returns the bech32 account address of the ECDSAPub for the given hrp, e.g. "cosmos" or "zeta".
+func (save LocalPartySaveData) CosmosAddress(hrp string) (string, error) {
+	if save.ECDSAPub == nil {
+		return "", errors.New("save data has no ECDSAPub")
+	}
+	return save.ECDSAPub.CosmosAddress(hrp)
+}
@@ -0,0 +1,238 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	cmts "github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/crypto/zkp"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+type (
+	batchTempData struct {
+		ui            *big.Int // used for tests
+		KGCs          []cmts.HashCommitment
+		vs            vss.Vs
+		shares        vss.Shares
+		deCommitPolyG cmts.HashDeCommitment
+	}
+)
+
+// NewBatchLocalParty returns a keygen party that produces `count` independent keys in one session.
+// The Paillier key, NTilde, h1, h2 and their proofs are exchanged and verified once and shared by all of the keys,
+// while each key gets its own VSS polynomial, commitment and shares in the same messages.
+// The save data of the keys is sent through `end` once completed, in the same order on every party.
+func NewBatchLocalParty(
+	params *tss.Parameters,
+	count int,
+	out chan<- tss.Message,
+	end chan<- []LocalPartySaveData,
+	optionalPreParams ...LocalPreParams,
+) tss.Party {
+	if count < 1 {
+		panic(fmt.Errorf("keygen.NewBatchLocalParty expected a count of at least 1, got %d", count))
+	}
+	p := NewLocalParty(params, out, nil, optionalPreParams...).(*LocalParty)
+	partyCount := params.PartyCount()
+	p.batch = make([]LocalPartySaveData, count-1)
+	p.temp.batch = make([]batchTempData, count-1)
+	for b := range p.batch {
+		p.batch[b] = NewLocalPartySaveData(partyCount)
+		p.temp.batch[b].KGCs = make([]cmts.HashCommitment, partyCount)
+	}
+	p.batchEnd = end
+	return p
+}
+
+// ----- //
+
+// startBatch creates the VSS polynomial, shares and commitment of each key after the first one in a batch keygen
+func (round *round1) startBatch() ([]cmts.HashCommitment, error) {
+	if len(round.batch) == 0 {
+		return nil, nil
+	}
+	if round.Params().IsWeighted() {
+		return nil, errors.New("a batch keygen cannot be weighted")
+	}
+	ids := round.Parties().IDs().Keys()
+	cts := make([]cmts.HashCommitment, len(round.batch))
+	for b := range round.batch {
+		ui := common.GetRandomPositiveInt(tss.EC().Params().N)
+		vs, shares, err := vss.Create(round.Threshold(), ui, ids)
+		if err != nil {
+			return nil, err
+		}
+		pGFlat, err := crypto.FlattenECPoints(vs)
+		if err != nil {
+			return nil, err
+		}
+		cmt := cmts.NewHashCommitment(pGFlat...)
+
+		round.batch[b].Ks = ids
+		round.batch[b].ShareID = ids[round.PartyID().Index]
+		round.temp.batch[b].ui = ui
+		round.temp.batch[b].vs = vs
+		round.temp.batch[b].shares = shares
+		round.temp.batch[b].deCommitPolyG = cmt.D
+		cts[b] = cmt.C
+	}
+	return cts, nil
+}
+
+// storeBatchCommitments stores the commitments of each Pj to the keys after the first one in a batch keygen
+func (round *round2) storeBatchCommitments() *tss.Error {
+	for j, msg := range round.temp.kgRound1Messages {
+		batchCts := msg.Content().(*KGRound1Message).UnmarshalBatchCommitments()
+		if len(batchCts) != len(round.batch) {
+			return round.WrapError(errors.New("got the wrong number of batch commitments"), msg.GetFrom())
+		}
+		if j == round.PartyID().Index {
+			continue
+		}
+		for b := range round.batch {
+			round.temp.batch[b].KGCs[j] = batchCts[b]
+		}
+	}
+	return nil
+}
+
+// batchShares returns the shares for Pj of the keys after the first one in a batch keygen
+func (round *round2) batchShares(j int) vss.Shares {
+	if len(round.batch) == 0 {
+		return nil
+	}
+	shares := make(vss.Shares, len(round.batch))
+	for b := range round.batch {
+		shares[b] = round.temp.batch[b].shares[j]
+	}
+	return shares
+}
+
+// batchDeCommitments returns the de-commitments of the keys after the first one in a batch keygen,
+// with a proof of knowledge of the u_i of each one
+func (round *round2) batchDeCommitments() ([]cmts.HashDeCommitment, []*zkp.DLogProof, error) {
+	deComs := make([]cmts.HashDeCommitment, len(round.batch))
+	proofs := make([]*zkp.DLogProof, len(round.batch))
+	for b := range round.batch {
+		proof, err := zkp.NewDLogProof(round.temp.batch[b].ui, round.temp.batch[b].vs[0])
+		if err != nil {
+			return nil, nil, errors2.Wrapf(err, "proving u_i of batch key %d", b+1)
+		}
+		deComs[b] = round.temp.batch[b].deCommitPolyG
+		proofs[b] = proof
+	}
+	return deComs, proofs, nil
+}
+
+// verifyBatchShares de-commits the VSS polynomials of Pj for the keys after the first one in a batch keygen and verifies our shares of them
+func (round *round3) verifyBatchShares(j int) ([]vss.Vs, error) {
+	r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+	r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
+	batchShares, batchDeCommitments := r2msg1.UnmarshalBatchShares(), r2msg2.UnmarshalBatchDeCommitments()
+	if len(batchShares) != len(round.batch) || len(batchDeCommitments) != len(round.batch) {
+		return nil, errors.New("got the wrong number of batch shares or de-commitments")
+	}
+	batchProofs, err := r2msg2.UnmarshalBatchZKProofs()
+	if err != nil {
+		return nil, errors2.Wrap(err, "unmarshalling the batch proofs")
+	}
+	batchVs := make([]vss.Vs, len(round.batch))
+	for b := range round.batch {
+		cmtDeCmt := cmts.HashCommitDecommit{C: round.temp.batch[b].KGCs[j], D: batchDeCommitments[b]}
+		ok, flatPolyGs := cmtDeCmt.DeCommit()
+		if !ok || flatPolyGs == nil {
+			return nil, fmt.Errorf("de-commitment verify failed for batch key %d", b+1)
+		}
+		PjVs, err := crypto.UnFlattenECPoints(tss.EC(), flatPolyGs)
+		if err != nil {
+			return nil, err
+		}
+		if len(PjVs) != round.Threshold()+1 {
+			return nil, fmt.Errorf("got the wrong number of vss commitments for batch key %d", b+1)
+		}
+		// Pj must prove that it knows the u_j of each key
+		if !batchProofs[b].Verify(PjVs[0]) {
+			return nil, fmt.Errorf("failed to prove u_j of batch key %d", b+1)
+		}
+		PjShare := vss.Share{
+			Threshold: round.Threshold(),
+			ID:        round.PartyID().KeyInt(),
+			Share:     batchShares[b],
+		}
+		if ok = PjShare.Verify(round.Threshold(), PjVs); !ok {
+			return nil, fmt.Errorf("vss verify failed for batch key %d", b+1)
+		}
+		batchVs[b] = PjVs
+	}
+	return batchVs, nil
+}
+
+// finishBatch computes xi, the BigXj and the ECDSA public key of each key after the first one in a batch keygen.
+// batchVs[j] holds the verified VSS commitments of Pj to those keys.
+func (round *round3) finishBatch(batchVs [][]vss.Vs) *tss.Error {
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+	modQ := common.ModInt(tss.EC().Params().N)
+	for b := range round.batch {
+		save := &round.batch[b]
+		xi := new(big.Int).Set(round.temp.batch[b].shares[PIdx].Share)
+		Vc := append(vss.Vs{}, round.temp.batch[b].vs...)
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+			xi = modQ.Add(xi, r2msg1.UnmarshalBatchShares()[b])
+			for c := range Vc {
+				var err error
+				if Vc[c], err = Vc[c].Add(batchVs[j][b][c]); err != nil {
+					return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), Pj)
+				}
+			}
+		}
+		save.Xi = xi
+		for j, Pj := range Ps {
+			BigXj, err := evaluateVs(Vc, Pj.KeyInt())
+			if err != nil {
+				return round.WrapError(errors2.Wrapf(err, "computing BigXj of batch key %d", b+1), Pj)
+			}
+			save.BigXj[j] = BigXj
+		}
+		ecdsaPubKey, err := crypto.NewECPoint(tss.EC(), Vc[0].X(), Vc[0].Y())
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "public key of batch key %d is not on the curve", b+1))
+		}
+		save.ECDSAPub = ecdsaPubKey
+	}
+	return nil
+}
+
+// batchSaveData returns the save data of every key of a batch keygen; they share the Paillier key, NTilde, h1 and h2 of the first one
+func (round *round5) batchSaveData() []LocalPartySaveData {
+	saves := make([]LocalPartySaveData, 0, len(round.batch)+1)
+	saves = append(saves, *round.save)
+	for _, save := range round.batch {
+		save.LocalPreParams = round.save.LocalPreParams
+		save.NTildej = append([]*big.Int{}, round.save.NTildej...)
+		save.H1j = append([]*big.Int{}, round.save.H1j...)
+		save.H2j = append([]*big.Int{}, round.save.H2j...)
+		save.PaillierPKs = append([]*paillier.PublicKey{}, round.save.PaillierPKs...)
+		save.Fingerprint = round.save.Fingerprint
+		saves = append(saves, save)
+	}
+	return saves
+}
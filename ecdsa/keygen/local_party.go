@@ -30,16 +30,21 @@ type (
 		temp localTempData
 		data LocalPartySaveData
 
+		// the keys after the first one in a batch keygen
+		batch []LocalPartySaveData
+
 		// outbound messaging
-		out chan<- tss.Message
-		end chan<- LocalPartySaveData
+		out      chan<- tss.Message
+		end      chan<- LocalPartySaveData
+		batchEnd chan<- []LocalPartySaveData
 	}
 
 	localMessageStore struct {
 		kgRound1Messages,
 		kgRound2Message1s,
 		kgRound2Message2s,
-		kgRound3Messages []tss.ParsedMessage
+		kgRound3Messages,
+		kgRound4Messages []tss.ParsedMessage
 	}
 
 	localTempData struct {
@@ -50,7 +55,9 @@ type (
 		KGCs          []cmt.HashCommitment
 		vs            vss.Vs
 		shares        vss.Shares
+		extraShares   []vss.Shares // shares for the ExtraKs of each party (weighted keygen)
 		deCommitPolyG cmt.HashDeCommitment
+		batch         []batchTempData // the temp data of the keys after the first one in a batch keygen
 	}
 )
 
@@ -86,13 +93,14 @@ func NewLocalParty(
 	p.temp.kgRound2Message1s = make([]tss.ParsedMessage, partyCount)
 	p.temp.kgRound2Message2s = make([]tss.ParsedMessage, partyCount)
 	p.temp.kgRound3Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.kgRound4Messages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.KGCs = make([]cmt.HashCommitment, partyCount)
 	return p
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.params, &p.data, &p.temp, p.out, p.end)
+	return newRound1(p.params, &p.data, &p.temp, p.batch, p.out, p.end, p.batchEnd)
 }
 
 func (p *LocalParty) Start() *tss.Error {
@@ -103,6 +111,11 @@ func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
 
+// Close stops the party and overwrites u_i, the shares made from it and the shares received from the other parties.
+func (p *LocalParty) Close() {
+	tss.BaseClose(p, p.temp.wipe)
+}
+
 func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
 	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
 	if err != nil {
@@ -141,6 +154,8 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		p.temp.kgRound2Message2s[fromPIdx] = msg
 	case *KGRound3Message:
 		p.temp.kgRound3Messages[fromPIdx] = msg
+	case *KGRound4Message:
+		p.temp.kgRound4Messages[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
 		common.Logger.Warnf("unrecognised message ignored: %v", msg)
 		return false, nil
@@ -172,3 +187,25 @@ func (p *LocalParty) PartyID() *tss.PartyID {
 func (p *LocalParty) String() string {
 	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
 }
+
+// wipe overwrites the secrets in the temp data; the save data is left as is, as it is shared with the data sent through `end`
+func (temp *localTempData) wipe() {
+	common.WipeBigInt(temp.ui)
+	temp.shares.Wipe()
+	for _, shares := range temp.extraShares {
+		shares.Wipe()
+	}
+	for _, batch := range temp.batch {
+		common.WipeBigInt(batch.ui)
+		batch.shares.Wipe()
+	}
+	for _, msg := range temp.kgRound2Message1s {
+		if msg == nil {
+			continue
+		}
+		r2msg1 := msg.Content().(*KGRound2Message1)
+		common.WipeBytes(r2msg1.GetShare())
+		common.WipeByteSlices(r2msg1.GetExtraShares()...)
+		common.WipeByteSlices(r2msg1.GetBatchShares()...)
+	}
+}
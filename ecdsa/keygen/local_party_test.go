@@ -14,15 +14,19 @@ import (
 	"math/big"
 	"os"
 	"runtime"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/crypto"
 	"github.com/zeta-chain/tss-lib/crypto/dlnp"
+	"github.com/zeta-chain/tss-lib/crypto/keystore"
 	"github.com/zeta-chain/tss-lib/crypto/paillier"
 	"github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/test"
@@ -166,6 +170,520 @@ func TestBadMessageCulprits(t *testing.T) {
 		err2.Error())
 }
 
+func TestGeneratePreParamsRejectsSmallModulus(t *testing.T) {
+	_, err := GeneratePreParamsWithOptions(time.Minute, PreParamsOptions{PaillierModulusLen: 1024})
+	assert.Error(t, err)
+	_, err = GeneratePreParamsWithOptions(time.Minute, PreParamsOptions{NTildeModulusLen: 1024})
+	assert.Error(t, err)
+	_, err = GeneratePreParamsWithOptions(time.Minute, PreParamsOptions{PaillierModulusLen: 3071})
+	assert.Error(t, err)
+}
+
+func TestRound2RejectsModulusBelowMinimum(t *testing.T) {
+	setUp("debug")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(2)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	// P[1] requires 3072-bit moduli but the fixtures were generated with 2048 bits
+	out := make(chan tss.Message, len(pIDs))
+	params0 := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), 1)
+	params1 := tss.NewParameters(p2pCtx, pIDs[1], len(pIDs), 1)
+	params1.SetMinModulusBitLen(3072)
+	P0 := NewLocalParty(params0, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+	P1 := NewLocalParty(params1, out, nil, fixtures[1].LocalPreParams).(*LocalParty)
+	if err := P0.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	r1msg := <-out
+	if err := P1.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	<-out
+
+	ok, err2 := P1.Update(r1msg.(tss.ParsedMessage))
+	assert.False(t, ok)
+	if !assert.Error(t, err2) {
+		return
+	}
+	assert.Equal(t, 2, err2.Round())
+	assert.Equal(t, []*tss.PartyID{pIDs[0]}, err2.Culprits())
+}
+
+func TestRound2RejectsModulusAboveMaximum(t *testing.T) {
+	setUp("debug")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(2)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	out := make(chan tss.Message, len(pIDs))
+	params0 := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), 1)
+	params1 := tss.NewParameters(p2pCtx, pIDs[1], len(pIDs), 1)
+	P0 := NewLocalParty(params0, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+	P1 := NewLocalParty(params1, out, nil, fixtures[1].LocalPreParams).(*LocalParty)
+	if err := P0.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	r1msg := <-out
+	if err := P1.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	<-out
+
+	// P[0] sends a Paillier modulus longer than twice the 2048-bit minimum
+	content := proto.Clone(r1msg.(tss.ParsedMessage).Content().(*KGRound1Message)).(*KGRound1Message)
+	N := new(big.Int).SetBytes(content.GetPaillierN())
+	content.PaillierN = new(big.Int).Mul(N, new(big.Int).Mul(N, N)).Bytes()
+	meta := tss.MessageRouting{From: pIDs[0], IsBroadcast: true}
+	ok, err2 := P1.Update(tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content)))
+	assert.False(t, ok)
+	if !assert.Error(t, err2) {
+		return
+	}
+	assert.Equal(t, 2, err2.Round())
+	assert.Contains(t, err2.Error(), "paillier modulus with a bit length out of range")
+	assert.Equal(t, []*tss.PartyID{pIDs[0]}, err2.Culprits())
+}
+
+func TestRound2RejectsProtocolVersionMismatch(t *testing.T) {
+	setUp("debug")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(2)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	// P[1] expects the v2 proofs but P[0] sends the v1 proofs
+	out := make(chan tss.Message, len(pIDs))
+	params0 := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), 1)
+	params1 := tss.NewParameters(p2pCtx, pIDs[1], len(pIDs), 1)
+	params1.SetProtocolVersion(tss.ProtocolV2)
+	P0 := NewLocalParty(params0, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+	P1 := NewLocalParty(params1, out, nil, fixtures[1].LocalPreParams).(*LocalParty)
+	if err := P0.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	r1msg := <-out
+	if err := P1.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	<-out
+
+	ok, err2 := P1.Update(r1msg.(tss.ParsedMessage))
+	assert.False(t, ok)
+	if !assert.Error(t, err2) {
+		return
+	}
+	assert.Equal(t, 2, err2.Round())
+	assert.Equal(t, []*tss.PartyID{pIDs[0]}, err2.Culprits())
+}
+
+func TestRound2RejectsProofsOfAnotherSessionNonce(t *testing.T) {
+	setUp("debug")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(2)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	// the v2 proofs of P[0] were made for another run of the keygen with the same parties
+	out := make(chan tss.Message, len(pIDs))
+	params0 := tss.NewParameters(p2pCtx, pIDs[0], len(pIDs), 1)
+	params1 := tss.NewParameters(p2pCtx, pIDs[1], len(pIDs), 1)
+	params0.SetProtocolVersion(tss.ProtocolV2)
+	params1.SetProtocolVersion(tss.ProtocolV2)
+	params0.SetSessionNonce([]byte("run 1"))
+	params1.SetSessionNonce([]byte("run 2"))
+	P0 := NewLocalParty(params0, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+	P1 := NewLocalParty(params1, out, nil, fixtures[1].LocalPreParams).(*LocalParty)
+	if err := P0.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	r1msg := <-out
+	if err := P1.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	<-out
+
+	ok, err2 := P1.Update(r1msg.(tss.ParsedMessage))
+	assert.False(t, ok)
+	if !assert.Error(t, err2) {
+		return
+	}
+	assert.Equal(t, 2, err2.Round())
+	assert.Equal(t, []*tss.PartyID{pIDs[0]}, err2.Culprits())
+}
+
+func TestFingerprintMismatchIsReported(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(2)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), 1)
+		P := NewLocalParty(params, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	for {
+		select {
+		case err := <-errCh:
+			assert.Equal(t, 5, err.Round())
+			assert.Equal(t, []*tss.PartyID{pIDs[0]}, err.Culprits())
+			return
+
+		case msg := <-outCh:
+			// P[0] claims a different fingerprint
+			if r4msg, ok := msg.(tss.ParsedMessage).Content().(*KGRound4Message); ok && msg.GetFrom().Index == 0 {
+				bad := append([]byte{}, r4msg.GetFingerprint()...)
+				bad[0] ^= 1
+				msg = NewKGRound4Message(msg.GetFrom(), bad)
+			}
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoError(t, err)
+			assert.Equal(t, 0, index, "only P[0] should finish")
+		}
+	}
+}
+
+func TestE2EConcurrentProtocolV2(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		params.SetProtocolVersion(tss.ProtocolV2)
+		P := NewLocalParty(params, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	saves := make([]LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+	for _, save := range saves {
+		assert.NoError(t, save.Validate())
+		assert.True(t, save.ECDSAPub.Equals(saves[0].ECDSAPub), "all parties should agree on the public key")
+		assert.Len(t, save.Fingerprint, FingerprintSize)
+		assert.Equal(t, saves[0].Fingerprint, save.Fingerprint, "all parties should agree on the fingerprint")
+	}
+	// the v2 messages carry the modulus and prm proofs instead of the v1 proofs
+	r1msg := parties[0].temp.kgRound1Messages[0].Content().(*KGRound1Message)
+	assert.Empty(t, r1msg.GetDlnproof_1())
+	assert.NotEmpty(t, r1msg.GetPrmProof())
+	r3msg := parties[0].temp.kgRound3Messages[0].Content().(*KGRound3Message)
+	assert.Empty(t, r3msg.GetPaillierProof())
+	assert.NotEmpty(t, r3msg.GetModProof())
+}
+
+func TestE2EConcurrentWeighted(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// P0 and P1 hold 3 and 2 shares, so together they satisfy t+1 = 4 on their own
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		params.SetPartyWeight(pIDs[0], 3)
+		params.SetPartyWeight(pIDs[1], 2)
+		P := NewLocalParty(params, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	saves := make([]LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			saves[index] = save
+			ended++
+		}
+	}
+	for i, save := range saves {
+		assert.NoError(t, save.Validate())
+		assert.True(t, save.IsWeighted())
+		assert.True(t, save.ECDSAPub.Equals(saves[0].ECDSAPub), "all parties should agree on the public key")
+		assert.Equal(t, parties[i].params.PartyWeight(i)-1, len(save.ExtraXi))
+	}
+
+	// the shares of P0 and P1 alone reconstruct the private key
+	shares := make(vss.Shares, 0, 5)
+	for j, save := range saves[:2] {
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+		for k, xik := range save.ExtraXi {
+			shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ExtraKs[j][k], Share: xik})
+		}
+	}
+	u, err := shares.ReConstruct()
+	assert.NoError(t, err, "vss.ReConstruct should not throw error")
+	assert.True(t, crypto.ScalarBaseMult(tss.EC(), u).Equals(saves[0].ECDSAPub), "the reconstructed key should match the public key")
+
+	// a tampered extra share is caught by Validate
+	saves[0].ExtraXi[0] = new(big.Int).Add(saves[0].ExtraXi[0], big.NewInt(1))
+	assert.Error(t, saves[0].Validate())
+}
+
+func TestE2EConcurrentBatch(t *testing.T) {
+	setUp("info")
+
+	const count = 3
+	threshold := testThreshold
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan []LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		P := NewBatchLocalParty(params, count, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	batches := make([][]LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case saves := <-endCh:
+			if !assert.Len(t, saves, count) {
+				return
+			}
+			index, err := saves[0].OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			batches[index] = saves
+			ended++
+		}
+	}
+
+	pubs := make(map[string]struct{}, count)
+	for b := 0; b < count; b++ {
+		// every party has a valid share of the same key, which shares the pre-params of the first one
+		shares := make(vss.Shares, 0, len(pIDs))
+		for i, saves := range batches {
+			save := saves[b]
+			assert.NoError(t, save.Validate())
+			assert.True(t, save.ECDSAPub.Equals(batches[0][b].ECDSAPub), "all parties should agree on the public key")
+			assert.Equal(t, batches[i][0].PaillierSK, save.PaillierSK)
+			shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+		}
+		u, err := shares[:threshold+1].ReConstruct()
+		assert.NoError(t, err, "vss.ReConstruct should not throw error")
+		assert.True(t, crypto.ScalarBaseMult(tss.EC(), u).Equals(batches[0][b].ECDSAPub), "the reconstructed key should match the public key")
+		pubs[fmt.Sprintf("%x", batches[0][b].ECDSAPub.Bytes())] = struct{}{}
+	}
+	assert.Len(t, pubs, count, "the keys should be independent")
+}
+
+func TestE2EConcurrentBatchBadProof(t *testing.T) {
+	setUp("info")
+
+	const count = 3
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan []LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P := NewBatchLocalParty(params, count, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	for {
+		select {
+		case err := <-errCh:
+			if assert.Len(t, err.Culprits(), 1) {
+				assert.Equal(t, pIDs[0], err.Culprits()[0], "P0 should be named as the culprit")
+			}
+			assert.Contains(t, err.Error(), "failed to prove u_j of batch key 1")
+			return
+
+		case msg := <-outCh:
+			// P0 sends a proof of u_i for the second key of the batch that does not verify
+			if r2msg2, ok := msg.(tss.ParsedMessage).Content().(*KGRound2Message2); ok && msg.GetFrom().Index == 0 {
+				proofs, err := r2msg2.UnmarshalBatchZKProofs()
+				if !assert.NoError(t, err) {
+					return
+				}
+				proofs[0].T = new(big.Int).Add(proofs[0].T, big.NewInt(1))
+				msg = NewKGRound2Message2(msg.GetFrom(), r2msg2.UnmarshalDeCommitment(), r2msg2.UnmarshalBatchDeCommitments(), proofs)
+			}
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			assert.FailNow(t, "the batch keygen should not end with a bad proof")
+		}
+	}
+}
+
 func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	setUp("info")
 
@@ -362,3 +880,101 @@ func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	}
 	//
 }
+
+func TestEncryptDecryptSaveData(t *testing.T) {
+	fixtures, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	key := fixtures[0]
+
+	blob, err := Encrypt(key, []byte("correct horse battery staple"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(blob), key.Xi.String(), "the secret share must not appear in the clear")
+
+	header, err := keystore.ReadHeader(blob)
+	assert.NoError(t, err)
+	assert.Equal(t, "ecdsa", header.Scheme)
+	assert.Equal(t, 0, header.ShareID.Cmp(key.ShareID))
+	assert.Equal(t, 0, header.PublicKeyX.Cmp(key.ECDSAPub.X()))
+
+	decrypted, err := Decrypt(blob, []byte("correct horse battery staple"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, decrypted.Xi.Cmp(key.Xi))
+	assert.Equal(t, 0, decrypted.PaillierSK.LambdaN.Cmp(key.PaillierSK.LambdaN))
+	assert.True(t, decrypted.ECDSAPub.Equals(key.ECDSAPub))
+	assert.Equal(t, len(key.BigXj), len(decrypted.BigXj))
+
+	_, err = Decrypt(blob, []byte("wrong"))
+	assert.Error(t, err)
+
+	// an authentic keystore of a corrupted share is rejected
+	key.Xi = new(big.Int).Add(key.Xi, big.NewInt(1))
+	blob, err = Encrypt(key, []byte("correct horse battery staple"))
+	assert.NoError(t, err)
+	_, err = Decrypt(blob, []byte("correct horse battery staple"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Xi*G does not match")
+	}
+}
+
+func TestValidateSaveData(t *testing.T) {
+	load := func() LocalPartySaveData {
+		fixtures, _, err := LoadKeygenTestFixtures(testParticipants)
+		if !assert.NoError(t, err, "should load keygen fixtures") {
+			t.FailNow()
+		}
+		return fixtures[1]
+	}
+	assert.NoError(t, load().Validate())
+	assert.NoError(t, load().ValidateThreshold(testThreshold))
+	assert.Error(t, load().ValidateThreshold(testThreshold-1), "the fixtures were generated with testThreshold")
+
+	cases := map[string]func(key *LocalPartySaveData){
+		"xi":           func(key *LocalPartySaveData) { key.Xi = new(big.Int).Add(key.Xi, big.NewInt(1)) },
+		"dup ks":       func(key *LocalPartySaveData) { key.Ks[2] = key.Ks[3] },
+		"share id":     func(key *LocalPartySaveData) { key.ShareID = big.NewInt(1) },
+		"big xj":       func(key *LocalPartySaveData) { key.BigXj[4] = key.BigXj[5] },
+		"ecdsa pub":    func(key *LocalPartySaveData) { key.ECDSAPub = key.BigXj[0] },
+		"paillier pk":  func(key *LocalPartySaveData) { key.PaillierPKs[1] = key.PaillierPKs[0] },
+		"h1j":          func(key *LocalPartySaveData) { key.H1j[1] = key.H1j[0] },
+		"party counts": func(key *LocalPartySaveData) { key.NTildej = key.NTildej[:3] },
+		"missing":      func(key *LocalPartySaveData) { key.PaillierSK = nil },
+	}
+	for name, corrupt := range cases {
+		key := load()
+		corrupt(&key)
+		assert.Error(t, key.Validate(), name)
+	}
+}
+
+func TestUpstreamSaveDataRoundTrip(t *testing.T) {
+	fixtures, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	key := fixtures[0]
+
+	bz, err := ExportUpstreamSaveData(key)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bz), `"Curve":"secp256k1"`)
+	assert.NotContains(t, string(bz), "PaillierModulusLen")
+
+	imported, err := ImportUpstreamSaveData(bz)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, imported.Xi.Cmp(key.Xi))
+	assert.True(t, imported.ECDSAPub.Equals(key.ECDSAPub))
+	assert.Equal(t, 0, imported.PaillierSK.N.Cmp(key.PaillierSK.N))
+
+	// the v1 layout without curve names is also accepted
+	bz, err = json.Marshal(&key)
+	assert.NoError(t, err)
+	_, err = ImportUpstreamSaveData(bz)
+	assert.NoError(t, err)
+
+	// points of another curve are rejected
+	bz, err = ExportUpstreamSaveData(key)
+	assert.NoError(t, err)
+	_, err = ImportUpstreamSaveData([]byte(strings.ReplaceAll(string(bz), `"secp256k1"`, `"nist256p1"`)))
+	assert.Error(t, err)
+}
@@ -10,11 +10,15 @@ import (
 	"math/big"
 
 	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
 	cmt "github.com/zeta-chain/tss-lib/crypto/commitments"
 	"github.com/zeta-chain/tss-lib/crypto/dlnp"
 	"github.com/zeta-chain/tss-lib/crypto/facproof"
+	"github.com/zeta-chain/tss-lib/crypto/modproof"
 	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/crypto/prmproof"
 	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/crypto/zkp"
 	"github.com/zeta-chain/tss-lib/tss"
 )
 
@@ -27,6 +31,7 @@ var (
 		(*KGRound2Message1)(nil),
 		(*KGRound2Message2)(nil),
 		(*KGRound3Message)(nil),
+		(*KGRound4Message)(nil),
 	}
 )
 
@@ -38,6 +43,7 @@ func NewKGRound1Message(
 	paillierPK *paillier.PublicKey,
 	nTildeI, h1I, h2I *big.Int,
 	dlnProof1, dlnProof2 *dlnp.Proof,
+	batchCts ...cmt.HashCommitment,
 ) (tss.ParsedMessage, error) {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -52,18 +58,46 @@ func NewKGRound1Message(
 		return nil, err
 	}
 	content := &KGRound1Message{
-		Commitment: ct.Bytes(),
-		PaillierN:  paillierPK.N.Bytes(),
-		NTilde:     nTildeI.Bytes(),
-		H1:         h1I.Bytes(),
-		H2:         h2I.Bytes(),
-		Dlnproof_1: dlnProof1Bz,
-		Dlnproof_2: dlnProof2Bz,
+		Commitment:       ct.Bytes(),
+		PaillierN:        paillierPK.N.Bytes(),
+		NTilde:           nTildeI.Bytes(),
+		H1:               h1I.Bytes(),
+		H2:               h2I.Bytes(),
+		Dlnproof_1:       dlnProof1Bz,
+		Dlnproof_2:       dlnProof2Bz,
+		BatchCommitments: common.BigIntsToBytes(batchCts),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg), nil
 }
 
+// NewKGRound1MessageV2 is used with tss.ProtocolV2 and carries a Ring-Pedersen parameter proof in place of the dln proofs.
+func NewKGRound1MessageV2(
+	from *tss.PartyID,
+	ct cmt.HashCommitment,
+	paillierPK *paillier.PublicKey,
+	nTildeI, h1I, h2I *big.Int,
+	prmProof *prmproof.ProofPrm,
+	batchCts ...cmt.HashCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	prmProofBzs := prmProof.Bytes()
+	content := &KGRound1Message{
+		Commitment:       ct.Bytes(),
+		PaillierN:        paillierPK.N.Bytes(),
+		NTilde:           nTildeI.Bytes(),
+		H1:               h1I.Bytes(),
+		H2:               h2I.Bytes(),
+		PrmProof:         prmProofBzs[:],
+		BatchCommitments: common.BigIntsToBytes(batchCts),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
 func (m *KGRound1Message) ValidateBasic() bool {
 	return m != nil &&
 		common.NonEmptyBytes(m.GetCommitment()) &&
@@ -71,15 +105,27 @@ func (m *KGRound1Message) ValidateBasic() bool {
 		common.NonEmptyBytes(m.GetNTilde()) &&
 		common.NonEmptyBytes(m.GetH1()) &&
 		common.NonEmptyBytes(m.GetH2()) &&
-		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
-		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnp.Iterations*2)) &&
+		(m.hasDLNProofs() || m.hasPrmProof())
+}
+
+func (m *KGRound1Message) hasDLNProofs() bool {
+	// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+	return common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnp.Iterations*2)) &&
 		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnp.Iterations*2))
 }
 
+func (m *KGRound1Message) hasPrmProof() bool {
+	return common.NonEmptyMultiBytes(m.GetPrmProof(), prmproof.ProofPrmBytesParts)
+}
+
 func (m *KGRound1Message) UnmarshalCommitment() *big.Int {
 	return new(big.Int).SetBytes(m.GetCommitment())
 }
 
+func (m *KGRound1Message) UnmarshalBatchCommitments() []*big.Int {
+	return common.ByteSlicesToBigInts(m.GetBatchCommitments())
+}
+
 func (m *KGRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
 	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
 }
@@ -104,12 +150,28 @@ func (m *KGRound1Message) UnmarshalDLNProof2() (*dlnp.Proof, error) {
 	return dlnp.UnmarshalProof(m.GetDlnproof_2())
 }
 
+func (m *KGRound1Message) UnmarshalPrmProof() (*prmproof.ProofPrm, error) {
+	return prmproof.NewProofFromBytes(m.GetPrmProof())
+}
+
 // ----- //
 
+// extraShares are the shares for the extra share ids of a party with a weight above one (weighted keygen)
 func NewKGRound2Message1(
 	to, from *tss.PartyID,
 	share *vss.Share,
 	proof *facproof.ProofFac,
+	extraShares ...*vss.Share,
+) tss.ParsedMessage {
+	return newKGRound2Message1(to, from, share, proof, extraShares, nil)
+}
+
+// newKGRound2Message1 also carries the shares of the keys after the first one in a batch keygen
+func newKGRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+	proof *facproof.ProofFac,
+	extraShares, batchShares vss.Shares,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -117,9 +179,19 @@ func NewKGRound2Message1(
 		IsBroadcast: false,
 	}
 	proofBzs := proof.Bytes()
+	extraShareBzs := make([][]byte, len(extraShares))
+	for k, extraShare := range extraShares {
+		extraShareBzs[k] = extraShare.Share.Bytes()
+	}
+	batchShareBzs := make([][]byte, len(batchShares))
+	for b, batchShare := range batchShares {
+		batchShareBzs[b] = batchShare.Share.Bytes()
+	}
 	content := &KGRound2Message1{
-		Share:    share.Share.Bytes(),
-		FacProof: proofBzs[:],
+		Share:       share.Share.Bytes(),
+		FacProof:    proofBzs[:],
+		ExtraShares: extraShareBzs,
+		BatchShares: batchShareBzs,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -134,27 +206,55 @@ func (m *KGRound2Message1) UnmarshalShare() *big.Int {
 	return new(big.Int).SetBytes(m.Share)
 }
 
+func (m *KGRound2Message1) UnmarshalExtraShares() []*big.Int {
+	return common.ByteSlicesToBigInts(m.GetExtraShares())
+}
+
+func (m *KGRound2Message1) UnmarshalBatchShares() []*big.Int {
+	return common.ByteSlicesToBigInts(m.GetBatchShares())
+}
+
 // ----- //
 
 func NewKGRound2Message2(
 	from *tss.PartyID,
 	deCommitment cmt.HashDeCommitment,
+	batchDeCommitments []cmt.HashDeCommitment,
+	batchProofs []*zkp.DLogProof,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
 	dcBzs := common.BigIntsToBytes(deCommitment)
+	batchDcs := make([]*KGRound2Message2_BatchDeCommitment, len(batchDeCommitments))
+	for b, batchDeCommitment := range batchDeCommitments {
+		batchDcs[b] = &KGRound2Message2_BatchDeCommitment{
+			DeCommitment: common.BigIntsToBytes(batchDeCommitment),
+			ProofAlpha:   batchProofs[b].Alpha.ToProtobufPoint(),
+			ProofT:       batchProofs[b].T.Bytes(),
+		}
+	}
 	content := &KGRound2Message2{
-		DeCommitment: dcBzs,
+		DeCommitment:       dcBzs,
+		BatchDeCommitments: batchDcs,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *KGRound2Message2) ValidateBasic() bool {
-	return m != nil &&
-		common.NonEmptyMultiBytes(m.GetDeCommitment())
+	if m == nil || !common.NonEmptyMultiBytes(m.GetDeCommitment()) {
+		return false
+	}
+	for _, batchDc := range m.GetBatchDeCommitments() {
+		if !common.NonEmptyMultiBytes(batchDc.GetDeCommitment()) ||
+			batchDc.GetProofAlpha() == nil ||
+			!common.NonEmptyBytes(batchDc.GetProofT()) {
+			return false
+		}
+	}
+	return true
 }
 
 func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
@@ -162,6 +262,31 @@ func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
+func (m *KGRound2Message2) UnmarshalBatchDeCommitments() []cmt.HashDeCommitment {
+	batchDcs := m.GetBatchDeCommitments()
+	deComs := make([]cmt.HashDeCommitment, len(batchDcs))
+	for b, batchDc := range batchDcs {
+		deComs[b] = cmt.NewHashDeCommitmentFromBytes(batchDc.GetDeCommitment())
+	}
+	return deComs
+}
+
+func (m *KGRound2Message2) UnmarshalBatchZKProofs() ([]*zkp.DLogProof, error) {
+	batchDcs := m.GetBatchDeCommitments()
+	proofs := make([]*zkp.DLogProof, len(batchDcs))
+	for b, batchDc := range batchDcs {
+		point, err := crypto.NewECPointFromProtobuf(batchDc.GetProofAlpha())
+		if err != nil {
+			return nil, err
+		}
+		proofs[b] = &zkp.DLogProof{
+			Alpha: point,
+			T:     new(big.Int).SetBytes(batchDc.GetProofT()),
+		}
+	}
+	return proofs, nil
+}
+
 func (m *KGRound2Message1) UnmarshalFacProof() (*facproof.ProofFac, error) {
 	return facproof.NewProofFromBytes(m.GetFacProof())
 }
@@ -190,9 +315,34 @@ func NewKGRound3Message(
 	return tss.NewMessage(meta, content, msg)
 }
 
+// NewKGRound3MessageV2 is used with tss.ProtocolV2 and carries a Paillier-Blum modulus proof in place of the Paillier proof.
+func NewKGRound3MessageV2(
+	from *tss.PartyID,
+	proof *modproof.ProofMod,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	proofBzs := proof.Bytes()
+	content := &KGRound3Message{
+		ModProof: proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
 func (m *KGRound3Message) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyMultiBytes(m.GetPaillierProof(), paillier.ProofIters)
+		(m.hasPaillierProof() || m.hasModProof())
+}
+
+func (m *KGRound3Message) hasPaillierProof() bool {
+	return common.NonEmptyMultiBytes(m.GetPaillierProof(), paillier.ProofIters)
+}
+
+func (m *KGRound3Message) hasModProof() bool {
+	return common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts)
 }
 
 func (m *KGRound3Message) UnmarshalProofInts() paillier.Proof {
@@ -203,3 +353,29 @@ func (m *KGRound3Message) UnmarshalProofInts() paillier.Proof {
 	}
 	return pf
 }
+
+func (m *KGRound3Message) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
+
+// ----- //
+
+func NewKGRound4Message(
+	from *tss.PartyID,
+	fingerprint []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &KGRound4Message{
+		Fingerprint: fingerprint,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KGRound4Message) ValidateBasic() bool {
+	return m != nil &&
+		len(m.GetFingerprint()) == FingerprintSize
+}
@@ -8,6 +8,7 @@ package keygen
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 	"runtime"
 	"time"
@@ -18,13 +19,21 @@ import (
 
 const (
 	// Using a modulus length of 2048 is recommended in the GG18 spec
-	paillierModulusLen = 2048
+	DefaultPaillierModulusLen = 2048
 	// Two 1024-bit safe primes to produce NTilde
-	safePrimeBitLen = 1024
+	DefaultNTildeModulusLen = 2048
 	// Ticker for printing log statements while generating primes/modulus
 	logProgressTickInterval = 8 * time.Second
 )
 
+// PreParamsOptions sets the modulus sizes used by GeneratePreParamsWithOptions.
+// Zero values fall back to the defaults; lengths below the defaults are rejected.
+type PreParamsOptions struct {
+	PaillierModulusLen int // bit length of the Paillier modulus N
+	NTildeModulusLen   int // bit length of NTilde, the product of two safe primes
+	Concurrency        int // defaults to the number of available CPU cores
+}
+
 // GeneratePreParams finds two safe primes and computes the Paillier secret required for the protocol.
 // This can be a time consuming process so it is recommended to do it out-of-band.
 // If not specified, a concurrency value equal to the number of available CPU cores will be used.
@@ -35,7 +44,28 @@ func GeneratePreParams(timeout time.Duration, optionalConcurrency ...int) (*Loca
 			panic(errors.New("GeneratePreParams: expected 0 or 1 item in `optionalConcurrency`"))
 		}
 		concurrency = optionalConcurrency[0]
-	} else {
+	}
+	return GeneratePreParamsWithOptions(timeout, PreParamsOptions{Concurrency: concurrency})
+}
+
+// GeneratePreParamsWithOptions works like GeneratePreParams but allows larger Paillier and NTilde moduli.
+// The chosen lengths are recorded in the returned LocalPreParams.
+func GeneratePreParamsWithOptions(timeout time.Duration, opts PreParamsOptions) (*LocalPreParams, error) {
+	paillierModulusLen, nTildeModulusLen := opts.PaillierModulusLen, opts.NTildeModulusLen
+	if paillierModulusLen == 0 {
+		paillierModulusLen = DefaultPaillierModulusLen
+	}
+	if nTildeModulusLen == 0 {
+		nTildeModulusLen = DefaultNTildeModulusLen
+	}
+	if paillierModulusLen < DefaultPaillierModulusLen || paillierModulusLen%2 != 0 {
+		return nil, fmt.Errorf("invalid Paillier modulus length %d", paillierModulusLen)
+	}
+	if nTildeModulusLen < DefaultNTildeModulusLen || nTildeModulusLen%2 != 0 {
+		return nil, fmt.Errorf("invalid NTilde modulus length %d", nTildeModulusLen)
+	}
+	concurrency := opts.Concurrency
+	if concurrency == 0 {
 		concurrency = runtime.NumCPU()
 	}
 	if concurrency /= 3; concurrency < 1 {
@@ -65,7 +95,7 @@ func GeneratePreParams(timeout time.Duration, optionalConcurrency ...int) (*Loca
 		var err error
 		common.Logger.Info("generating the safe primes for the signing proofs, please wait...")
 		start := time.Now()
-		sgps, err := common.GetRandomSafePrimesConcurrent(safePrimeBitLen, 2, timeout, concurrency)
+		sgps, err := common.GetRandomSafePrimesConcurrent(nTildeModulusLen/2, 2, timeout, concurrency)
 		if err != nil {
 			ch <- nil
 			return
@@ -127,6 +157,9 @@ consumer:
 		Beta:       beta,
 		P:          p,
 		Q:          q,
+
+		PaillierModulusLen: paillierModulusLen,
+		NTildeModulusLen:   nTildeModulusLen,
 	}
 	return preParams, nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/zeta-chain/tss-lib/crypto"
 	cmts "github.com/zeta-chain/tss-lib/crypto/commitments"
 	"github.com/zeta-chain/tss-lib/crypto/dlnp"
+	"github.com/zeta-chain/tss-lib/crypto/prmproof"
 	"github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/tss"
 )
@@ -23,9 +24,9 @@ var (
 )
 
 // round 1 represents round 1 of the keygen part of the GG18 ECDSA TSS spec (Gennaro, Goldfeder; 2018)
-func newRound1(params *tss.Parameters, save *LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- LocalPartySaveData) tss.Round {
+func newRound1(params *tss.Parameters, save *LocalPartySaveData, temp *localTempData, batch []LocalPartySaveData, out chan<- tss.Message, end chan<- LocalPartySaveData, batchEnd chan<- []LocalPartySaveData) tss.Round {
 	return &round1{
-		&base{params, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+		&base{params, save, temp, batch, out, end, batchEnd, make([]bool, len(params.Parties().IDs())), false, 1}}
 }
 
 func (round *round1) Start() *tss.Error {
@@ -44,9 +45,17 @@ func (round *round1) Start() *tss.Error {
 
 	round.temp.ui = ui
 
-	// 2. compute the vss shares
+	// 2. compute the vss shares; a party with a weight above one also gets a share for each of its extra ids
 	ids := round.Parties().IDs().Keys()
-	vs, shares, err := vss.Create(round.Threshold(), ui, ids)
+	allIDs := append([]*big.Int{}, ids...)
+	if round.Params().IsWeighted() {
+		round.save.ExtraKs = make([][]*big.Int, len(ids))
+		for j, kj := range ids {
+			round.save.ExtraKs[j] = vss.ExtraIndexes(tss.EC(), kj, round.PartyWeight(j)-1)
+			allIDs = append(allIDs, round.save.ExtraKs[j]...)
+		}
+	}
+	vs, shares, err := vss.Create(round.Threshold(), ui, allIDs)
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
@@ -74,7 +83,11 @@ func (round *round1) Start() *tss.Error {
 	} else if round.save.LocalPreParams.ValidateWithProof() {
 		preParams = &round.save.LocalPreParams
 	} else {
-		preParams, err = GeneratePreParams(round.SafePrimeGenTimeout(), 3)
+		preParams, err = GeneratePreParamsWithOptions(round.SafePrimeGenTimeout(), PreParamsOptions{
+			PaillierModulusLen: round.MinModulusBitLen(),
+			NTildeModulusLen:   round.MinModulusBitLen(),
+			Concurrency:        3,
+		})
 		if err != nil {
 			return round.WrapError(errors.New("pre-params generation failed"), Pi)
 		}
@@ -83,18 +96,6 @@ func (round *round1) Start() *tss.Error {
 	round.save.NTildej[i] = preParams.NTildei
 	round.save.H1j[i], round.save.H2j[i] = preParams.H1i, preParams.H2i
 
-	// generate the dlnproofs for keygen
-	h1i, h2i, alpha, beta, p, q, NTildei :=
-		preParams.H1i,
-		preParams.H2i,
-		preParams.Alpha,
-		preParams.Beta,
-		preParams.P,
-		preParams.Q,
-		preParams.NTildei
-	dlnProof1 := dlnp.NewProof(h1i, h2i, alpha, p, q, NTildei)
-	dlnProof2 := dlnp.NewProof(h2i, h1i, beta, p, q, NTildei)
-
 	// for this P: SAVE
 	// - shareID
 	// and keep in temporary storage:
@@ -102,17 +103,30 @@ func (round *round1) Start() *tss.Error {
 	// - our set of Shamir shares
 	round.save.ShareID = ids[i]
 	round.temp.vs = vs
-	round.temp.shares = shares
+	round.temp.shares = shares[:len(ids)]
+	if round.save.IsWeighted() {
+		round.temp.extraShares = make([]vss.Shares, len(ids))
+		offset := len(ids)
+		for j := range ids {
+			round.temp.extraShares[j] = shares[offset : offset+len(round.save.ExtraKs[j])]
+			offset += len(round.save.ExtraKs[j])
+		}
+	}
 
 	// for this P: SAVE de-commitments, paillier keys for round 2
 	round.save.PaillierSK = preParams.PaillierSK
 	round.save.PaillierPKs[i] = &preParams.PaillierSK.PublicKey
 	round.temp.deCommitPolyG = cmt.D
 
+	// batch keygen: the same for each key after the first one
+	batchCts, err := round.startBatch()
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
 	// BROADCAST commitments, paillier pk + proof; round 1 message
 	{
-		msg, err := NewKGRound1Message(
-			round.PartyID(), cmt.C, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+		msg, err := newRound1Message(round.Params(), cmt.C, preParams, batchCts)
 		if err != nil {
 			return round.WrapError(err, Pi)
 		}
@@ -122,6 +136,33 @@ func (round *round1) Start() *tss.Error {
 	return nil
 }
 
+// newRound1Message proves that h1 and h2 generate the same group mod NTilde with the proofs of the selected protocol version
+func newRound1Message(params *tss.Parameters, ct cmts.HashCommitment, preParams *LocalPreParams, batchCts []cmts.HashCommitment) (tss.ParsedMessage, error) {
+	h1i, h2i, alpha, beta, p, q, NTildei :=
+		preParams.H1i,
+		preParams.H2i,
+		preParams.Alpha,
+		preParams.Beta,
+		preParams.P,
+		preParams.Q,
+		preParams.NTildei
+	if params.ProtocolVersion() == tss.ProtocolV2 {
+		// h1 = h2^beta, where the order of h2 divides pq
+		session := tss.ProofSession(params.SessionID(), params.PartyID())
+		prmProof, err := prmproof.NewProof(session, NTildei, h1i, h2i, new(big.Int).Mul(p, q), beta)
+		if err != nil {
+			return nil, err
+		}
+		return NewKGRound1MessageV2(
+			params.PartyID(), ct, &preParams.PaillierSK.PublicKey, NTildei, h1i, h2i, prmProof, batchCts...), nil
+	}
+	// generate the dlnproofs for keygen
+	dlnProof1 := dlnp.NewProof(h1i, h2i, alpha, p, q, NTildei)
+	dlnProof2 := dlnp.NewProof(h2i, h1i, beta, p, q, NTildei)
+	return NewKGRound1Message(
+		params.PartyID(), ct, &preParams.PaillierSK.PublicKey, NTildei, h1i, h2i, dlnProof1, dlnProof2, batchCts...)
+}
+
 func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
 	if _, ok := msg.Content().(*KGRound1Message); ok {
 		return msg.IsBroadcast()
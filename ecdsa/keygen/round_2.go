@@ -13,13 +13,10 @@ import (
 	"sync"
 
 	"github.com/zeta-chain/tss-lib/crypto/facproof"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/tss"
 )
 
-const (
-	paillierBitsLen = 2048
-)
-
 func (round *round2) Start() *tss.Error {
 	if round.started {
 		return round.WrapError(errors.New("round already started"))
@@ -30,7 +27,7 @@ func (round *round2) Start() *tss.Error {
 
 	i := round.PartyID().Index
 
-	// 6. verify dln proofs, store r1 message pieces, ensure uniqueness of h1j, h2j
+	// 6. verify dln proofs (or the Ring-Pedersen parameter proof in v2), store r1 message pieces, ensure uniqueness of h1j, h2j
 	h1H2Map := make(map[string]struct{}, len(round.temp.kgRound1Messages)*2)
 	dlnProof1FailCulprits := make([]*tss.PartyID, len(round.temp.kgRound1Messages))
 	dlnProof2FailCulprits := make([]*tss.PartyID, len(round.temp.kgRound1Messages))
@@ -43,12 +40,12 @@ func (round *round2) Start() *tss.Error {
 			r1msg.UnmarshalNTilde(),
 			r1msg.UnmarshalPaillierPK()
 
-		if paillierPubKeyj.N.BitLen() != paillierBitsLen {
-			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+		if bitLen := paillierPubKeyj.N.BitLen(); bitLen < round.MinModulusBitLen() || bitLen > round.MaxModulusBitLen() {
+			return round.WrapError(errors.New("got paillier modulus with a bit length out of range for this party"), msg.GetFrom())
 		}
 
-		if NTildej.BitLen() != paillierBitsLen {
-			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+		if bitLen := NTildej.BitLen(); bitLen < round.MinModulusBitLen() || bitLen > round.MaxModulusBitLen() {
+			return round.WrapError(errors.New("got NTildej with a bit length out of range for this party"), msg.GetFrom())
 		}
 
 		if H1j.Cmp(H2j) == 0 {
@@ -65,6 +62,17 @@ func (round *round2) Start() *tss.Error {
 			}
 			h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
 		}
+		if round.ProtocolVersion() == tss.ProtocolV2 {
+			wg.Add(1)
+			go func(j int, msg tss.ParsedMessage, r1msg *KGRound1Message, H1j, H2j, NTildej *big.Int) {
+				session := tss.ProofSession(round.SessionID(), msg.GetFrom())
+				if prmProof, err := r1msg.UnmarshalPrmProof(); err != nil || !prmProof.Verify(session, NTildej, H1j, H2j) {
+					dlnProof1FailCulprits[j] = msg.GetFrom()
+				}
+				wg.Done()
+			}(j, msg, r1msg, H1j, H2j, NTildej)
+			continue
+		}
 		wg.Add(2)
 		go func(j int, msg tss.ParsedMessage, r1msg *KGRound1Message, H1j, H2j, NTildej *big.Int) {
 			if dlnProof1, err := r1msg.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(H1j, H2j, NTildej) {
@@ -82,6 +90,9 @@ func (round *round2) Start() *tss.Error {
 	wg.Wait()
 	for _, culprit := range append(dlnProof1FailCulprits, dlnProof2FailCulprits...) {
 		if culprit != nil {
+			if round.ProtocolVersion() == tss.ProtocolV2 {
+				return round.WrapError(errors.New("prm proof verification failed"), culprit)
+			}
 			return round.WrapError(errors.New("dln proof verification failed"), culprit)
 		}
 	}
@@ -102,6 +113,9 @@ func (round *round2) Start() *tss.Error {
 		round.save.H1j[j], round.save.H2j[j] = H1j, H2j
 		round.temp.KGCs[j] = KGC
 	}
+	if err := round.storeBatchCommitments(); err != nil {
+		return err
+	}
 
 	// 5. p2p send share ij to Pj
 	shares := round.temp.shares
@@ -111,7 +125,11 @@ func (round *round2) Start() *tss.Error {
 		if err != nil {
 			return round.WrapError(err, round.PartyID())
 		}
-		r2msg1 := NewKGRound2Message1(Pj, round.PartyID(), shares[j], facProof)
+		var extraShares vss.Shares
+		if round.save.IsWeighted() {
+			extraShares = round.temp.extraShares[j]
+		}
+		r2msg1 := newKGRound2Message1(Pj, round.PartyID(), shares[j], facProof, extraShares, round.batchShares(j))
 		// do not send to this Pj, but store for round 3
 		if j == i {
 			round.temp.kgRound2Message1s[j] = r2msg1
@@ -122,7 +140,11 @@ func (round *round2) Start() *tss.Error {
 	}
 
 	// 7. BROADCAST de-commitments of Shamir poly*G
-	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG)
+	batchDeComs, batchProofs, err := round.batchDeCommitments()
+	if err != nil {
+		return round.WrapError(err, round.PartyID())
+	}
+	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, batchDeComs, batchProofs)
 	round.temp.kgRound2Message2s[i] = r2msg2
 	round.out <- r2msg2
 
@@ -16,6 +16,7 @@ import (
 	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/crypto"
 	"github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/modproof"
 	"github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/tss"
 )
@@ -37,10 +38,16 @@ func (round *round3) Start() *tss.Error {
 		Vc[c] = round.temp.vs[c] // ours
 	}
 
+	var ourExtraKs []*big.Int
+	if round.save.IsWeighted() {
+		ourExtraKs = round.save.ExtraKs[PIdx]
+	}
+
 	// 4-11.
 	type vssOut struct {
 		unWrappedErr error
 		pjVs         vss.Vs
+		batchVs      []vss.Vs
 	}
 	chs := make([]chan vssOut, len(Ps))
 	for i := range chs {
@@ -62,12 +69,12 @@ func (round *round3) Start() *tss.Error {
 			cmtDeCmt := commitments.HashCommitDecommit{C: KGCj, D: KGDj}
 			ok, flatPolyGs := cmtDeCmt.DeCommit()
 			if !ok || flatPolyGs == nil {
-				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
+				ch <- vssOut{unWrappedErr: errors.New("de-commitment verify failed")}
 				return
 			}
 			PjVs, err := crypto.UnFlattenECPoints(tss.EC(), flatPolyGs)
 			if err != nil {
-				ch <- vssOut{err, nil}
+				ch <- vssOut{unWrappedErr: err}
 				return
 			}
 			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
@@ -77,7 +84,26 @@ func (round *round3) Start() *tss.Error {
 				Share:     r2msg1.UnmarshalShare(),
 			}
 			if ok = PjShare.Verify(round.Threshold(), PjVs); !ok {
-				ch <- vssOut{errors.New("vss verify failed"), nil}
+				ch <- vssOut{unWrappedErr: errors.New("vss verify failed")}
+				return
+			}
+			// weighted keygen: verify the shares for our extra ids
+			extraShares := r2msg1.UnmarshalExtraShares()
+			if len(extraShares) != len(ourExtraKs) {
+				ch <- vssOut{unWrappedErr: errors.New("got the wrong number of extra shares")}
+				return
+			}
+			for k, extraShare := range extraShares {
+				PjExtraShare := vss.Share{Threshold: round.Threshold(), ID: ourExtraKs[k], Share: extraShare}
+				if ok = PjExtraShare.Verify(round.Threshold(), PjVs); !ok {
+					ch <- vssOut{unWrappedErr: errors.New("vss verify of an extra share failed")}
+					return
+				}
+			}
+			// batch keygen: verify our shares of the keys after the first one
+			batchVs, err := round.verifyBatchShares(j)
+			if err != nil {
+				ch <- vssOut{unWrappedErr: err}
 				return
 			}
 			facProof, err := r2msg1.UnmarshalFacProof()
@@ -88,12 +114,12 @@ func (round *round3) Start() *tss.Error {
 			} else {
 				if ok = facProof.Verify(tss.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
 					round.save.H1i, round.save.H2i); !ok {
-					ch <- vssOut{errors.New("facProof verify failed"), nil}
+					ch <- vssOut{unWrappedErr: errors.New("facProof verify failed")}
 					return
 				}
 			}
 			// (9) handled above
-			ch <- vssOut{nil, PjVs}
+			ch <- vssOut{pjVs: PjVs, batchVs: batchVs}
 		}(j, chs[j])
 	}
 
@@ -107,8 +133,10 @@ func (round *round3) Start() *tss.Error {
 		r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
 		share := r2msg1.UnmarshalShare()
 		xi = xi.Add(xi, share)
+		common.WipeBigInt(share)
 	}
 	round.save.Xi = modQ.Add(xi, zero)
+	common.WipeBigInt(xi)
 
 	// consume unbuffered channels (end the goroutines)
 	vssResults := make([]vssOut, len(Ps))
@@ -135,6 +163,21 @@ func (round *round3) Start() *tss.Error {
 			return round.WrapError(multiErr, culprits...)
 		}
 	}
+	// calculate the shares for our extra ids (weighted keygen)
+	if round.save.IsWeighted() {
+		round.save.ExtraXi = make([]*big.Int, len(ourExtraKs))
+		for k := range ourExtraKs {
+			xik := new(big.Int).Set(round.temp.extraShares[PIdx][k].Share)
+			for j := range Ps {
+				if j == PIdx {
+					continue
+				}
+				r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+				xik = xik.Add(xik, r2msg1.UnmarshalExtraShares()[k])
+			}
+			round.save.ExtraXi[k] = modQ.Add(xik, zero)
+		}
+	}
 	{
 		var err error
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
@@ -180,6 +223,20 @@ func (round *round3) Start() *tss.Error {
 		}
 		round.save.BigXj = bigXj
 	}
+	// compute the public shares for the extra ids of each Pj (weighted keygen)
+	if round.save.IsWeighted() {
+		round.save.ExtraBigXj = make([][]*crypto.ECPoint, len(Ps))
+		for j, extraKs := range round.save.ExtraKs {
+			round.save.ExtraBigXj[j] = make([]*crypto.ECPoint, len(extraKs))
+			for k, kjk := range extraKs {
+				BigXjk, err := evaluateVs(Vc, kjk)
+				if err != nil {
+					return round.WrapError(errors2.Wrapf(err, "computing an extra BigXj"), Ps[j])
+				}
+				round.save.ExtraBigXj[j][k] = BigXjk
+			}
+		}
+	}
 
 	// 17. compute and SAVE the ECDSA public key `y`
 	ecdsaPubKey, err := crypto.NewECPoint(tss.EC(), Vc[0].X(), Vc[0].Y())
@@ -188,13 +245,33 @@ func (round *round3) Start() *tss.Error {
 	}
 	round.save.ECDSAPub = ecdsaPubKey
 
+	// batch keygen: the same for each key after the first one
+	batchVs := make([][]vss.Vs, len(Ps))
+	for j := range Ps {
+		batchVs[j] = vssResults[j].batchVs
+	}
+	if err := round.finishBatch(batchVs); err != nil {
+		return err
+	}
+
 	// PRINT public key & private share
 	common.Logger.Debugf("%s public key: %x", round.PartyID(), ecdsaPubKey)
 
 	// BROADCAST paillier proof for Pi
-	ki := round.PartyID().KeyInt()
-	proof := round.save.PaillierSK.Proof(ki, ecdsaPubKey)
-	r3msg := NewKGRound3Message(round.PartyID(), proof)
+	var r3msg tss.ParsedMessage
+	if round.ProtocolVersion() == tss.ProtocolV2 {
+		paiSK := round.save.PaillierSK
+		session := tss.ProofSession(round.SessionID(), round.PartyID(), ecdsaPubKey.X(), ecdsaPubKey.Y())
+		proof, err := modproof.NewProof(session, paiSK.N, paiSK.P, paiSK.Q)
+		if err != nil {
+			return round.WrapError(err, Ps[PIdx])
+		}
+		r3msg = NewKGRound3MessageV2(round.PartyID(), proof)
+	} else {
+		ki := round.PartyID().KeyInt()
+		proof := round.save.PaillierSK.Proof(ki, ecdsaPubKey)
+		r3msg = NewKGRound3Message(round.PartyID(), proof)
+	}
 	round.temp.kgRound3Messages[PIdx] = r3msg
 	round.out <- r3msg
 	return nil
@@ -225,3 +302,18 @@ func (round *round3) NextRound() tss.Round {
 	round.started = false
 	return &round4{round}
 }
+
+// evaluateVs evaluates the polynomial committed to by Vs at id in the exponent
+func evaluateVs(Vs vss.Vs, id *big.Int) (*crypto.ECPoint, error) {
+	modQ := common.ModInt(tss.EC().Params().N)
+	var err error
+	result := Vs[0]
+	z := big.NewInt(1)
+	for c := 1; c < len(Vs); c++ {
+		z = modQ.Mul(z, id)
+		if result, err = result.Add(Vs[c].ScalarMult(z)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
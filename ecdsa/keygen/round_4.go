@@ -10,7 +10,6 @@ import (
 	"errors"
 
 	"github.com/zeta-chain/tss-lib/common"
-	"github.com/zeta-chain/tss-lib/crypto/paillier"
 	"github.com/zeta-chain/tss-lib/tss"
 )
 
@@ -39,16 +38,31 @@ func (round *round4) Start() *tss.Error {
 			continue
 		}
 		r3msg := msg.Content().(*KGRound3Message)
-		go func(prf paillier.Proof, j int, ch chan<- bool) {
+		go func(r3msg *KGRound3Message, j int, ch chan<- bool) {
 			ppk := round.save.PaillierPKs[j]
-			ok, err := prf.Verify(ppk.N, PIDs[j], ecdsaPub)
+			if round.ProtocolVersion() == tss.ProtocolV2 {
+				prf, err := r3msg.UnmarshalModProof()
+				if err != nil {
+					common.Logger.Error(round.WrapError(err, Ps[j]).Error())
+					ch <- false
+					return
+				}
+				session := tss.ProofSession(round.SessionID(), Ps[j], ecdsaPub.X(), ecdsaPub.Y())
+				ch <- prf.Verify(session, ppk.N)
+				return
+			}
+			if !r3msg.hasPaillierProof() {
+				ch <- false
+				return
+			}
+			ok, err := r3msg.UnmarshalProofInts().Verify(ppk.N, PIDs[j], ecdsaPub)
 			if err != nil {
 				common.Logger.Error(round.WrapError(err, Ps[j]).Error())
 				ch <- false
 				return
 			}
 			ch <- ok
-		}(r3msg.UnmarshalProofInts(), j, chs[j])
+		}(r3msg, j, chs[j])
 	}
 
 	// consume unbuffered channels (end the goroutines)
@@ -73,21 +87,73 @@ func (round *round4) Start() *tss.Error {
 		return round.WrapError(errors.New("paillier verify failed"), culprits...)
 	}
 
-	round.end <- *round.save
+	// the proofs are done; `ok` now tracks the fingerprints received from the other parties
+	round.resetOK()
 
+	// BROADCAST the fingerprint of the public data so that every party can confirm it ended up with the same key
+	round.save.Fingerprint = round.fingerprint()
+	r4msg := NewKGRound4Message(round.PartyID(), round.save.Fingerprint)
+	round.temp.kgRound4Messages[i] = r4msg
+	round.out <- r4msg
 	return nil
 }
 
 func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
-	// not expecting any incoming messages in this round
+	if _, ok := msg.Content().(*KGRound4Message); ok {
+		return msg.IsBroadcast()
+	}
 	return false
 }
 
 func (round *round4) Update() (bool, *tss.Error) {
-	// not expecting any incoming messages in this round
-	return false, nil
+	for j, msg := range round.temp.kgRound4Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		// fingerprint check is in round 5
+		round.ok[j] = true
+	}
+	return true, nil
 }
 
 func (round *round4) NextRound() tss.Round {
-	return nil // finished!
+	round.started = false
+	return &round5{round}
+}
+
+// fingerprint hashes the public data of the keygen that every party should agree on:
+// the public key, each party's share id, public share, Paillier and range proof parameters, and the commitments of round 1.
+// The keys after the first one of a batch keygen are included too.
+func (round *round4) fingerprint() []byte {
+	save := round.save
+	in := [][]byte{[]byte(TaskName), save.ECDSAPub.Bytes()}
+	r1msgs := make([]*KGRound1Message, len(round.temp.kgRound1Messages))
+	for j, msg := range round.temp.kgRound1Messages {
+		r1msgs[j] = msg.Content().(*KGRound1Message)
+	}
+	for j := range round.Parties().IDs() {
+		in = append(in,
+			save.Ks[j].Bytes(),
+			save.BigXj[j].Bytes(),
+			save.PaillierPKs[j].N.Bytes(),
+			save.NTildej[j].Bytes(),
+			save.H1j[j].Bytes(),
+			save.H2j[j].Bytes(),
+			r1msgs[j].GetCommitment())
+		if save.IsWeighted() {
+			for e, kj := range save.ExtraKs[j] {
+				in = append(in, kj.Bytes(), save.ExtraBigXj[j][e].Bytes())
+			}
+		}
+	}
+	for b, batchSave := range round.batch {
+		in = append(in, batchSave.ECDSAPub.Bytes())
+		for j := range round.Parties().IDs() {
+			in = append(in, batchSave.BigXj[j].Bytes(), r1msgs[j].GetBatchCommitments()[b])
+		}
+	}
+	return common.SHA512_256(in...)
 }
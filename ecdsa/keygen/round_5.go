@@ -0,0 +1,64 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// FingerprintSize is the size in bytes of the keygen fingerprint stored in LocalPartySaveData.Fingerprint
+const FingerprintSize = 32
+
+func (round *round5) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+
+	// the parties whose fingerprint differs from ours; if we are the odd one out, this names everyone else
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, msg := range round.temp.kgRound4Messages {
+		r4msg := msg.Content().(*KGRound4Message)
+		if !bytes.Equal(r4msg.GetFingerprint(), round.save.Fingerprint) {
+			common.Logger.Warnf("keygen fingerprint of party %s does not match ours", Ps[j])
+			culprits = append(culprits, Ps[j])
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("keygen fingerprints do not match"), culprits...)
+	}
+
+	if round.batchEnd != nil {
+		round.batchEnd <- round.batchSaveData()
+		return nil
+	}
+	round.end <- *round.save
+
+	return nil
+}
+
+func (round *round5) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round5) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round5) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -17,13 +17,15 @@ const (
 type (
 	base struct {
 		*tss.Parameters
-		save    *LocalPartySaveData
-		temp    *localTempData
-		out     chan<- tss.Message
-		end     chan<- LocalPartySaveData
-		ok      []bool // `ok` tracks parties which have been verified by Update()
-		started bool
-		number  int
+		save     *LocalPartySaveData
+		temp     *localTempData
+		batch    []LocalPartySaveData // the keys after the first one in a batch keygen
+		out      chan<- tss.Message
+		end      chan<- LocalPartySaveData
+		batchEnd chan<- []LocalPartySaveData
+		ok       []bool // `ok` tracks parties which have been verified by Update()
+		started  bool
+		number   int
 	}
 	round1 struct {
 		*base
@@ -37,6 +39,9 @@ type (
 	round4 struct {
 		*round3
 	}
+	round5 struct {
+		*round4
+	}
 )
 
 var (
@@ -44,6 +49,7 @@ var (
 	_ tss.Round = (*round2)(nil)
 	_ tss.Round = (*round3)(nil)
 	_ tss.Round = (*round4)(nil)
+	_ tss.Round = (*round5)(nil)
 )
 
 // ----- //
@@ -9,10 +9,12 @@ package keygen
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/zeta-chain/tss-lib/crypto"
 	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/tss"
 )
 
@@ -23,11 +25,17 @@ type (
 		H1i, H2i,
 		Alpha, Beta,
 		P, Q *big.Int
+
+		// bit lengths chosen in GeneratePreParamsWithOptions; zero in data saved by older versions
+		PaillierModulusLen, NTildeModulusLen int
 	}
 
 	LocalSecrets struct {
 		// secret fields (not shared, but stored locally)
 		Xi, ShareID *big.Int // xi, kj
+
+		// the shares at ExtraKs[i] held by a party with a weight above one (weighted keygen)
+		ExtraXi []*big.Int
 	}
 
 	// Everything in LocalPartySaveData is saved locally to user's HD when done
@@ -47,6 +55,16 @@ type (
 
 		// the ECDSA public key
 		ECDSAPub *crypto.ECPoint // y
+
+		// the share ids and public shares beyond Ks[j], BigXj[j] of each party with a weight above one (weighted keygen); nil otherwise
+		ExtraKs    [][]*big.Int
+		ExtraBigXj [][]*crypto.ECPoint
+
+		// the hash of the public data of the keygen confirmed by all parties in its last round; nil for keys from older versions
+		Fingerprint []byte
+
+		// the name of the curve of the key, i.e. tss.Secp256k1 or tss.Nist256p1; empty for keys from older versions
+		Curve tss.CurveName
 	}
 )
 
@@ -56,6 +74,7 @@ func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
 	saveData.H1j, saveData.H2j = make([]*big.Int, partyCount), make([]*big.Int, partyCount)
 	saveData.BigXj = make([]*crypto.ECPoint, partyCount)
 	saveData.PaillierPKs = make([]*paillier.PublicKey, partyCount)
+	saveData.Curve, _ = tss.GetCurveName(tss.EC())
 	return
 }
 
@@ -76,6 +95,158 @@ func (preParams LocalPreParams) ValidateWithProof() bool {
 		preParams.Q != nil
 }
 
+// Validate checks that the save data is self-consistent so that a corrupted or mismatched share is detected before a protocol is started.
+// The threshold is not stored, so the BigXj must interpolate to ECDSAPub for the lowest threshold that fits all of them.
+func (save LocalPartySaveData) Validate() error {
+	_, err := save.validate()
+	return err
+}
+
+// ValidateThreshold checks the save data as Validate does, and that the BigXj lie on a polynomial of degree threshold,
+// so that a key from a keygen with another threshold than the protocol parameters is rejected before it is started.
+// The signing, re-sharing, CGGMP21 aux info and presigning parties call it in their constructors and return its error from
+// Start(), so that a corrupted share fails before any message is sent instead of several rounds in.
+func (save LocalPartySaveData) ValidateThreshold(threshold int) error {
+	degree, err := save.validate()
+	if err != nil {
+		return err
+	}
+	if degree != threshold {
+		return fmt.Errorf("save data was generated with threshold %d, not %d", degree, threshold)
+	}
+	return nil
+}
+
+// validate returns the degree of the polynomial that the BigXj lie on
+func (save LocalPartySaveData) validate() (int, error) {
+	n := len(save.Ks)
+	if n == 0 || save.Xi == nil || save.ShareID == nil || save.ECDSAPub == nil {
+		return 0, errors.New("save data is incomplete")
+	}
+	if len(save.BigXj) != n || len(save.PaillierPKs) != n || len(save.NTildej) != n || len(save.H1j) != n || len(save.H2j) != n {
+		return 0, errors.New("save data has inconsistent party counts")
+	}
+	if err := save.validateCurve(); err != nil {
+		return 0, err
+	}
+	i, err := validateKsAndShareID(save.Ks, save.ShareID)
+	if err != nil {
+		return 0, err
+	}
+	if err := save.validateExtraShares(i); err != nil {
+		return 0, err
+	}
+	ks, bigXs := save.AllShareIDs()
+	if _, err := validateKsAndShareID(ks, save.ShareID); err != nil {
+		return 0, err
+	}
+	for j := range bigXs {
+		if bigXs[j] == nil || !bigXs[j].IsOnCurve() {
+			return 0, fmt.Errorf("save data public share %d is invalid", j)
+		}
+	}
+	for j := range save.Ks {
+		if save.PaillierPKs[j] == nil || save.PaillierPKs[j].N == nil ||
+			save.NTildej[j] == nil || save.H1j[j] == nil || save.H2j[j] == nil {
+			return 0, fmt.Errorf("save data is missing the public parameters of party %d", j)
+		}
+	}
+	if !crypto.ScalarBaseMult(tss.EC(), save.Xi).Equals(save.BigXj[i]) {
+		return 0, errors.New("save data Xi*G does not match BigXj[i]")
+	}
+	for k, xik := range save.ExtraXi {
+		if xik == nil || !crypto.ScalarBaseMult(tss.EC(), xik).Equals(save.ExtraBigXj[i][k]) {
+			return 0, fmt.Errorf("save data ExtraXi[%d]*G does not match ExtraBigXj[i][%d]", k, k)
+		}
+	}
+	degree, err := vss.PublicSharesThreshold(tss.EC(), ks, bigXs, save.ECDSAPub)
+	if err != nil {
+		return 0, fmt.Errorf("save data BigXj do not match ECDSAPub: %v", err)
+	}
+	if !save.LocalPreParams.Validate() {
+		return 0, errors.New("save data is missing the local pre-params")
+	}
+	if save.PaillierSK.N == nil || save.PaillierSK.N.Cmp(save.PaillierPKs[i].N) != 0 {
+		return 0, errors.New("save data PaillierSK does not match PaillierPKs[i]")
+	}
+	if save.NTildei.Cmp(save.NTildej[i]) != 0 || save.H1i.Cmp(save.H1j[i]) != 0 || save.H2i.Cmp(save.H2j[i]) != 0 {
+		return 0, errors.New("save data NTildei, H1i, H2i do not match NTildej[i], H1j[i], H2j[i]")
+	}
+	return degree, nil
+}
+
+// IsWeighted returns true if the key was generated by a weighted keygen in which a party may hold several shares.
+func (save LocalPartySaveData) IsWeighted() bool {
+	return save.ExtraKs != nil
+}
+
+// AllShareIDs returns the share ids and public shares of every party, the Ks and BigXj followed by the ExtraKs and ExtraBigXj in party order.
+func (save LocalPartySaveData) AllShareIDs() ([]*big.Int, []*crypto.ECPoint) {
+	ks, bigXs := append([]*big.Int{}, save.Ks...), append([]*crypto.ECPoint{}, save.BigXj...)
+	for j := range save.ExtraKs {
+		ks = append(ks, save.ExtraKs[j]...)
+		bigXs = append(bigXs, save.ExtraBigXj[j]...)
+	}
+	return ks, bigXs
+}
+
+// validateCurve checks that the key is on the curve currently set with tss.SetCurve
+func (save LocalPartySaveData) validateCurve() error {
+	if save.Curve != "" {
+		curve, ok := tss.GetCurveByName(save.Curve)
+		if !ok {
+			return fmt.Errorf("save data curve %q is not registered", save.Curve)
+		}
+		if !tss.SameCurve(curve, tss.EC()) {
+			name, _ := tss.GetCurveName(tss.EC())
+			return fmt.Errorf("save data is for curve %q, but the curve in use is %q", save.Curve, name)
+		}
+	}
+	if !tss.SameCurve(save.ECDSAPub.Curve(), tss.EC()) {
+		return errors.New("save data ECDSAPub is not on the curve in use")
+	}
+	return nil
+}
+
+func (save LocalPartySaveData) validateExtraShares(i int) error {
+	if !save.IsWeighted() {
+		if save.ExtraBigXj != nil || len(save.ExtraXi) != 0 {
+			return errors.New("save data has extra shares but no ExtraKs")
+		}
+		return nil
+	}
+	if len(save.ExtraKs) != len(save.Ks) || len(save.ExtraBigXj) != len(save.Ks) {
+		return errors.New("save data has inconsistent party counts in ExtraKs, ExtraBigXj")
+	}
+	for j := range save.ExtraKs {
+		if len(save.ExtraKs[j]) != len(save.ExtraBigXj[j]) {
+			return fmt.Errorf("save data has inconsistent share counts for party %d", j)
+		}
+	}
+	if len(save.ExtraXi) != len(save.ExtraKs[i]) {
+		return errors.New("save data ExtraXi does not match ExtraKs[i]")
+	}
+	return nil
+}
+
+// validateKsAndShareID checks that the Ks are unique and non-zero and returns the index of shareID within them.
+func validateKsAndShareID(ks []*big.Int, shareID *big.Int) (int, error) {
+	for j, kj := range ks {
+		if kj == nil {
+			return -1, fmt.Errorf("save data Ks[%d] is missing", j)
+		}
+	}
+	if _, err := vss.CheckIndexes(tss.EC(), ks); err != nil {
+		return -1, fmt.Errorf("save data Ks are invalid: %v", err)
+	}
+	for j, kj := range ks {
+		if kj.Cmp(shareID) == 0 {
+			return j, nil
+		}
+	}
+	return -1, errors.New("save data ShareID was not found in Ks")
+}
+
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
 func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) LocalPartySaveData {
 	keysToIndices := make(map[string]int, len(sourceData.Ks))
@@ -86,6 +257,8 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData.LocalPreParams = sourceData.LocalPreParams
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.ECDSAPub = sourceData.ECDSAPub
+	newData.Fingerprint = sourceData.Fingerprint
+	newData.Curve = sourceData.Curve
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
@@ -97,6 +270,14 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 		newData.H2j[j] = sourceData.H2j[savedIdx]
 		newData.BigXj[j] = sourceData.BigXj[savedIdx]
 		newData.PaillierPKs[j] = sourceData.PaillierPKs[savedIdx]
+		if sourceData.IsWeighted() {
+			if newData.ExtraKs == nil {
+				newData.ExtraKs = make([][]*big.Int, sortedIDs.Len())
+				newData.ExtraBigXj = make([][]*crypto.ECPoint, sortedIDs.Len())
+			}
+			newData.ExtraKs[j] = sourceData.ExtraKs[savedIdx]
+			newData.ExtraBigXj[j] = sourceData.ExtraBigXj[savedIdx]
+		}
 	}
 	return newData
 }
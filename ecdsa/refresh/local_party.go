@@ -0,0 +1,184 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zeta-chain/tss-lib/common"
+	cmt "github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp        localTempData
+		input, save keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		rfRound1Messages,
+		rfRound2Message1s,
+		rfRound2Message2s []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after refresh)
+		rotatePaillier bool
+		RFCs           []cmt.HashCommitment
+		vs             vss.Vs
+		shares         vss.Shares
+		deCommitPolyG  cmt.HashDeCommitment
+	}
+)
+
+// Exported, used in `tss` client
+// The share refresh protocol re-randomises the `Xi` of every party in the committee with a sharing of zero, so the
+// ECDSAPub stays the same while the previous shares become useless to an attacker. Every party holding a share of the
+// key must take part. When `optionalPreParams` is provided this party also rotates its Paillier key and NTilde, h1, h2.
+// The `key` given here is not modified; the refreshed save data is sent through `end` and the old share should be
+// deleted once every party has finished.
+func NewLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- keygen.LocalPartySaveData,
+	optionalPreParams ...keygen.LocalPreParams,
+) tss.Party {
+	partyCount := params.PartyCount()
+	if len(key.Ks) != partyCount {
+		panic(errors.New("refresh.NewLocalParty: every party holding a share of the key must take part in the refresh"))
+	}
+	if key.IsWeighted() {
+		panic(errors.New("refresh.NewLocalParty: refreshing a key from a weighted keygen is not supported"))
+	}
+	subset := keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		input:     subset,
+		save:      keygen.NewLocalPartySaveData(partyCount),
+		out:       out,
+		end:       end,
+	}
+	// when `optionalPreParams` is provided we'll rotate to the given Paillier key and safe primes
+	if 0 < len(optionalPreParams) {
+		if 1 < len(optionalPreParams) {
+			panic(errors.New("refresh.NewLocalParty expected 0 or 1 item in `optionalPreParams`"))
+		}
+		if !optionalPreParams[0].ValidateWithProof() {
+			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+		}
+		p.save.LocalPreParams = optionalPreParams[0]
+		p.temp.rotatePaillier = true
+	} else {
+		p.save.LocalPreParams = subset.LocalPreParams
+	}
+	// msgs init
+	p.temp.rfRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.rfRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.rfRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.RFCs = make([]cmt.HashCommitment, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+// Close stops the party and overwrites the shares of zero that it made and received.
+// The key data given to NewLocalParty is left as is.
+func (p *LocalParty) Close() {
+	tss.BaseClose(p, p.temp.wipe)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *RFRound1Message:
+		p.temp.rfRound1Messages[fromPIdx] = msg
+	case *RFRound2Message1:
+		p.temp.rfRound2Message1s[fromPIdx] = msg
+	case *RFRound2Message2:
+		p.temp.rfRound2Message2s[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+func (temp *localTempData) wipe() {
+	temp.shares.Wipe()
+	for _, msg := range temp.rfRound2Message1s {
+		if msg != nil {
+			common.WipeBytes(msg.Content().(*RFRound2Message1).GetShare())
+		}
+	}
+}
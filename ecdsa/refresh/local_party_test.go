@@ -0,0 +1,216 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh_test
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	. "github.com/zeta-chain/tss-lib/ecdsa/refresh"
+	"github.com/zeta-chain/tss-lib/ecdsa/signing"
+	"github.com/zeta-chain/tss-lib/test"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	oldKeys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: refresh
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// parties 0 and 1 rotate their Paillier keys by swapping pre-params; this keeps h1j, h2j unique across the committee
+	for j, pID := range pIDs {
+		params := tss.NewParameters(p2pCtx, pID, len(pIDs), threshold)
+		var P *LocalParty
+		switch j {
+		case 0:
+			P = NewLocalParty(params, oldKeys[j], outCh, endCh, oldKeys[1].LocalPreParams).(*LocalParty)
+		case 1:
+			P = NewLocalParty(params, oldKeys[j], outCh, endCh, oldKeys[0].LocalPreParams).(*LocalParty)
+		default:
+			P = NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty)
+		}
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	var ended int32
+refresh:
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = save
+			if atomic.AddInt32(&ended, 1) == int32(len(pIDs)) {
+				t.Logf("Refresh done. Refreshed %d participants", ended)
+				break refresh
+			}
+		}
+	}
+
+	// the public key is unchanged and every share has been re-randomised
+	for j, key := range newKeys {
+		assert.True(t, key.ECDSAPub.Equals(oldKeys[0].ECDSAPub), "ensure the ECDSAPub is unchanged")
+		assert.NotEqual(t, 0, key.Xi.Cmp(oldKeys[j].Xi), "ensure xj has been refreshed")
+		assert.False(t, key.BigXj[j].Equals(oldKeys[j].BigXj[j]), "ensure BigX_j has been refreshed")
+		for k, BigXk := range key.BigXj {
+			assert.True(t, BigXk.Equals(newKeys[k].BigXj[k]), "ensure all parties agree on BigX_k")
+		}
+		gXj := crypto.ScalarBaseMult(tss.EC(), key.Xi)
+		assert.True(t, key.BigXj[j].Equals(gXj), "ensure BigX_j == g^x_j")
+	}
+
+	// the rotated Paillier keys are known to every party
+	for _, key := range newKeys {
+		assert.Equal(t, 0, key.PaillierPKs[0].N.Cmp(oldKeys[1].PaillierSK.N))
+		assert.Equal(t, 0, key.PaillierPKs[1].N.Cmp(oldKeys[0].PaillierSK.N))
+		assert.Equal(t, 0, key.NTildej[0].Cmp(oldKeys[1].NTildei))
+		assert.Equal(t, 0, key.PaillierPKs[2].N.Cmp(oldKeys[2].PaillierSK.N))
+	}
+	assert.Equal(t, 0, newKeys[0].PaillierSK.N.Cmp(oldKeys[1].PaillierSK.N))
+
+	// the refreshed shares reconstruct the same secret while mixing old and new shares does not
+	shares := make(vss.Shares, 0, threshold+1)
+	mixed := make(vss.Shares, 0, threshold+1)
+	for j := 0; j <= threshold; j++ {
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: newKeys[j].ShareID, Share: newKeys[j].Xi})
+		mixedKey := newKeys[j]
+		if j == 0 {
+			mixedKey = oldKeys[j]
+		}
+		mixed = append(mixed, &vss.Share{Threshold: threshold, ID: mixedKey.ShareID, Share: mixedKey.Xi})
+	}
+	secret, err := shares.ReConstruct()
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.EC(), secret).Equals(oldKeys[0].ECDSAPub), "ensure the refreshed shares reconstruct the secret key")
+	mixedSecret, err := mixed.ReConstruct()
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, secret.Cmp(mixedSecret), "ensure an old share cannot be combined with refreshed shares")
+
+	// PHASE: signing with a subset of the refreshed keys
+	signPIDs := pIDs[:threshold+1]
+	signP2pCtx := tss.NewPeerContext(signPIDs)
+	signParties := make([]*signing.LocalParty, 0, len(signPIDs))
+
+	signErrCh := make(chan *tss.Error, len(signPIDs))
+	signOutCh := make(chan tss.Message, len(signPIDs))
+	signEndCh := make(chan *signing.SignatureData, len(signPIDs))
+
+	for j, signPID := range signPIDs {
+		params := tss.NewParameters(signP2pCtx, signPID, len(signPIDs), threshold)
+		P := signing.NewLocalParty(big.NewInt(42), params, newKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
+		signParties = append(signParties, P)
+		go func(P *signing.LocalParty) {
+			if err := P.Start(); err != nil {
+				signErrCh <- err
+			}
+		}(P)
+	}
+
+	var signEnded int32
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-signErrCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-signOutCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range signParties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, signErrCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(signParties[dest[0].Index], msg, signErrCh)
+			}
+
+		case signData := <-signEndCh:
+			if atomic.AddInt32(&signEnded, 1) == int32(len(signPIDs)) {
+				t.Logf("Signing done. Received sign data from %d participants", signEnded)
+
+				pk := oldKeys[0].ECDSAPub.ToECDSAPubKey()
+				ok := ecdsa.Verify(pk, big.NewInt(42).Bytes(),
+					new(big.Int).SetBytes(signData.Signature.R),
+					new(big.Int).SetBytes(signData.Signature.S))
+				assert.True(t, ok, "ecdsa verify must pass")
+				t.Log("ECDSA signing test done.")
+				return
+			}
+		}
+	}
+}
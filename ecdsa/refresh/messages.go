@@ -0,0 +1,194 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	cmt "github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/dlnp"
+	"github.com/zeta-chain/tss-lib/crypto/facproof"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-refresh.pb.go
+
+var (
+	// Ensure that refresh messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*RFRound1Message)(nil),
+		(*RFRound2Message1)(nil),
+		(*RFRound2Message2)(nil),
+	}
+)
+
+// ----- //
+
+// NewRFRound1Message builds the round 1 broadcast. `paillierPK` should be nil when the sender keeps its Paillier key,
+// in which case the remaining Paillier and NTilde arguments are ignored.
+func NewRFRound1Message(
+	from *tss.PartyID,
+	ct cmt.HashCommitment,
+	paillierPK *paillier.PublicKey,
+	paillierPf paillier.Proof,
+	nTildeI, h1I, h2I *big.Int,
+	dlnProof1, dlnProof2 *dlnp.Proof,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &RFRound1Message{
+		Commitment: ct.Bytes(),
+	}
+	if paillierPK != nil {
+		dlnProof1Bz, err := dlnProof1.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dlnProof2Bz, err := dlnProof2.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		content.PaillierN = paillierPK.N.Bytes()
+		content.PaillierProof = common.BigIntsToBytes(paillierPf[:])
+		content.NTilde = nTildeI.Bytes()
+		content.H1 = h1I.Bytes()
+		content.H2 = h2I.Bytes()
+		content.Dlnproof_1 = dlnProof1Bz
+		content.Dlnproof_2 = dlnProof2Bz
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RFRound1Message) ValidateBasic() bool {
+	if m == nil || !common.NonEmptyBytes(m.GetCommitment()) {
+		return false
+	}
+	if !m.RotatesPaillier() {
+		return len(m.GetPaillierProof()) == 0 &&
+			len(m.GetNTilde()) == 0 &&
+			len(m.GetH1()) == 0 &&
+			len(m.GetH2()) == 0 &&
+			len(m.GetDlnproof_1()) == 0 &&
+			len(m.GetDlnproof_2()) == 0
+	}
+	return common.NonEmptyMultiBytes(m.GetPaillierProof(), paillier.ProofIters) &&
+		common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnp.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnp.Iterations*2))
+}
+
+// RotatesPaillier reports whether the sender is replacing its Paillier key and NTilde, h1, h2 in this refresh
+func (m *RFRound1Message) RotatesPaillier() bool {
+	return common.NonEmptyBytes(m.GetPaillierN())
+}
+
+func (m *RFRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+func (m *RFRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *RFRound1Message) UnmarshalPaillierProof() paillier.Proof {
+	var pf paillier.Proof
+	ints := common.ByteSlicesToBigInts(m.GetPaillierProof())
+	copy(pf[:], ints[:paillier.ProofIters])
+	return pf
+}
+
+func (m *RFRound1Message) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *RFRound1Message) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *RFRound1Message) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *RFRound1Message) UnmarshalDLNProof1() (*dlnp.Proof, error) {
+	return dlnp.UnmarshalProof(m.GetDlnproof_1())
+}
+
+func (m *RFRound1Message) UnmarshalDLNProof2() (*dlnp.Proof, error) {
+	return dlnp.UnmarshalProof(m.GetDlnproof_2())
+}
+
+// ----- //
+
+func NewRFRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+	proof *facproof.ProofFac,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	proofBzs := proof.Bytes()
+	content := &RFRound2Message1{
+		Share:    share.Share.Bytes(),
+		FacProof: proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RFRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare()) &&
+		common.NonEmptyMultiBytes(m.GetFacProof(), facproof.ProofFacBytesParts)
+}
+
+func (m *RFRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.GetShare())
+}
+
+func (m *RFRound2Message1) UnmarshalFacProof() (*facproof.ProofFac, error) {
+	return facproof.NewProofFromBytes(m.GetFacProof())
+}
+
+// ----- //
+
+func NewRFRound2Message2(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	content := &RFRound2Message2{
+		DeCommitment: dcBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RFRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment())
+}
+
+func (m *RFRound2Message2) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
@@ -0,0 +1,152 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/zeta-chain/tss-lib/crypto/facproof"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// 1. verify the paillier & dln proofs of the parties that are rotating their keys
+	proofFailCulprits := make([]*tss.PartyID, len(round.temp.rfRound1Messages))
+	wg := new(sync.WaitGroup)
+	for j, msg := range round.temp.rfRound1Messages {
+		r1msg := msg.Content().(*RFRound1Message)
+		round.temp.RFCs[j] = r1msg.UnmarshalCommitment()
+		if j == i || !r1msg.RotatesPaillier() {
+			continue
+		}
+		paiPK, NTildej, H1j, H2j :=
+			r1msg.UnmarshalPaillierPK(),
+			r1msg.UnmarshalNTilde(),
+			r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2()
+		if bitLen := paiPK.N.BitLen(); bitLen < round.MinModulusBitLen() || bitLen > round.MaxModulusBitLen() {
+			return round.WrapError(errors.New("got paillier modulus with a bit length out of range for this party"), msg.GetFrom())
+		}
+		if bitLen := NTildej.BitLen(); bitLen < round.MinModulusBitLen() || bitLen > round.MaxModulusBitLen() {
+			return round.WrapError(errors.New("got NTildej with a bit length out of range for this party"), msg.GetFrom())
+		}
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+		}
+		wg.Add(1)
+		go func(j int, msg tss.ParsedMessage, r1msg *RFRound1Message) {
+			defer wg.Done()
+			if ok, err := r1msg.UnmarshalPaillierProof().Verify(paiPK.N, msg.GetFrom().KeyInt(), round.save.ECDSAPub); err != nil || !ok {
+				proofFailCulprits[j] = msg.GetFrom()
+				return
+			}
+			if dlnProof1, err := r1msg.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(H1j, H2j, NTildej) {
+				proofFailCulprits[j] = msg.GetFrom()
+				return
+			}
+			if dlnProof2, err := r1msg.UnmarshalDLNProof2(); err != nil || !dlnProof2.Verify(H2j, H1j, NTildej) {
+				proofFailCulprits[j] = msg.GetFrom()
+			}
+		}(j, msg, r1msg)
+		round.save.PaillierPKs[j] = paiPK
+		round.save.NTildej[j] = NTildej
+		round.save.H1j[j], round.save.H2j[j] = H1j, H2j
+	}
+	wg.Wait()
+	culprits := make([]*tss.PartyID, 0, len(proofFailCulprits))
+	for _, culprit := range proofFailCulprits {
+		if culprit != nil {
+			culprits = append(culprits, culprit)
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("paillier or dln proof verification failed"), culprits...)
+	}
+
+	// 2. ensure uniqueness of h1j, h2j across the committee after any rotation
+	// the H1, H2 dupe check is disabled during some benchmarking scenarios to allow reuse of pre-params
+	if !round.Params().UNSAFE_KGIgnoreH1H2Dupes() {
+		h1H2Map := make(map[string]struct{}, len(round.save.H1j)*2)
+		for j, Pj := range round.Parties().IDs() {
+			h1JHex, h2JHex := hex.EncodeToString(round.save.H1j[j].Bytes()), hex.EncodeToString(round.save.H2j[j].Bytes())
+			if _, found := h1H2Map[h1JHex]; found {
+				return round.WrapError(errors.New("this h1j was already used by another party"), Pj)
+			}
+			if _, found := h1H2Map[h2JHex]; found {
+				return round.WrapError(errors.New("this h2j was already used by another party"), Pj)
+			}
+			h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+		}
+	}
+
+	// 3. p2p send share ij to Pj
+	shares := round.temp.shares
+	for j, Pj := range round.Parties().IDs() {
+		facProof, err := facproof.NewProof(tss.EC(), round.save.PaillierSK.N, round.save.NTildej[j],
+			round.save.H1j[j], round.save.H2j[j], round.save.PaillierSK.P, round.save.PaillierSK.Q)
+		if err != nil {
+			return round.WrapError(err, round.PartyID())
+		}
+		r2msg1 := NewRFRound2Message1(Pj, round.PartyID(), shares[j], facProof)
+		// do not send to this Pj, but store for round 3
+		if j == i {
+			round.temp.rfRound2Message1s[j] = r2msg1
+			continue
+		}
+		round.out <- r2msg1
+	}
+
+	// 4. BROADCAST de-commitments of the zero poly*G
+	r2msg2 := NewRFRound2Message2(round.PartyID(), round.temp.deCommitPolyG)
+	round.temp.rfRound2Message2s[i] = r2msg2
+	round.out <- r2msg2
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RFRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*RFRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.rfRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		msg2 := round.temp.rfRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
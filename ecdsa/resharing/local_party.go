@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -30,6 +31,7 @@ type (
 
 		temp        localTempData
 		input, save keygen.LocalPartySaveData
+		inputErr    error // set when the key data of an old committee member failed to validate
 
 		// outbound messaging
 		out chan<- tss.Message
@@ -72,14 +74,20 @@ func NewLocalParty(
 ) tss.Party {
 	oldPartyCount := len(params.OldParties().IDs())
 	subset := key
+	var inputErr error
 	if params.IsOldCommittee() {
-		subset = keygen.BuildLocalSaveDataSubset(key, params.OldParties().IDs())
+		if key.IsWeighted() {
+			inputErr = errors.New("re-sharing a key from a weighted keygen is not supported")
+		} else if inputErr = key.ValidateThreshold(params.Threshold()); inputErr == nil {
+			subset = keygen.BuildLocalSaveDataSubset(key, params.OldParties().IDs())
+		}
 	}
 	p := &LocalParty{
 		BaseParty: new(tss.BaseParty),
 		params:    params,
 		temp:      localTempData{},
 		input:     subset,
+		inputErr:  inputErr,
 		save:      keygen.NewLocalPartySaveData(params.NewPartyCount()),
 		out:       out,
 		end:       end,
@@ -103,13 +111,24 @@ func (p *LocalParty) FirstRound() tss.Round {
 }
 
 func (p *LocalParty) Start() *tss.Error {
-	return tss.BaseStart(p, TaskName)
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		if p.inputErr != nil {
+			return round.WrapError(fmt.Errorf("invalid key data: %v", p.inputErr))
+		}
+		return nil
+	})
 }
 
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
 
+// Close stops the party and overwrites the shares made for the new committee and the shares received from the old one.
+// The key data given to NewLocalParty is left as is; an old committee member's share is only destroyed when the re-sharing succeeds.
+func (p *LocalParty) Close() {
+	tss.BaseClose(p, p.wipe)
+}
+
 func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
 	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
 	if err != nil {
@@ -173,3 +192,16 @@ func (p *LocalParty) PartyID() *tss.PartyID {
 func (p *LocalParty) String() string {
 	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
 }
+
+// wipe overwrites the secrets in the temp data; the new x_i is left as is once it is saved, as it is sent through `end`
+func (p *LocalParty) wipe() {
+	p.temp.NewShares.Wipe()
+	if p.temp.newXi != p.save.Xi {
+		common.WipeBigInt(p.temp.newXi)
+	}
+	for _, msg := range p.temp.dgRound3Message1s {
+		if msg != nil {
+			common.WipeBytes(msg.Content().(*DGRound3Message1).GetShare())
+		}
+	}
+}
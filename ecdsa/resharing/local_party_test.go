@@ -8,17 +8,20 @@ package resharing_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"fmt"
 	"math/big"
 	"runtime"
 	"sync/atomic"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
 	. "github.com/zeta-chain/tss-lib/ecdsa/resharing"
 	"github.com/zeta-chain/tss-lib/ecdsa/signing"
@@ -38,9 +41,21 @@ func setUp(level string) {
 }
 
 func TestE2EConcurrent(t *testing.T) {
-	setUp("info")
+	testE2EConcurrent(t, tss.ProtocolV1, nil)
+}
 
-	// tss.SetCurve(elliptic.P256())
+// The fixtures were generated with the v1 proofs, so this also checks that those keys may be re-shared with v2.
+func TestE2EConcurrentProtocolV2(t *testing.T) {
+	testE2EConcurrent(t, tss.ProtocolV2, nil)
+}
+
+func TestE2EConcurrentP256(t *testing.T) {
+	testE2EConcurrent(t, tss.ProtocolV1, elliptic.P256())
+}
+
+// testE2EConcurrent re-shares the fixture keys and signs with the new ones; with a curve, a key on that curve is dealt to the fixture parties first
+func testE2EConcurrent(t *testing.T, version tss.ProtocolVersion, curve elliptic.Curve) {
+	setUp("info")
 
 	threshold, newThreshold := testThreshold, testThreshold
 
@@ -56,6 +71,12 @@ func TestE2EConcurrent(t *testing.T) {
 	if err != nil {
 		common.Logger.Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
 	}
+	// the pre-params of the fixtures do not depend on the curve, but their points are on secp256k1
+	if curve != nil {
+		tss.SetCurve(curve)
+		defer tss.SetCurve(btcec.S256())
+		oldKeys = dealKeys(t, oldKeys, threshold)
+	}
 	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
 	newP2PCtx := tss.NewPeerContext(newPIDs)
 	newPCount := len(newPIDs)
@@ -73,12 +94,14 @@ func TestE2EConcurrent(t *testing.T) {
 	// init the old parties first
 	for j, pID := range oldPIDs {
 		params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		params.SetProtocolVersion(version)
 		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty) // discard old key data
 		oldCommittee = append(oldCommittee, P)
 	}
 	// init the new parties
 	for j, pID := range newPIDs {
 		params := tss.NewReSharingParameters(oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		params.SetProtocolVersion(version)
 		save := keygen.NewLocalPartySaveData(newPCount)
 		if j < len(fixtures) && len(newPIDs) <= len(fixtures) {
 			save.LocalPreParams = fixtures[j].LocalPreParams
@@ -146,7 +169,10 @@ func TestE2EConcurrent(t *testing.T) {
 				t.Logf("Resharing done. Reshared %d participants", reSharingEnded)
 
 				// xj tests: BigXj == xj*G
+				curveName, _ := tss.GetCurveName(tss.EC())
 				for j, key := range newKeys {
+					assert.Equal(t, curveName, key.Curve, "ensure the save data records the curve")
+					assert.True(t, key.ECDSAPub.Equals(oldKeys[0].ECDSAPub), "ensure the ECDSAPub is unchanged")
 					// xj test: BigXj == xj*G
 					xj := key.Xi
 					gXj := crypto.ScalarBaseMult(tss.EC(), xj)
@@ -154,6 +180,14 @@ func TestE2EConcurrent(t *testing.T) {
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 				}
 
+				// closing the parties wipes their temp data, but not the new keys, which sign below
+				for _, committee := range [][]*LocalParty{oldCommittee, newCommittee} {
+					for _, P := range committee {
+						P.Close()
+						assert.Error(t, P.Start(), "a closed party should not start")
+					}
+				}
+
 				// more verification of signing is implemented within local_party_test.go of keygen package
 				goto signing
 			}
@@ -231,3 +265,30 @@ signing:
 		}
 	}
 }
+
+// dealKeys replaces the key of the fixtures with a new one on the curve in use, dealt with Feldman VSS to the same share ids
+func dealKeys(t *testing.T, keys []keygen.LocalPartySaveData, threshold int) []keygen.LocalPartySaveData {
+	secret := common.GetRandomPositiveInt(tss.EC().Params().N)
+	_, shares, err := vss.Create(threshold, secret, keys[0].Ks)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	bigXj := make([]*crypto.ECPoint, len(shares))
+	for j, share := range shares {
+		bigXj[j] = crypto.ScalarBaseMult(tss.EC(), share.Share)
+	}
+	curveName, _ := tss.GetCurveName(tss.EC())
+	dealt := make([]keygen.LocalPartySaveData, len(keys))
+	for i, key := range keys {
+		key.BigXj, key.ECDSAPub, key.Curve = bigXj, crypto.ScalarBaseMult(tss.EC(), secret), curveName
+		key.Fingerprint = nil
+		for j, kj := range key.Ks {
+			if kj.Cmp(key.ShareID) == 0 {
+				key.Xi = shares[j].Share
+			}
+		}
+		assert.NoError(t, key.Validate())
+		dealt[i] = key
+	}
+	return dealt
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/zeta-chain/tss-lib/crypto"
 	cmt "github.com/zeta-chain/tss-lib/crypto/commitments"
 	"github.com/zeta-chain/tss-lib/crypto/dlnp"
+	"github.com/zeta-chain/tss-lib/crypto/modproof"
 	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/crypto/prmproof"
 	"github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/tss"
 )
@@ -106,18 +108,56 @@ func NewDGRound2Message1(
 	return tss.NewMessage(meta, content, msg), nil
 }
 
+// NewDGRound2Message1V2 is used with tss.ProtocolV2 and carries a Paillier-Blum modulus proof and a Ring-Pedersen
+// parameter proof in place of the Paillier proof and the dln proofs.
+func NewDGRound2Message1V2(
+	to []*tss.PartyID,
+	from *tss.PartyID,
+	paillierPK *paillier.PublicKey,
+	modProof *modproof.ProofMod,
+	NTildei, H1i, H2i *big.Int,
+	prmProof *prmproof.ProofPrm,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:             from,
+		To:               to,
+		IsBroadcast:      true,
+		IsToOldCommittee: false,
+	}
+	modProofBzs, prmProofBzs := modProof.Bytes(), prmProof.Bytes()
+	content := &DGRound2Message1{
+		PaillierN: paillierPK.N.Bytes(),
+		NTilde:    NTildei.Bytes(),
+		H1:        H1i.Bytes(),
+		H2:        H2i.Bytes(),
+		ModProof:  modProofBzs[:],
+		PrmProof:  prmProofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
 func (m *DGRound2Message1) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyMultiBytes(m.PaillierProof) &&
 		common.NonEmptyBytes(m.PaillierN) &&
 		common.NonEmptyBytes(m.NTilde) &&
 		common.NonEmptyBytes(m.H1) &&
 		common.NonEmptyBytes(m.H2) &&
+		(m.hasV1Proofs() || m.hasV2Proofs())
+}
+
+func (m *DGRound2Message1) hasV1Proofs() bool {
+	return common.NonEmptyMultiBytes(m.GetPaillierProof(), paillier.ProofIters) &&
 		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
 		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnp.Iterations*2)) &&
 		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnp.Iterations*2))
 }
 
+func (m *DGRound2Message1) hasV2Proofs() bool {
+	return common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetPrmProof(), prmproof.ProofPrmBytesParts)
+}
+
 func (m *DGRound2Message1) UnmarshalPaillierPK() *paillier.PublicKey {
 	return &paillier.PublicKey{
 		N: new(big.Int).SetBytes(m.PaillierN),
@@ -151,6 +191,14 @@ func (m *DGRound2Message1) UnmarshalDLNProof2() (*dlnp.Proof, error) {
 	return dlnp.UnmarshalProof(m.GetDlnproof_2())
 }
 
+func (m *DGRound2Message1) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
+
+func (m *DGRound2Message1) UnmarshalPrmProof() (*prmproof.ProofPrm, error) {
+	return prmproof.NewProofFromBytes(m.GetPrmProof())
+}
+
 // ----- //
 
 func NewDGRound2Message2(
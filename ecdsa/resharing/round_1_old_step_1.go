@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/crypto"
 	"github.com/zeta-chain/tss-lib/crypto/commitments"
 	"github.com/zeta-chain/tss-lib/crypto/vss"
@@ -51,6 +52,7 @@ func (round *round1) Start() *tss.Error {
 	if err != nil {
 		return round.WrapError(err, round.PartyID())
 	}
+	defer common.WipeBigInt(wi)
 
 	// 2.
 	vi, shares, err := vss.Create(round.NewThreshold(), wi, newKs)
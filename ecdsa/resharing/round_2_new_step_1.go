@@ -8,8 +8,12 @@ package resharing
 
 import (
 	"errors"
+	"math/big"
 
+	"github.com/zeta-chain/tss-lib/crypto"
 	"github.com/zeta-chain/tss-lib/crypto/dlnp"
+	"github.com/zeta-chain/tss-lib/crypto/modproof"
+	"github.com/zeta-chain/tss-lib/crypto/prmproof"
 	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
 	"github.com/zeta-chain/tss-lib/tss"
 )
@@ -49,7 +53,10 @@ func (round *round2) Start() *tss.Error {
 		preParams = &round.save.LocalPreParams
 	} else {
 		var err error
-		preParams, err = keygen.GeneratePreParams(round.SafePrimeGenTimeout())
+		preParams, err = keygen.GeneratePreParamsWithOptions(round.SafePrimeGenTimeout(), keygen.PreParamsOptions{
+			PaillierModulusLen: round.MinModulusBitLen(),
+			NTildeModulusLen:   round.MinModulusBitLen(),
+		})
 		if err != nil {
 			return round.WrapError(errors.New("pre-params generation failed"), Pi)
 		}
@@ -58,22 +65,7 @@ func (round *round2) Start() *tss.Error {
 	round.save.NTildej[i] = preParams.NTildei
 	round.save.H1j[i], round.save.H2j[i] = preParams.H1i, preParams.H2i
 
-	// generate the dlnproofs for resharing
-	h1i, h2i, alpha, beta, p, q, NTildei :=
-		preParams.H1i,
-		preParams.H2i,
-		preParams.Alpha,
-		preParams.Beta,
-		preParams.P,
-		preParams.Q,
-		preParams.NTildei
-	dlnProof1 := dlnp.NewProof(h1i, h2i, alpha, p, q, NTildei)
-	dlnProof2 := dlnp.NewProof(h2i, h1i, beta, p, q, NTildei)
-
-	paillierPf := preParams.PaillierSK.Proof(Pi.KeyInt(), round.save.ECDSAPub)
-	r2msg2, err := NewDGRound2Message1(
-		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
-		&preParams.PaillierSK.PublicKey, paillierPf, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+	r2msg2, err := newDGRound2Message1(round.ReSharingParams(), round.save.ECDSAPub, preParams)
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
@@ -152,3 +144,37 @@ func (round *round2) NextRound() tss.Round {
 	round.started = false
 	return &round3{round}
 }
+
+// newDGRound2Message1 proves the well-formedness of the Paillier modulus and NTilde with the proofs of the selected protocol version
+func newDGRound2Message1(params *tss.ReSharingParameters, ecdsaPub *crypto.ECPoint, preParams *keygen.LocalPreParams) (tss.ParsedMessage, error) {
+	Pi := params.PartyID()
+	to := params.NewParties().IDs().Exclude(Pi)
+	h1i, h2i, alpha, beta, p, q, NTildei, paiSK :=
+		preParams.H1i,
+		preParams.H2i,
+		preParams.Alpha,
+		preParams.Beta,
+		preParams.P,
+		preParams.Q,
+		preParams.NTildei,
+		preParams.PaillierSK
+	if params.ProtocolVersion() == tss.ProtocolV2 {
+		session := tss.ProofSession(params.SessionID(), Pi, ecdsaPub.X(), ecdsaPub.Y())
+		modProof, err := modproof.NewProof(session, paiSK.N, paiSK.P, paiSK.Q)
+		if err != nil {
+			return nil, err
+		}
+		// h1 = h2^beta, where the order of h2 divides pq
+		prmProof, err := prmproof.NewProof(session, NTildei, h1i, h2i, new(big.Int).Mul(p, q), beta)
+		if err != nil {
+			return nil, err
+		}
+		return NewDGRound2Message1V2(to, Pi, &paiSK.PublicKey, modProof, NTildei, h1i, h2i, prmProof), nil
+	}
+	// generate the dlnproofs for resharing
+	dlnProof1 := dlnp.NewProof(h1i, h2i, alpha, p, q, NTildei)
+	dlnProof2 := dlnp.NewProof(h2i, h1i, beta, p, q, NTildei)
+
+	paillierPf := paiSK.Proof(Pi.KeyInt(), ecdsaPub)
+	return NewDGRound2Message1(to, Pi, &paiSK.PublicKey, paillierPf, NTildei, h1i, h2i, dlnProof1, dlnProof2)
+}
@@ -39,7 +39,7 @@ func (round *round4) Start() *tss.Error {
 	Pi := round.PartyID()
 	i := Pi.Index
 
-	// 1-3. verify paillier & dln proofs, store message pieces, ensure uniqueness of h1j, h2j
+	// 1-3. verify paillier & dln proofs (or the modulus & prm proofs in v2), store message pieces, ensure uniqueness of h1j, h2j
 	h1H2Map := make(map[string]struct{}, len(round.temp.dgRound2Message1s)*2)
 	paiProofCulprits := make([]*tss.PartyID, len(round.temp.dgRound2Message1s)) // who caused the error(s)
 	dlnProof1FailCulprits := make([]*tss.PartyID, len(round.temp.dgRound2Message1s))
@@ -52,6 +52,12 @@ func (round *round4) Start() *tss.Error {
 			r2msg1.UnmarshalNTilde(),
 			r2msg1.UnmarshalH1(),
 			r2msg1.UnmarshalH2()
+		if bitLen := paiPK.N.BitLen(); bitLen < round.MinModulusBitLen() || bitLen > round.MaxModulusBitLen() {
+			return round.WrapError(errors.New("got paillier modulus with a bit length out of range for this party"), msg.GetFrom())
+		}
+		if bitLen := NTildej.BitLen(); bitLen < round.MinModulusBitLen() || bitLen > round.MaxModulusBitLen() {
+			return round.WrapError(errors.New("got NTildej with a bit length out of range for this party"), msg.GetFrom())
+		}
 		if H1j.Cmp(H2j) == 0 {
 			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
 		}
@@ -63,6 +69,29 @@ func (round *round4) Start() *tss.Error {
 			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
 		}
 		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+		if round.ProtocolVersion() == tss.ProtocolV2 {
+			ecdsaPub := round.save.ECDSAPub
+			session := tss.ProofSession(round.SessionID(), msg.GetFrom(), ecdsaPub.X(), ecdsaPub.Y())
+			wg.Add(2)
+			go func(j int, msg tss.ParsedMessage, r2msg1 *DGRound2Message1) {
+				if modProof, err := r2msg1.UnmarshalModProof(); err != nil || !modProof.Verify(session, paiPK.N) {
+					paiProofCulprits[j] = msg.GetFrom()
+					common.Logger.Warnf("paillier-blum modulus proof verify failed for party %s: %v", msg.GetFrom(), err)
+				}
+				wg.Done()
+			}(j, msg, r2msg1)
+			go func(j int, msg tss.ParsedMessage, r2msg1 *DGRound2Message1, H1j, H2j, NTildej *big.Int) {
+				if prmProof, err := r2msg1.UnmarshalPrmProof(); err != nil || !prmProof.Verify(session, NTildej, H1j, H2j) {
+					dlnProof1FailCulprits[j] = msg.GetFrom()
+					common.Logger.Warnf("prm proof verify failed for party %s: %v", msg.GetFrom(), err)
+				}
+				wg.Done()
+			}(j, msg, r2msg1, H1j, H2j, NTildej)
+			continue
+		}
+		if !r2msg1.hasV1Proofs() {
+			return round.WrapError(errors.New("got a message without the paillier and dln proofs"), msg.GetFrom())
+		}
 		wg.Add(3)
 		go func(j int, msg tss.ParsedMessage, r2msg1 *DGRound2Message1) {
 			if ok, err := r2msg1.UnmarshalPaillierProof().Verify(paiPK.N, msg.GetFrom().KeyInt(), round.save.ECDSAPub); err != nil || !ok {
@@ -142,7 +171,7 @@ func (round *round4) Start() *tss.Error {
 		}
 
 		// 9.
-		newXi = new(big.Int).Add(newXi, sharej.Share)
+		newXi.Add(newXi, sharej.Share)
 	}
 
 	// 10-13.
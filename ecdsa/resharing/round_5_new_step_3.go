@@ -9,6 +9,7 @@ package resharing
 import (
 	"errors"
 
+	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/tss"
 )
 
@@ -42,7 +43,7 @@ func (round *round5) Start() *tss.Error {
 			round.save.PaillierPKs[j] = r2msg1.UnmarshalPaillierPK()
 		}
 	} else if round.IsOldCommittee() {
-		round.input.Xi.SetInt64(0)
+		common.WipeBigInt(round.input.Xi)
 	}
 
 	round.end <- *round.save
@@ -0,0 +1,406 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+const (
+	TaskNameBatch = "signing-batch"
+
+	// the most messages that one session sends from a single Start or Update, as several rounds may finish at once
+	batchSessionOutPerParty = 16
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*BatchLocalParty)(nil)
+var _ fmt.Stringer = (*BatchLocalParty)(nil)
+
+type (
+	// BatchLocalParty signs several digests in one session. It runs one signing session per digest in lockstep:
+	// the messages of every session for the same round and recipient are sent together in one SignBatchMessage,
+	// so a batch takes as many network round trips as signing a single digest.
+	BatchLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		mtx      sync.Mutex
+		sessions []*batchSession
+		started  bool
+		ended    bool
+		closed   bool
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- []*SignatureData
+	}
+
+	batchSession struct {
+		party   *LocalParty
+		out     chan tss.Message
+		end     chan *SignatureData
+		pending []tss.Message // messages of this session waiting to be bundled with the other sessions'
+		data    *SignatureData
+		err     *tss.Error
+
+		// the parties that have stopped this session, usually after an identified abort
+		stoppedBy []*tss.PartyID
+	}
+)
+
+// NewBatchLocalParty returns a party that signs each of the digests, as in NewLocalPartyWithDigest.
+// The signatures are sent through `end` once every session has finished, in the order of the digests.
+// A session that fails with an identified abort does not stop the others: its error is returned by Start or Update
+// with the index of its digest, its entry in the result is nil, and Errors reports it once the batch has ended.
+func NewBatchLocalParty(
+	digests [][]byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- []*SignatureData,
+) tss.Party {
+	if len(digests) == 0 {
+		panic(errors.New("signing.NewBatchLocalParty expected at least one digest"))
+	}
+	partyCount := params.PartyCount()
+	p := &BatchLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		sessions:  make([]*batchSession, len(digests)),
+		out:       out,
+		end:       end,
+	}
+	for b, digest := range digests {
+		s := &batchSession{
+			out: make(chan tss.Message, batchSessionOutPerParty*partyCount),
+			end: make(chan *SignatureData, 1),
+		}
+		s.party = NewLocalPartyWithDigest(digest, params, key, s.out, s.end).(*LocalParty)
+		p.sessions[b] = s
+	}
+	return p
+}
+
+func (p *BatchLocalParty) FirstRound() tss.Round {
+	return nil // the rounds are run by the session of each digest
+}
+
+func (p *BatchLocalParty) Start() *tss.Error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.closed {
+		return p.WrapError(errors.New("could not start. this party has been closed"))
+	}
+	if p.started {
+		return p.WrapError(errors.New("could not start. this party is in an unexpected state. use the constructor and Start()"))
+	}
+	p.started = true
+	common.Logger.Infof("party %s: %s of %d digests starting", p.PartyID(), TaskNameBatch, len(p.sessions))
+	var firstErr *tss.Error
+	for b, s := range p.sessions {
+		if err := s.party.Start(); err != nil {
+			firstErr = p.fail(b, err, firstErr)
+		}
+	}
+	return p.flush(firstErr)
+}
+
+func (p *BatchLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.closed {
+		return false, p.WrapError(errors.New("could not update. this party has been closed"))
+	}
+	if !p.started {
+		return false, p.WrapError(errors.New("received a message before Start()"))
+	}
+	if p.ended {
+		return true, nil
+	}
+	messages := msg.Content().(*SignBatchMessage).GetMessages()
+	from := msg.GetFrom()
+	var firstErr *tss.Error
+	for b, s := range p.sessions {
+		if s.done() {
+			continue
+		}
+		if len(messages[b]) == 0 {
+			// the sender stopped this session; give it the chance to identify the culprit itself before it is failed in flush
+			s.stoppedBy = append(s.stoppedBy, from)
+			continue
+		}
+		sMsg, err := tss.ParseWireMessage(messages[b], from, msg.IsBroadcast())
+		if err != nil {
+			firstErr = p.fail(b, s.party.WrapError(err, from), firstErr)
+			continue
+		}
+		if _, err := s.party.Update(sMsg); err != nil {
+			firstErr = p.fail(b, err, firstErr)
+		}
+	}
+	if err := p.flush(firstErr); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Close stops the session of each digest and overwrites its secrets, as LocalParty.Close does.
+func (p *BatchLocalParty) Close() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.closed = true
+	for _, s := range p.sessions {
+		s.party.Close()
+		s.pending = nil
+	}
+}
+
+func (p *BatchLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *BatchLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	batchMsg, ok := msg.Content().(*SignBatchMessage)
+	if !ok {
+		return false, p.WrapError(fmt.Errorf("expected a batch message, got %s", msg.Type()), msg.GetFrom())
+	}
+	if len(batchMsg.GetMessages()) != len(p.sessions) {
+		return false, p.WrapError(fmt.Errorf("expected a batch of %d messages, got %d",
+			len(p.sessions), len(batchMsg.GetMessages())), msg.GetFrom())
+	}
+	return true, nil
+}
+
+// StoreMessage is not used by a batch party, whose sessions store the messages they are given in Update
+func (p *BatchLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	return p.ValidateMessage(msg)
+}
+
+func (p *BatchLocalParty) Running() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.started && !p.ended && !p.closed
+}
+
+// WaitingFor returns the parties that any of the unfinished sessions is waiting for
+func (p *BatchLocalParty) WaitingFor() []*tss.PartyID {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	seen := make(map[int]bool)
+	ids := make([]*tss.PartyID, 0, p.params.PartyCount())
+	for _, s := range p.sessions {
+		if s.done() {
+			continue
+		}
+		for _, Pj := range s.party.WaitingFor() {
+			if !seen[Pj.Index] {
+				seen[Pj.Index] = true
+				ids = append(ids, Pj)
+			}
+		}
+	}
+	return ids
+}
+
+// Errors returns the error of each session that failed, in the order of the digests, and nil for the others
+func (p *BatchLocalParty) Errors() []*tss.Error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	errs := make([]*tss.Error, len(p.sessions))
+	for b, s := range p.sessions {
+		errs[b] = s.err
+	}
+	return errs
+}
+
+func (p *BatchLocalParty) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskNameBatch, -1, p.PartyID(), culprits...)
+}
+
+func (p *BatchLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *BatchLocalParty) String() string {
+	return fmt.Sprintf("id: %s, batch of %d", p.PartyID(), len(p.sessions))
+}
+
+// ----- //
+
+// done is true once the session has ended or failed
+func (s *batchSession) done() bool {
+	return s.err != nil || s.data != nil
+}
+
+// stopped is true when another party has stopped this session, which is still running
+func (s *batchSession) stopped() bool {
+	return !s.done() && 0 < len(s.stoppedBy)
+}
+
+// fail records the error of session b and returns the first error of this call, tagged with the index of its digest
+func (p *BatchLocalParty) fail(b int, err *tss.Error, firstErr *tss.Error) *tss.Error {
+	s := p.sessions[b]
+	if s.err != nil {
+		return firstErr
+	}
+	s.err = tss.NewError(fmt.Errorf("digest %d: %v", b, err.Cause()), err.Task(), err.Round(), err.Victim(), err.Culprits()...)
+	s.pending = nil
+	common.Logger.Warnf("party %s: %s", p.PartyID(), s.err)
+	if firstErr == nil {
+		return s.err
+	}
+	return firstErr
+}
+
+// flush collects the output of the sessions, sends the bundles of messages that every unfinished session has produced,
+// and sends the signatures once every session is done
+func (p *BatchLocalParty) flush(firstErr *tss.Error) *tss.Error {
+	for _, s := range p.sessions {
+	drain:
+		for {
+			select {
+			case msg := <-s.out:
+				if s.err == nil {
+					s.pending = append(s.pending, msg)
+				}
+			case data := <-s.end:
+				if s.err == nil {
+					s.data = data
+				}
+			default:
+				break drain
+			}
+		}
+	}
+	// a session that others have stopped cannot finish. the other sessions are done only after every message of the round
+	// in which it was stopped has arrived, so by then it would have found any culprit itself
+	if p.onlyStoppedLeft() {
+		for b, s := range p.sessions {
+			if !s.done() {
+				err := s.party.WrapError(fmt.Errorf("parties %s stopped signing this digest", s.stoppedBy))
+				firstErr = p.fail(b, err, firstErr)
+			}
+		}
+	}
+	for {
+		head := p.nextHead()
+		if head == nil {
+			break
+		}
+		messages := make([][]byte, len(p.sessions))
+		for b, s := range p.sessions {
+			if len(s.pending) == 0 {
+				continue // this session is done or stopped
+			}
+			msg := s.pending[0]
+			if !sameRoute(head, msg) {
+				if s.stopped() {
+					continue // keep it for the parties that have not stopped this session yet
+				}
+				err := s.party.WrapError(fmt.Errorf("session is out of lockstep: sending %s while the batch sends %s", msg.Type(), head.Type()))
+				firstErr = p.fail(b, err, firstErr)
+				continue
+			}
+			s.pending = s.pending[1:]
+			bz, _, err := msg.WireBytes()
+			if err != nil {
+				firstErr = p.fail(b, s.party.WrapError(err), firstErr)
+				continue
+			}
+			messages[b] = bz
+		}
+		p.out <- NewSignBatchMessage(head.GetTo(), p.PartyID(), head.IsBroadcast(), messages)
+	}
+	if !p.ended && p.allDone() {
+		p.ended = true
+		result := make([]*SignatureData, len(p.sessions))
+		for b, s := range p.sessions {
+			result[b] = s.data
+		}
+		common.Logger.Infof("party %s: %s finished!", p.PartyID(), TaskNameBatch)
+		p.end <- result
+	}
+	return firstErr
+}
+
+// nextHead returns the next message to bundle once every session that is still running has one pending; nil otherwise.
+// A stopped session does not hold up the others.
+func (p *BatchLocalParty) nextHead() tss.Message {
+	var head tss.Message
+	for _, s := range p.sessions {
+		if s.stopped() {
+			continue
+		}
+		if len(s.pending) == 0 {
+			if s.done() {
+				continue
+			}
+			return nil
+		}
+		if head == nil {
+			head = s.pending[0]
+		}
+	}
+	return head
+}
+
+func (p *BatchLocalParty) onlyStoppedLeft() bool {
+	left := false
+	for _, s := range p.sessions {
+		if s.done() {
+			continue
+		}
+		if !s.stopped() {
+			return false
+		}
+		left = true
+	}
+	return left
+}
+
+func (p *BatchLocalParty) allDone() bool {
+	for _, s := range p.sessions {
+		if !s.done() || (s.err == nil && 0 < len(s.pending)) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameRoute(a, b tss.Message) bool {
+	if a.Type() != b.Type() || a.IsBroadcast() != b.IsBroadcast() || len(a.GetTo()) != len(b.GetTo()) {
+		return false
+	}
+	for i, to := range a.GetTo() {
+		if to.Index != b.GetTo()[i].Index {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// MaxDigestLen is the length of the longest digest accepted for signing, that of SHA-512
+const MaxDigestLen = 64
+
+// DigestToInt converts a message digest into the integer that is signed with the current curve, as in SEC1 4.1.3 step 5.
+// A digest longer than the curve order is truncated to its leftmost bits. The digest must be at least as long as the order
+// in bytes and no longer than MaxDigestLen; a shorter one is usually a hash whose leading zero bytes were lost.
+func DigestToInt(digest []byte) (*big.Int, error) {
+	N := tss.EC().Params().N
+	orderBits := N.BitLen()
+	orderBytes := (orderBits + 7) / 8
+	if len(digest) < orderBytes || MaxDigestLen < len(digest) {
+		return nil, fmt.Errorf("digest must be between %d and %d bytes, got %d", orderBytes, MaxDigestLen, len(digest))
+	}
+	if len(digest) > orderBytes {
+		digest = digest[:orderBytes]
+	}
+	e := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - orderBits; excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+	return e.Mod(e, N), nil
+}
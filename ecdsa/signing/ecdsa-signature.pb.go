@@ -97,6 +97,8 @@ type SignatureData_OneRoundData struct {
 	// Components for identifiable aborts during the final phase
 	BigRBarJ map[string]*common.ECPoint `protobuf:"bytes,5,rep,name=big_r_bar_j,json=bigRBarJ,proto3" json:"big_r_bar_j,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	BigSJ    map[string]*common.ECPoint `protobuf:"bytes,6,rep,name=big_s_j,json=bigSJ,proto3" json:"big_s_j,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Components for a Type 7 identified abort during the final phase, set only when sum(S_j) != y
+	AbortData *SignatureData_AbortData `protobuf:"bytes,7,opt,name=abort_data,json=abortData,proto3" json:"abort_data,omitempty"`
 }
 
 func (x *SignatureData_OneRoundData) Reset() {
@@ -173,41 +175,169 @@ func (x *SignatureData_OneRoundData) GetBigSJ() map[string]*common.ECPoint {
 	return nil
 }
 
+func (x *SignatureData_OneRoundData) GetAbortData() *SignatureData_AbortData {
+	if x != nil {
+		return x.AbortData
+	}
+	return nil
+}
+
+type SignatureData_AbortData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Revealed to the other signers in place of s_i
+	Abort *SignRound7Message_AbortData `protobuf:"bytes,1,opt,name=abort,proto3" json:"abort,omitempty"`
+	// The public values that the revealed data of each signer is checked against
+	PaillierNJ map[string][]byte          `protobuf:"bytes,2,rep,name=paillier_n_j,json=paillierNJ,proto3" json:"paillier_n_j,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CJ         map[string][]byte          `protobuf:"bytes,3,rep,name=c_j,json=cJ,proto3" json:"c_j,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	C2JI       map[string][]byte          `protobuf:"bytes,4,rep,name=c2_j_i,json=c2JI,proto3" json:"c2_j_i,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BigWJ      map[string]*common.ECPoint `protobuf:"bytes,5,rep,name=big_w_j,json=bigWJ,proto3" json:"big_w_j,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *SignatureData_AbortData) Reset() {
+	*x = SignatureData_AbortData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signature_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignatureData_AbortData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignatureData_AbortData) ProtoMessage() {}
+
+func (x *SignatureData_AbortData) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signature_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignatureData_AbortData.ProtoReflect.Descriptor instead.
+func (*SignatureData_AbortData) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signature_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *SignatureData_AbortData) GetAbort() *SignRound7Message_AbortData {
+	if x != nil {
+		return x.Abort
+	}
+	return nil
+}
+
+func (x *SignatureData_AbortData) GetPaillierNJ() map[string][]byte {
+	if x != nil {
+		return x.PaillierNJ
+	}
+	return nil
+}
+
+func (x *SignatureData_AbortData) GetCJ() map[string][]byte {
+	if x != nil {
+		return x.CJ
+	}
+	return nil
+}
+
+func (x *SignatureData_AbortData) GetC2JI() map[string][]byte {
+	if x != nil {
+		return x.C2JI
+	}
+	return nil
+}
+
+func (x *SignatureData_AbortData) GetBigWJ() map[string]*common.ECPoint {
+	if x != nil {
+		return x.BigWJ
+	}
+	return nil
+}
+
 var File_protob_ecdsa_signature_proto protoreflect.FileDescriptor
 
 var file_protob_ecdsa_signature_proto_rawDesc = []byte{
 	0x0a, 0x1c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x73,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0xfe, 0x03, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x45, 0x43, 0x53, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x12, 0x41, 0x0a, 0x0e, 0x6f, 0x6e, 0x65, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x4f, 0x6e, 0x65, 0x52, 0x6f, 0x75,
-	0x6e, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0c, 0x6f, 0x6e, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64,
-	0x44, 0x61, 0x74, 0x61, 0x1a, 0xfd, 0x02, 0x0a, 0x0c, 0x4f, 0x6e, 0x65, 0x52, 0x6f, 0x75, 0x6e,
-	0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x01, 0x74, 0x12, 0x0f, 0x0a, 0x03, 0x6b, 0x5f, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x02, 0x6b, 0x49, 0x12, 0x1a, 0x0a, 0x09, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x5f,
-	0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x72, 0x53, 0x69, 0x67, 0x6d, 0x61, 0x49,
-	0x12, 0x1d, 0x0a, 0x05, 0x62, 0x69, 0x67, 0x5f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x08, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x04, 0x62, 0x69, 0x67, 0x52, 0x12,
-	0x48, 0x0a, 0x0b, 0x62, 0x69, 0x67, 0x5f, 0x72, 0x5f, 0x62, 0x61, 0x72, 0x5f, 0x6a, 0x18, 0x05,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x44, 0x61, 0x74, 0x61, 0x2e, 0x4f, 0x6e, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x44, 0x61, 0x74,
-	0x61, 0x2e, 0x42, 0x69, 0x67, 0x52, 0x42, 0x61, 0x72, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x08, 0x62, 0x69, 0x67, 0x52, 0x42, 0x61, 0x72, 0x4a, 0x12, 0x3e, 0x0a, 0x07, 0x62, 0x69, 0x67,
-	0x5f, 0x73, 0x5f, 0x6a, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x53, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x4f, 0x6e, 0x65, 0x52, 0x6f,
-	0x75, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x42, 0x69, 0x67, 0x53, 0x4a, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x05, 0x62, 0x69, 0x67, 0x53, 0x4a, 0x1a, 0x45, 0x0a, 0x0d, 0x42, 0x69, 0x67,
-	0x52, 0x42, 0x61, 0x72, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x45, 0x43,
-	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x1a, 0x42, 0x0a, 0x0a, 0x42, 0x69, 0x67, 0x53, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x73, 0x69, 0x67,
+	0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x2f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xe2, 0x08, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x2a, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x45, 0x43, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x41, 0x0a,
+	0x0e, 0x6f, 0x6e, 0x65, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x4f, 0x6e, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x0c, 0x6f, 0x6e, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x61,
+	0x1a, 0xb6, 0x03, 0x0a, 0x0c, 0x4f, 0x6e, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x74, 0x12,
+	0x0f, 0x0a, 0x03, 0x6b, 0x5f, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x6b, 0x49,
+	0x12, 0x1a, 0x0a, 0x09, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x5f, 0x69, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x72, 0x53, 0x69, 0x67, 0x6d, 0x61, 0x49, 0x12, 0x1d, 0x0a, 0x05,
+	0x62, 0x69, 0x67, 0x5f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x45, 0x43,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x04, 0x62, 0x69, 0x67, 0x52, 0x12, 0x48, 0x0a, 0x0b, 0x62,
+	0x69, 0x67, 0x5f, 0x72, 0x5f, 0x62, 0x61, 0x72, 0x5f, 0x6a, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x29, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x2e, 0x4f, 0x6e, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x42, 0x69,
+	0x67, 0x52, 0x42, 0x61, 0x72, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x62, 0x69, 0x67,
+	0x52, 0x42, 0x61, 0x72, 0x4a, 0x12, 0x3e, 0x0a, 0x07, 0x62, 0x69, 0x67, 0x5f, 0x73, 0x5f, 0x6a,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x4f, 0x6e, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x2e, 0x42, 0x69, 0x67, 0x53, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05,
+	0x62, 0x69, 0x67, 0x53, 0x4a, 0x12, 0x37, 0x0a, 0x0a, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x09, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x45,
+	0x0a, 0x0d, 0x42, 0x69, 0x67, 0x52, 0x42, 0x61, 0x72, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x1e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x08, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x42, 0x0a, 0x0a, 0x42, 0x69, 0x67, 0x53, 0x4a, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xa8, 0x04, 0x0a, 0x09, 0x41, 0x62,
+	0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a, 0x05, 0x61, 0x62, 0x6f, 0x72, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x37, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x05, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x12, 0x4a, 0x0a, 0x0c, 0x70,
+	0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x6e, 0x5f, 0x6a, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x50, 0x61, 0x69, 0x6c,
+	0x6c, 0x69, 0x65, 0x72, 0x4e, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x61, 0x69,
+	0x6c, 0x6c, 0x69, 0x65, 0x72, 0x4e, 0x4a, 0x12, 0x31, 0x0a, 0x03, 0x63, 0x5f, 0x6a, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x43,
+	0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x02, 0x63, 0x4a, 0x12, 0x38, 0x0a, 0x06, 0x63, 0x32,
+	0x5f, 0x6a, 0x5f, 0x69, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74,
+	0x44, 0x61, 0x74, 0x61, 0x2e, 0x43, 0x32, 0x4a, 0x49, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04,
+	0x63, 0x32, 0x4a, 0x49, 0x12, 0x3b, 0x0a, 0x07, 0x62, 0x69, 0x67, 0x5f, 0x77, 0x5f, 0x6a, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x2e,
+	0x42, 0x69, 0x67, 0x57, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x62, 0x69, 0x67, 0x57,
+	0x4a, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x4e, 0x4a, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x35, 0x0a, 0x07, 0x43, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x37, 0x0a, 0x09, 0x43, 0x32, 0x4a, 0x49, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x42, 0x0a, 0x0a, 0x42, 0x69, 0x67, 0x57, 0x4a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
 	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
 	0x12, 0x1e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x08, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
@@ -229,28 +359,41 @@ func file_protob_ecdsa_signature_proto_rawDescGZIP() []byte {
 	return file_protob_ecdsa_signature_proto_rawDescData
 }
 
-var file_protob_ecdsa_signature_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_protob_ecdsa_signature_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_protob_ecdsa_signature_proto_goTypes = []interface{}{
-	(*SignatureData)(nil),              // 0: SignatureData
-	(*SignatureData_OneRoundData)(nil), // 1: SignatureData.OneRoundData
-	nil,                                // 2: SignatureData.OneRoundData.BigRBarJEntry
-	nil,                                // 3: SignatureData.OneRoundData.BigSJEntry
-	(*common.ECSignature)(nil),         // 4: ECSignature
-	(*common.ECPoint)(nil),             // 5: ECPoint
+	(*SignatureData)(nil),               // 0: SignatureData
+	(*SignatureData_OneRoundData)(nil),  // 1: SignatureData.OneRoundData
+	(*SignatureData_AbortData)(nil),     // 2: SignatureData.AbortData
+	nil,                                 // 3: SignatureData.OneRoundData.BigRBarJEntry
+	nil,                                 // 4: SignatureData.OneRoundData.BigSJEntry
+	nil,                                 // 5: SignatureData.AbortData.PaillierNJEntry
+	nil,                                 // 6: SignatureData.AbortData.CJEntry
+	nil,                                 // 7: SignatureData.AbortData.C2JIEntry
+	nil,                                 // 8: SignatureData.AbortData.BigWJEntry
+	(*common.ECSignature)(nil),          // 9: ECSignature
+	(*common.ECPoint)(nil),              // 10: ECPoint
+	(*SignRound7Message_AbortData)(nil), // 11: SignRound7Message.AbortData
 }
 var file_protob_ecdsa_signature_proto_depIdxs = []int32{
-	4, // 0: SignatureData.signature:type_name -> ECSignature
-	1, // 1: SignatureData.one_round_data:type_name -> SignatureData.OneRoundData
-	5, // 2: SignatureData.OneRoundData.big_r:type_name -> ECPoint
-	2, // 3: SignatureData.OneRoundData.big_r_bar_j:type_name -> SignatureData.OneRoundData.BigRBarJEntry
-	3, // 4: SignatureData.OneRoundData.big_s_j:type_name -> SignatureData.OneRoundData.BigSJEntry
-	5, // 5: SignatureData.OneRoundData.BigRBarJEntry.value:type_name -> ECPoint
-	5, // 6: SignatureData.OneRoundData.BigSJEntry.value:type_name -> ECPoint
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	9,  // 0: SignatureData.signature:type_name -> ECSignature
+	1,  // 1: SignatureData.one_round_data:type_name -> SignatureData.OneRoundData
+	10, // 2: SignatureData.OneRoundData.big_r:type_name -> ECPoint
+	3,  // 3: SignatureData.OneRoundData.big_r_bar_j:type_name -> SignatureData.OneRoundData.BigRBarJEntry
+	4,  // 4: SignatureData.OneRoundData.big_s_j:type_name -> SignatureData.OneRoundData.BigSJEntry
+	2,  // 5: SignatureData.OneRoundData.abort_data:type_name -> SignatureData.AbortData
+	11, // 6: SignatureData.AbortData.abort:type_name -> SignRound7Message.AbortData
+	5,  // 7: SignatureData.AbortData.paillier_n_j:type_name -> SignatureData.AbortData.PaillierNJEntry
+	6,  // 8: SignatureData.AbortData.c_j:type_name -> SignatureData.AbortData.CJEntry
+	7,  // 9: SignatureData.AbortData.c2_j_i:type_name -> SignatureData.AbortData.C2JIEntry
+	8,  // 10: SignatureData.AbortData.big_w_j:type_name -> SignatureData.AbortData.BigWJEntry
+	10, // 11: SignatureData.OneRoundData.BigRBarJEntry.value:type_name -> ECPoint
+	10, // 12: SignatureData.OneRoundData.BigSJEntry.value:type_name -> ECPoint
+	10, // 13: SignatureData.AbortData.BigWJEntry.value:type_name -> ECPoint
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_protob_ecdsa_signature_proto_init() }
@@ -258,6 +401,7 @@ func file_protob_ecdsa_signature_proto_init() {
 	if File_protob_ecdsa_signature_proto != nil {
 		return
 	}
+	file_protob_ecdsa_signing_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_protob_ecdsa_signature_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignatureData); i {
@@ -283,6 +427,18 @@ func file_protob_ecdsa_signature_proto_init() {
 				return nil
 			}
 		}
+		file_protob_ecdsa_signature_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignatureData_AbortData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -290,7 +446,7 @@ func file_protob_ecdsa_signature_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_ecdsa_signature_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
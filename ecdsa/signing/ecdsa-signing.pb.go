@@ -394,7 +394,6 @@ type SignRound6Message struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Content:
-	//
 	//	*SignRound6Message_Success
 	//	*SignRound6Message_Abort
 	Content isSignRound6Message_Content `protobuf_oneof:"content"`
@@ -476,7 +475,6 @@ type SignRound7Message struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Content:
-	//
 	//	*SignRound7Message_SI
 	//	*SignRound7Message_Abort
 	Content isSignRound7Message_Content `protobuf_oneof:"content"`
@@ -551,6 +549,55 @@ func (*SignRound7Message_SI) isSignRound7Message_Content() {}
 
 func (*SignRound7Message_Abort) isSignRound7Message_Content() {}
 
+// Carries one message of each session of a batch signing, in the order of the digests.
+// An empty entry means that the sender has stopped signing that digest.
+type SignBatchMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Messages [][]byte `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *SignBatchMessage) Reset() {
+	*x = SignBatchMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignBatchMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBatchMessage) ProtoMessage() {}
+
+func (x *SignBatchMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBatchMessage.ProtoReflect.Descriptor instead.
+func (*SignBatchMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SignBatchMessage) GetMessages() [][]byte {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
 type SignRound6Message_SuccessData struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -566,7 +613,7 @@ type SignRound6Message_SuccessData struct {
 func (x *SignRound6Message_SuccessData) Reset() {
 	*x = SignRound6Message_SuccessData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protob_ecdsa_signing_proto_msgTypes[8]
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -579,7 +626,7 @@ func (x *SignRound6Message_SuccessData) String() string {
 func (*SignRound6Message_SuccessData) ProtoMessage() {}
 
 func (x *SignRound6Message_SuccessData) ProtoReflect() protoreflect.Message {
-	mi := &file_protob_ecdsa_signing_proto_msgTypes[8]
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,7 +691,7 @@ type SignRound6Message_AbortData struct {
 func (x *SignRound6Message_AbortData) Reset() {
 	*x = SignRound6Message_AbortData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protob_ecdsa_signing_proto_msgTypes[9]
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -657,7 +704,7 @@ func (x *SignRound6Message_AbortData) String() string {
 func (*SignRound6Message_AbortData) ProtoMessage() {}
 
 func (x *SignRound6Message_AbortData) ProtoReflect() protoreflect.Message {
-	mi := &file_protob_ecdsa_signing_proto_msgTypes[9]
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -718,7 +765,7 @@ type SignRound7Message_AbortData struct {
 func (x *SignRound7Message_AbortData) Reset() {
 	*x = SignRound7Message_AbortData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -731,7 +778,7 @@ func (x *SignRound7Message_AbortData) String() string {
 func (*SignRound7Message_AbortData) ProtoMessage() {}
 
 func (x *SignRound7Message_AbortData) ProtoReflect() protoreflect.Message {
-	mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -888,7 +935,10 @@ var file_protob_ecdsa_signing_proto_rawDesc = []byte{
 	0x41, 0x32, 0x12, 0x22, 0x0a, 0x0d, 0x65, 0x63, 0x64, 0x64, 0x68, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
 	0x66, 0x5f, 0x7a, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x65, 0x63, 0x64, 0x64, 0x68,
 	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x5a, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x74, 0x22, 0x2e, 0x0a, 0x10, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
 	0x7a, 0x65, 0x74, 0x61, 0x2d, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x74, 0x73, 0x73, 0x2d, 0x6c,
 	0x69, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67,
 	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
@@ -906,7 +956,7 @@ func file_protob_ecdsa_signing_proto_rawDescGZIP() []byte {
 	return file_protob_ecdsa_signing_proto_rawDescData
 }
 
-var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_protob_ecdsa_signing_proto_goTypes = []interface{}{
 	(*SignRound1Message1)(nil),            // 0: SignRound1Message1
 	(*SignRound1Message2)(nil),            // 1: SignRound1Message2
@@ -916,23 +966,24 @@ var file_protob_ecdsa_signing_proto_goTypes = []interface{}{
 	(*SignRound5Message)(nil),             // 5: SignRound5Message
 	(*SignRound6Message)(nil),             // 6: SignRound6Message
 	(*SignRound7Message)(nil),             // 7: SignRound7Message
-	(*SignRound6Message_SuccessData)(nil), // 8: SignRound6Message.SuccessData
-	(*SignRound6Message_AbortData)(nil),   // 9: SignRound6Message.AbortData
-	(*SignRound7Message_AbortData)(nil),   // 10: SignRound7Message.AbortData
-	(*common.ECPoint)(nil),                // 11: ECPoint
+	(*SignBatchMessage)(nil),              // 8: SignBatchMessage
+	(*SignRound6Message_SuccessData)(nil), // 9: SignRound6Message.SuccessData
+	(*SignRound6Message_AbortData)(nil),   // 10: SignRound6Message.AbortData
+	(*SignRound7Message_AbortData)(nil),   // 11: SignRound7Message.AbortData
+	(*common.ECPoint)(nil),                // 12: ECPoint
 }
 var file_protob_ecdsa_signing_proto_depIdxs = []int32{
-	11, // 0: SignRound3Message.t_i:type_name -> ECPoint
-	11, // 1: SignRound3Message.t_proof_alpha:type_name -> ECPoint
-	11, // 2: SignRound5Message.r_i:type_name -> ECPoint
-	8,  // 3: SignRound6Message.success:type_name -> SignRound6Message.SuccessData
-	9,  // 4: SignRound6Message.abort:type_name -> SignRound6Message.AbortData
-	10, // 5: SignRound7Message.abort:type_name -> SignRound7Message.AbortData
-	11, // 6: SignRound6Message.SuccessData.s_i:type_name -> ECPoint
-	11, // 7: SignRound6Message.SuccessData.st_proof_alpha:type_name -> ECPoint
-	11, // 8: SignRound6Message.SuccessData.st_proof_beta:type_name -> ECPoint
-	11, // 9: SignRound7Message.AbortData.ecddh_proof_a1:type_name -> ECPoint
-	11, // 10: SignRound7Message.AbortData.ecddh_proof_a2:type_name -> ECPoint
+	12, // 0: SignRound3Message.t_i:type_name -> ECPoint
+	12, // 1: SignRound3Message.t_proof_alpha:type_name -> ECPoint
+	12, // 2: SignRound5Message.r_i:type_name -> ECPoint
+	9,  // 3: SignRound6Message.success:type_name -> SignRound6Message.SuccessData
+	10, // 4: SignRound6Message.abort:type_name -> SignRound6Message.AbortData
+	11, // 5: SignRound7Message.abort:type_name -> SignRound7Message.AbortData
+	12, // 6: SignRound6Message.SuccessData.s_i:type_name -> ECPoint
+	12, // 7: SignRound6Message.SuccessData.st_proof_alpha:type_name -> ECPoint
+	12, // 8: SignRound6Message.SuccessData.st_proof_beta:type_name -> ECPoint
+	12, // 9: SignRound7Message.AbortData.ecddh_proof_a1:type_name -> ECPoint
+	12, // 10: SignRound7Message.AbortData.ecddh_proof_a2:type_name -> ECPoint
 	11, // [11:11] is the sub-list for method output_type
 	11, // [11:11] is the sub-list for method input_type
 	11, // [11:11] is the sub-list for extension type_name
@@ -1043,7 +1094,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			}
 		}
 		file_protob_ecdsa_signing_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignRound6Message_SuccessData); i {
+			switch v := v.(*SignBatchMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1055,7 +1106,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			}
 		}
 		file_protob_ecdsa_signing_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignRound6Message_AbortData); i {
+			switch v := v.(*SignRound6Message_SuccessData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1067,6 +1118,18 @@ func file_protob_ecdsa_signing_proto_init() {
 			}
 		}
 		file_protob_ecdsa_signing_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignRound6Message_AbortData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound7Message_AbortData); i {
 			case 0:
 				return &v.state
@@ -1093,7 +1156,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_ecdsa_signing_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
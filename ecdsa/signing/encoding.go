@@ -0,0 +1,193 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Encoders of the secp256k1 signatures returned in SignatureData.Signature for the chains we sign for.
+// The finalize step always returns a low-S signature with the recovery ID matching it, so they are used as is.
+// Each encoder takes the public key of the signature and refuses one of a key on another curve.
+
+const (
+	// ethereumLegacyV is added to the recovery ID for the v of a pre-EIP-155 Ethereum signature
+	ethereumLegacyV = 27
+	// eip155V is added to the recovery ID and twice the chain ID for the v of an EIP-155 signature
+	eip155V = 35
+)
+
+// EncodeEthereum returns the 64-byte r || s encoding of a signature and its v.
+// With a nil chainID v is 27 or 28; otherwise it is the EIP-155 v, chainID * 2 + 35 or 36, which does not fit in one
+// byte for most chain IDs and is therefore returned apart, as it is stored in a legacy transaction.
+// Typed transactions (EIP-2718) carry the recovery ID itself, see SignatureData.Signature.SignatureRecovery.
+func EncodeEthereum(sig *common.ECSignature, pub *crypto.ECPoint, chainID *big.Int) ([]byte, *big.Int, error) {
+	r, s, recID, err := signatureValues(sig, pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	if 1 < recID {
+		// R.X was above the order; this happens with negligible probability and Ethereum has no v for it
+		return nil, nil, fmt.Errorf("recovery ID %d cannot be encoded for Ethereum", recID)
+	}
+	v := big.NewInt(int64(recID + ethereumLegacyV))
+	if chainID != nil {
+		if chainID.Sign() <= 0 {
+			return nil, nil, errors.New("the chain ID must be positive")
+		}
+		v = new(big.Int).Lsh(chainID, 1)
+		v.Add(v, big.NewInt(int64(recID+eip155V)))
+	}
+	return append(paddedScalar(r), paddedScalar(s)...), v, nil
+}
+
+// VerifyEthereum recovers the public key from a 64-byte r || s signature of digest with its v and checks that it is pub.
+// chainID must be the one that was given to EncodeEthereum.
+func VerifyEthereum(sigBz []byte, v *big.Int, digest []byte, chainID *big.Int, pub *crypto.ECPoint) error {
+	if len(sigBz) != 64 {
+		return fmt.Errorf("an Ethereum signature must be 64 bytes, got %d", len(sigBz))
+	}
+	if v == nil {
+		return errors.New("v is missing")
+	}
+	recID := new(big.Int).Set(v)
+	if chainID != nil {
+		recID.Sub(recID, new(big.Int).Lsh(chainID, 1))
+		recID.Sub(recID, big.NewInt(eip155V))
+	} else {
+		recID.Sub(recID, big.NewInt(ethereumLegacyV))
+	}
+	if !recID.IsInt64() || recID.Int64() < 0 || recID.Int64() > 1 {
+		return errors.New("invalid v for this chain ID")
+	}
+	if err := checkLowS(new(big.Int).SetBytes(sigBz[32:])); err != nil {
+		return err
+	}
+	// btcec expects the recovery ID in the leading byte, offset as for an uncompressed key
+	compact := append([]byte{byte(recID.Int64() + ethereumLegacyV)}, sigBz...)
+	recovered, _, err := btcec.RecoverCompact(btcec.S256(), compact, digest)
+	if err != nil {
+		return err
+	}
+	if err = checkSecp256k1(pub); err != nil {
+		return err
+	}
+	if recovered.X.Cmp(pub.X()) != 0 || recovered.Y.Cmp(pub.Y()) != 0 {
+		return errors.New("the recovered public key does not match")
+	}
+	return nil
+}
+
+// EncodeBitcoinDER returns the strict DER encoding of a signature followed by the sighash type, as found in Bitcoin scripts.
+func EncodeBitcoinDER(sig *common.ECSignature, pub *crypto.ECPoint, sighash byte) ([]byte, error) {
+	r, s, _, err := signatureValues(sig, pub)
+	if err != nil {
+		return nil, err
+	}
+	der := (&btcec.Signature{R: r, S: s}).Serialize()
+	return append(der, sighash), nil
+}
+
+// VerifyBitcoinDER checks a DER signature with a trailing sighash type against digest and pub, and returns the sighash type.
+func VerifyBitcoinDER(sigBz, digest []byte, pub *crypto.ECPoint) (byte, error) {
+	if len(sigBz) < 2 {
+		return 0, errors.New("the signature is too short")
+	}
+	sighash := sigBz[len(sigBz)-1]
+	sig, err := btcec.ParseDERSignature(sigBz[:len(sigBz)-1], btcec.S256())
+	if err != nil {
+		return 0, err
+	}
+	if err = checkLowS(sig.S); err != nil {
+		return 0, err
+	}
+	if err = verifySecp256k1(sig.R, sig.S, digest, pub); err != nil {
+		return 0, err
+	}
+	return sighash, nil
+}
+
+// EncodeCosmos returns the 64-byte r || s encoding of a signature used by Cosmos SDK secp256k1 keys.
+func EncodeCosmos(sig *common.ECSignature, pub *crypto.ECPoint) ([]byte, error) {
+	r, s, _, err := signatureValues(sig, pub)
+	if err != nil {
+		return nil, err
+	}
+	return append(paddedScalar(r), paddedScalar(s)...), nil
+}
+
+// VerifyCosmos checks a 64-byte r || s signature against digest and pub, rejecting a high S as the Cosmos SDK does.
+func VerifyCosmos(sigBz, digest []byte, pub *crypto.ECPoint) error {
+	if len(sigBz) != 64 {
+		return fmt.Errorf("a Cosmos signature must be 64 bytes, got %d", len(sigBz))
+	}
+	r, s := new(big.Int).SetBytes(sigBz[:32]), new(big.Int).SetBytes(sigBz[32:])
+	if err := checkLowS(s); err != nil {
+		return err
+	}
+	return verifySecp256k1(r, s, digest, pub)
+}
+
+// ----- //
+
+func signatureValues(sig *common.ECSignature, pub *crypto.ECPoint) (r, s *big.Int, recID byte, err error) {
+	if err = checkSecp256k1(pub); err != nil {
+		return nil, nil, 0, err
+	}
+	if sig == nil || len(sig.GetR()) == 0 || len(sig.GetS()) == 0 || len(sig.GetSignatureRecovery()) != 1 {
+		return nil, nil, 0, errors.New("the signature is incomplete")
+	}
+	r, s, recID = new(big.Int).SetBytes(sig.GetR()), new(big.Int).SetBytes(sig.GetS()), sig.GetSignatureRecovery()[0]
+	if 3 < recID {
+		return nil, nil, 0, fmt.Errorf("invalid recovery ID %d", recID)
+	}
+	if err = checkLowS(s); err != nil {
+		return nil, nil, 0, err
+	}
+	return r, s, recID, nil
+}
+
+// checkLowS checks s against the order of secp256k1; the caller has checked the curve of the public key
+func checkLowS(s *big.Int) error {
+	N := btcec.S256().N
+	if s.Sign() <= 0 || s.Cmp(new(big.Int).Rsh(N, 1)) > 0 {
+		return errors.New("the signature S is not in the lower half of the order")
+	}
+	return nil
+}
+
+func checkSecp256k1(pub *crypto.ECPoint) error {
+	if pub == nil || !tss.SameCurve(pub.Curve(), btcec.S256()) {
+		return errors.New("the public key is not a secp256k1 key")
+	}
+	return nil
+}
+
+func verifySecp256k1(r, s *big.Int, digest []byte, pub *crypto.ECPoint) error {
+	if err := checkSecp256k1(pub); err != nil {
+		return err
+	}
+	pk := &ecdsa.PublicKey{Curve: btcec.S256(), X: pub.X(), Y: pub.Y()}
+	if !ecdsa.Verify(pk, digest, r, s) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
+
+func paddedScalar(x *big.Int) []byte {
+	bz := make([]byte, 32)
+	return x.FillBytes(bz)
+}
@@ -13,7 +13,6 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/btcsuite/btcd/btcec"
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/zeta-chain/tss-lib/common"
@@ -32,6 +31,7 @@ const (
 // -----
 
 // FinalizeGetOurSigShare is called in one-round signing mode after the online rounds have finished to compute s_i.
+// The state must not carry AbortData; such a presignature cannot sign, see OnlineLocalParty.
 func FinalizeGetOurSigShare(state *SignatureData, msg *big.Int) (sI *big.Int) {
 	data := state.GetOneRoundData()
 
@@ -39,12 +39,15 @@ func FinalizeGetOurSigShare(state *SignatureData, msg *big.Int) (sI *big.Int) {
 	modN := common.ModInt(N)
 
 	kI, rSigmaI := new(big.Int).SetBytes(data.GetKI()), new(big.Int).SetBytes(data.GetRSigmaI())
-	sI = modN.Add(modN.Mul(msg, kI), rSigmaI)
+	mKI := modN.Mul(msg, kI)
+	sI = modN.Add(mKI, rSigmaI)
+	common.WipeBigInts(kI, rSigmaI, mKI)
 	return
 }
 
 // FinalizeGetOurSigShare is called in one-round signing mode to build a final signature given others' s_i shares and a msg.
 // Note: each P in otherPs should correspond with that P's s_i at the same index in otherSIs.
+// The signature is made on the curve of tss.EC() and is also returned on its own; `pk` must be on the same curve.
 func FinalizeGetAndVerifyFinalSig(
 	state *SignatureData,
 	pk *ecdsa.PublicKey,
@@ -52,7 +55,7 @@ func FinalizeGetAndVerifyFinalSig(
 	ourP *tss.PartyID,
 	ourSI *big.Int,
 	otherSIs map[*tss.PartyID]*big.Int,
-) (*SignatureData, *btcec.Signature, *tss.Error) {
+) (*SignatureData, *common.ECSignature, *tss.Error) {
 	if len(otherSIs) == 0 {
 		return nil, nil, FinalizeWrapError(errors.New("len(otherSIs) == 0"), ourP)
 	}
@@ -60,6 +63,9 @@ func FinalizeGetAndVerifyFinalSig(
 	if data.GetT() != int32(len(otherSIs)) {
 		return nil, nil, FinalizeWrapError(errors.New("len(otherSIs) != T"), ourP)
 	}
+	if pk == nil || !tss.SameCurve(pk.Curve, tss.EC()) {
+		return nil, nil, FinalizeWrapError(errors.New("the public key is not on the curve in use"), ourP)
+	}
 
 	N := tss.EC().Params().N
 	modN := common.ModInt(N)
@@ -71,7 +77,7 @@ func FinalizeGetAndVerifyFinalSig(
 		return nil, nil, FinalizeWrapError(err, ourP)
 	}
 
-	r, s := bigR.X(), ourSI
+	r, s := new(big.Int).Mod(bigR.X(), N), ourSI
 	culprits := make([]*tss.PartyID, 0, len(otherSIs))
 	for Pj, sJ := range otherSIs {
 		bigRBarJBz := data.GetBigRBarJ()[Pj.Id]
@@ -113,9 +119,10 @@ func FinalizeGetAndVerifyFinalSig(
 
 	// Calculate Recovery ID: It is not possible to compute the public key out of the signature itself;
 	// the Recovery ID is used to enable extracting the public key from the signature.
-	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	// byte v = if(R.X >= curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	// This holds for any curve of prime order such as secp256k1 and P-256, with N the order of the curve in use.
 	recId := 0
-	if bigR.X().Cmp(N) > 0 {
+	if bigR.X().Cmp(N) >= 0 {
 		recId = 2
 	}
 	if bigR.Y().Bit(0) != 0 {
@@ -126,15 +133,17 @@ func FinalizeGetAndVerifyFinalSig(
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
 	// This is needed because of tendermint checks here:
 	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
-	secp256k1halfN := new(big.Int).Rsh(N, 1)
-	if s.Cmp(secp256k1halfN) > 0 {
+	// Negating s is the same as negating R, so the parity bit of the recovery ID flips with it.
+	// P-256 verifiers accept either s, so the low s is output for every curve and the signature is unique for a given R.
+	halfN := new(big.Int).Rsh(N, 1)
+	if s.Cmp(halfN) > 0 {
 		s.Sub(N, s)
 		recId ^= 1
 	}
 
 	ok := ecdsa.Verify(pk, msg.Bytes(), r, s)
 	if !ok {
-		return nil, nil, FinalizeWrapError(fmt.Errorf("signature verification 1 failed"), ourP)
+		return nil, nil, FinalizeWrapError(errors.New("signature verification failed"), ourP)
 	}
 
 	// save the signature for final output
@@ -145,15 +154,10 @@ func FinalizeGetAndVerifyFinalSig(
 	signature.M = msg.Bytes()
 	state.Signature = signature
 
-	btcecSig := &btcec.Signature{R: r, S: s}
-	if ok = btcecSig.Verify(msg.Bytes(), (*btcec.PublicKey)(pk)); !ok {
-		return nil, nil, FinalizeWrapError(fmt.Errorf("signature verification 2 failed"), ourP)
-	}
-
 	// SECURITY: to be safe the oneRoundData is no longer needed here and reuse of `r` can compromise the key
 	state.OneRoundData = nil
 
-	return state, btcecSig, nil
+	return state, signature, nil
 }
 
 func FinalizeWrapError(err error, victim *tss.PartyID, culprits ...*tss.PartyID) *tss.Error {
@@ -182,113 +186,20 @@ func (round *finalization) Start() *tss.Error {
 	// Identifiable Abort Type 7 triggered during Phase 6 (GG20)
 	if round.abortingT7 {
 		common.Logger.Infof("round 8: Abort Type 7 code path triggered")
-		q := tss.EC().Params().N
-		kIs := make([][]byte, len(Ps))
-		gMus := make([][]*crypto.ECPoint, len(Ps))
-		gNus := make([][]*crypto.ECPoint, len(Ps))
-		gSigmaIPfs := make([]*zkp.ECDDHProof, len(Ps))
-		for i := range gMus {
-			gMus[i] = make([]*crypto.ECPoint, len(Ps))
+		statement := &type7Statement{
+			paiPKs: make([]*paillier.PublicKey, len(Ps)),
+			cAs:    make([][]byte, len(Ps)),
+			c2JIs:  round.temp.c2JIs,
+			bigWs:  round.temp.bigWs,
+			bigR:   round.temp.rI,
+			bigSJ:  round.temp.BigSJ,
 		}
-		for j := range gNus {
-			gNus[j] = make([]*crypto.ECPoint, len(Ps))
+		for j, msg := range round.temp.signRound1Message1s {
+			statement.paiPKs[j] = round.key.PaillierPKs[j]
+			statement.cAs[j] = msg.Content().(*SignRound1Message1).GetC()
 		}
-	outer:
-		for j, msg := range round.temp.signRound7Messages {
-			Pj := round.Parties().IDs()[j]
-			var err error
-			var paiPKJ *paillier.PublicKey
-			if j == i {
-				paiPKJ = &round.key.PaillierSK.PublicKey
-			} else {
-				paiPKJ = round.key.PaillierPKs[j]
-			}
-
-			r7msgInner, ok := msg.Content().(*SignRound7Message).GetContent().(*SignRound7Message_Abort)
-			if !ok {
-				common.Logger.Warnf("round 8: unexpected success message while in aborting mode: %+v", r7msgInner)
-				culprits = append(culprits, Pj)
-				continue
-			}
-			r7msg := r7msgInner.Abort
-
-			// keep k_i and the g^sigma_i proof for later
-			kIs[j] = r7msg.GetKI()
-			if gSigmaIPfs[j], err = r7msg.UnmarshalSigmaIProof(); err != nil {
-				culprits = append(culprits, Pj)
-				continue
-			}
-
-			// content length sanity check
-			// note: the len equivalence of each of the slices in this msg have already been checked in ValidateBasic(), so just look at the UIJ slice here
-			if len(r7msg.GetMuIJ()) != len(Ps) {
-				culprits = append(culprits, Pj)
-				continue
-			}
-
-			// re-encrypt k_i to make sure it matches the one we have "on record"
-			cA, err := paiPKJ.EncryptWithChosenRandomness(
-				new(big.Int).SetBytes(r7msg.GetKI()),
-				new(big.Int).SetBytes(r7msg.GetKRandI()))
-			r1msg1 := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
-			if err != nil || !bytes.Equal(cA.Bytes(), r1msg1.GetC()) {
-				culprits = append(culprits, Pj)
-				continue
-			}
-
-			mus := common.ByteSlicesToBigInts(r7msg.GetMuIJ())
-			muRands := common.ByteSlicesToBigInts(r7msg.GetMuRandIJ())
-
-			// check correctness of mu_i_j
-			if muIJ, muRandIJ := mus[i], muRands[i]; j != i {
-				cB, err := paiPKJ.EncryptWithChosenRandomness(muIJ, muRandIJ)
-				if err != nil || !bytes.Equal(cB.Bytes(), round.temp.c2JIs[j].Bytes()) {
-					culprits = append(culprits, Pj)
-					continue outer
-				}
-			}
-			// compute g^mu_i_j
-			for k, mu := range mus {
-				if k == j {
-					continue
-				}
-				gMus[j][k] = crypto.ScalarBaseMult(tss.EC(), mu.Mod(mu, q))
-			}
-		}
-		bigR := round.temp.rI
-		if 0 < len(culprits) {
-			goto fail
-		}
-		// compute g^nu_j_i's
-		for i := range Ps {
-			for j := range Ps {
-				if j == i {
-					continue
-				}
-				gWJKI := round.temp.bigWs[j].ScalarMultBytes(kIs[i])
-				gNus[i][j], _ = gWJKI.Sub(gMus[i][j])
-			}
-		}
-		// compute g^sigma_i's
-		for i, P := range Ps {
-			gWIMulKi := round.temp.bigWs[i].ScalarMultBytes(kIs[i])
-			gSigmaI := gWIMulKi
-			for j := range Ps {
-				if j == i {
-					continue
-				}
-				// add sum g^mu_i_j, sum g^nu_j_i
-				gMuIJ, gNuJI := gMus[i][j], gNus[j][i]
-				gSigmaI, _ = gSigmaI.Add(gMuIJ)
-				gSigmaI, _ = gSigmaI.Add(gNuJI)
-			}
-			bigSI, _ := crypto.NewECPointFromProtobuf(round.temp.BigSJ[P.Id])
-			if !gSigmaIPfs[i].VerifySigmaI(tss.EC(), gSigmaI, bigR, bigSI) {
-				culprits = append(culprits, P)
-				continue
-			}
-		}
-	fail:
+		statement.paiPKs[i] = &round.key.PaillierSK.PublicKey
+		culprits = identifyType7Abort(Ps, i, round.temp.signRound7Messages, statement)
 		return round.WrapError(errors.New("round 7 consistency check failed: y != bigSJ products, Type 7 identified abort, culprits known"), culprits...)
 	}
 
@@ -322,6 +233,14 @@ func (round *finalization) Start() *tss.Error {
 	if err != nil {
 		return err
 	}
+	if round.temp.digest != nil {
+		// the signature is over the digest itself, which keeps its leading zeros unlike m
+		r, s := new(big.Int).SetBytes(data.Signature.R), new(big.Int).SetBytes(data.Signature.S)
+		if !ecdsa.Verify(pk, round.temp.digest, r, s) {
+			return round.WrapError(errors.New("signature verification of the digest failed"))
+		}
+		data.Signature.M = round.temp.digest
+	}
 	round.data = data
 	round.end <- round.data
 	return nil
@@ -340,3 +259,119 @@ func (round *finalization) Update() (bool, *tss.Error) {
 func (round *finalization) NextRound() tss.Round {
 	return nil // finished!
 }
+
+// type7Statement holds the public values of a signing session that the data revealed in a Type 7 identified abort is checked against.
+// The slices are indexed by the signers; c2JIs holds the MtAwc ciphertext that this party sent to each P_j in round 2.
+type type7Statement struct {
+	paiPKs []*paillier.PublicKey
+	cAs    [][]byte
+	c2JIs  []*big.Int
+	bigWs  []*crypto.ECPoint
+	bigR   *crypto.ECPoint
+	bigSJ  map[string]*common.ECPoint
+}
+
+// identifyType7Abort checks the abort data that each signer revealed in r7msgs against the statement and returns the culprits
+func identifyType7Abort(Ps []*tss.PartyID, i int, r7msgs []tss.ParsedMessage, statement *type7Statement) []*tss.PartyID {
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	q := tss.EC().Params().N
+	kIs := make([][]byte, len(Ps))
+	gMus := make([][]*crypto.ECPoint, len(Ps))
+	gNus := make([][]*crypto.ECPoint, len(Ps))
+	gSigmaIPfs := make([]*zkp.ECDDHProof, len(Ps))
+	for i := range gMus {
+		gMus[i] = make([]*crypto.ECPoint, len(Ps))
+	}
+	for j := range gNus {
+		gNus[j] = make([]*crypto.ECPoint, len(Ps))
+	}
+outer:
+	for j, msg := range r7msgs {
+		Pj := Ps[j]
+		var err error
+		paiPKJ := statement.paiPKs[j]
+
+		r7msgInner, ok := msg.Content().(*SignRound7Message).GetContent().(*SignRound7Message_Abort)
+		if !ok {
+			common.Logger.Warnf("round 8: unexpected success message while in aborting mode: %+v", r7msgInner)
+			culprits = append(culprits, Pj)
+			continue
+		}
+		r7msg := r7msgInner.Abort
+
+		// keep k_i and the g^sigma_i proof for later
+		kIs[j] = r7msg.GetKI()
+		if gSigmaIPfs[j], err = r7msg.UnmarshalSigmaIProof(); err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+
+		// content length sanity check
+		// note: the len equivalence of each of the slices in this msg have already been checked in ValidateBasic(), so just look at the UIJ slice here
+		if len(r7msg.GetMuIJ()) != len(Ps) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+
+		// re-encrypt k_i to make sure it matches the one we have "on record"
+		cA, err := paiPKJ.EncryptWithChosenRandomness(
+			new(big.Int).SetBytes(r7msg.GetKI()),
+			new(big.Int).SetBytes(r7msg.GetKRandI()))
+		if err != nil || !bytes.Equal(cA.Bytes(), statement.cAs[j]) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+
+		mus := common.ByteSlicesToBigInts(r7msg.GetMuIJ())
+		muRands := common.ByteSlicesToBigInts(r7msg.GetMuRandIJ())
+
+		// check correctness of mu_i_j
+		if muIJ, muRandIJ := mus[i], muRands[i]; j != i {
+			cB, err := paiPKJ.EncryptWithChosenRandomness(muIJ, muRandIJ)
+			if err != nil || !bytes.Equal(cB.Bytes(), statement.c2JIs[j].Bytes()) {
+				culprits = append(culprits, Pj)
+				continue outer
+			}
+		}
+		// compute g^mu_i_j
+		for k, mu := range mus {
+			if k == j {
+				continue
+			}
+			gMus[j][k] = crypto.ScalarBaseMult(tss.EC(), mu.Mod(mu, q))
+		}
+	}
+	if 0 < len(culprits) {
+		return culprits
+	}
+	// compute g^nu_j_i's
+	for i := range Ps {
+		for j := range Ps {
+			if j == i {
+				continue
+			}
+			gWJKI := statement.bigWs[j].ScalarMultBytes(kIs[i])
+			gNus[i][j], _ = gWJKI.Sub(gMus[i][j])
+		}
+	}
+	// compute g^sigma_i's
+	for i, P := range Ps {
+		gWIMulKi := statement.bigWs[i].ScalarMultBytes(kIs[i])
+		gSigmaI := gWIMulKi
+		for j := range Ps {
+			if j == i {
+				continue
+			}
+			// add sum g^mu_i_j, sum g^nu_j_i
+			gMuIJ, gNuJI := gMus[i][j], gNus[j][i]
+			gSigmaI, _ = gSigmaI.Add(gMuIJ)
+			gSigmaI, _ = gSigmaI.Add(gNuJI)
+		}
+		bigSI, _ := crypto.NewECPointFromProtobuf(statement.bigSJ[P.Id])
+		if !gSigmaIPfs[i].VerifySigmaI(tss.EC(), gSigmaI, statement.bigR, bigSI) {
+			culprits = append(culprits, P)
+			continue
+		}
+	}
+	return culprits
+}
@@ -29,9 +29,11 @@ type (
 		*tss.BaseParty
 		params *tss.Parameters
 
-		keys keygen.LocalPartySaveData
-		temp localTempData
-		data SignatureData
+		keys      keygen.LocalPartySaveData
+		keyErr    error // set when the key data failed to validate
+		digestErr error // set when the digest given to NewLocalPartyWithDigest is invalid
+		temp      localTempData
+		data      SignatureData
 
 		// outbound messaging
 		out chan<- tss.Message
@@ -53,6 +55,7 @@ type (
 		localMessageStore
 
 		// temp data (thrown away after sign) / round 1
+		digest []byte // the original message digest, when signing with NewLocalPartyWithDigest
 		m,
 		wI,
 		cAKI,
@@ -103,12 +106,15 @@ func NewLocalParty(
 	p := &LocalParty{
 		BaseParty: new(tss.BaseParty),
 		params:    params,
-		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		keys:      key,
 		temp:      localTempData{},
 		data:      SignatureData{},
 		out:       out,
 		end:       end,
 	}
+	if p.keyErr = key.ValidateThreshold(params.Threshold()); p.keyErr == nil {
+		p.keys = keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	}
 	// msgs init
 	p.temp.signRound1Message1s = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound1Message2s = make([]tss.ParsedMessage, partyCount)
@@ -134,6 +140,22 @@ func NewLocalParty(
 	return p
 }
 
+// Constructs a new ECDSA signing party for a message digest, which is kept byte for byte in the final SignatureData.
+// See DigestToInt for the accepted lengths and how a digest longer than the curve order is truncated.
+func NewLocalPartyWithDigest(
+	digest []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SignatureData,
+) tss.Party {
+	m, err := DigestToInt(digest)
+	p := NewLocalParty(m, params, key, out, end).(*LocalParty)
+	p.digestErr = err
+	p.temp.digest = append([]byte{}, digest...)
+	return p
+}
+
 // Constructs a new ECDSA signing party for one-round signing. The final SignatureData struct will be a partial struct containing only the data for a final signing round (see the readme).
 func NewLocalPartyWithOneRoundSign(
 	params *tss.Parameters,
@@ -154,6 +176,12 @@ func (p *LocalParty) Start() *tss.Error {
 		if !ok {
 			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
 		}
+		if p.keyErr != nil {
+			return round.WrapError(fmt.Errorf("invalid key data: %v", p.keyErr))
+		}
+		if p.digestErr != nil {
+			return round.WrapError(p.digestErr)
+		}
 		if err := round1.prepare(); err != nil {
 			return round.WrapError(err)
 		}
@@ -165,6 +193,12 @@ func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
 
+// Close stops the party and overwrites w_i, k_i, gamma_i, sigma_i, l_i, the MtA shares and the abort data.
+// The one-round data is left as is when it has been sent through `end` in one-round signing mode.
+func (p *LocalParty) Close() {
+	tss.BaseClose(p, p.wipe)
+}
+
 func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
 	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
 	if err != nil {
@@ -225,3 +259,29 @@ func (p *LocalParty) PartyID() *tss.PartyID {
 func (p *LocalParty) String() string {
 	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
 }
+
+// wipe overwrites the secrets in the temp data
+func (p *LocalParty) wipe() {
+	temp := &p.temp
+	common.WipeBigInts(temp.wI, temp.rAKI, temp.gammaI, temp.deltaI, temp.sigmaI, temp.lI, temp.sI)
+	common.WipeBigInts(temp.betas...)
+	common.WipeBigInts(temp.vJIs...)
+	wipeR5AbortData(&temp.r5AbortData)
+	wipeR7AbortData(&temp.r7AbortData)
+	if p.data.OneRoundData != &temp.SignatureData_OneRoundData {
+		common.WipeByteSlices(temp.KI, temp.RSigmaI)
+		wipeR7AbortData(temp.GetAbortData().GetAbort())
+	}
+}
+
+func wipeR5AbortData(data *SignRound6Message_AbortData) {
+	common.WipeByteSlices(data.GetKI(), data.GetGammaI())
+	common.WipeByteSlices(data.GetAlphaIJ()...)
+	common.WipeByteSlices(data.GetBetaJI()...)
+}
+
+func wipeR7AbortData(data *SignRound7Message_AbortData) {
+	common.WipeByteSlices(data.GetKI(), data.GetKRandI())
+	common.WipeByteSlices(data.GetMuIJ()...)
+	common.WipeByteSlices(data.GetMuRandIJ()...)
+}
@@ -8,18 +8,30 @@ package signing
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"runtime"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/mta"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/crypto/zkp"
 	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
 	"github.com/zeta-chain/tss-lib/test"
 	"github.com/zeta-chain/tss-lib/tss"
@@ -61,6 +73,10 @@ func TestE2EConcurrent(t *testing.T) {
 	msg := common.GetRandomPrimeInt(256)
 	for i := 0; i < len(signPIDs); i++ {
 		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		if i == 0 {
+			// one party computes and verifies its MtA proofs one at a time
+			params.SetConcurrency(1)
+		}
 
 		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
 		parties = append(parties, P)
@@ -141,3 +157,1385 @@ signing:
 		}
 	}
 }
+
+func TestStartRejectsInvalidKey(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// corrupt the secret share of the first signer
+	key := keys[0]
+	key.Xi = new(big.Int).Add(key.Xi, big.NewInt(1))
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := tss.NewParameters(p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	P := NewLocalParty(big.NewInt(42), params, key, outCh, endCh)
+	err2 := P.Start()
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "invalid key data")
+	}
+	assert.Equal(t, 0, len(outCh), "no messages should be sent")
+}
+
+func TestStartRejectsKeyOfAnotherThreshold(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(3, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// re-share a new secret with threshold 1 to the fixture share ids, as a keygen with t=1 would have done
+	key := keys[0]
+	secret := common.GetRandomPositiveInt(tss.EC().Params().N)
+	_, shares, err := vss.Create(1, secret, key.Ks)
+	if !assert.NoError(t, err) {
+		return
+	}
+	key.BigXj = make([]*crypto.ECPoint, len(shares))
+	for j, share := range shares {
+		key.BigXj[j] = crypto.ScalarBaseMult(tss.EC(), share.Share)
+		if share.ID.Cmp(key.ShareID) == 0 {
+			key.Xi = share.Share
+		}
+	}
+	key.ECDSAPub = crypto.ScalarBaseMult(tss.EC(), secret)
+	assert.NoError(t, key.ValidateThreshold(1), "the key is valid for t=1")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := tss.NewParameters(p2pCtx, signPIDs[0], len(signPIDs), 2)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	P := NewLocalParty(big.NewInt(42), params, key, outCh, endCh)
+	err2 := P.Start()
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "generated with threshold 1, not 2")
+	}
+	assert.Equal(t, 0, len(outCh), "no messages should be sent")
+}
+
+func TestSelectQuorum(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	key := keys[0]
+
+	// the same live set in any order, with duplicates and unknown peers, gives the same quorum
+	stranger := tss.NewPartyID("stranger", "stranger", big.NewInt(7))
+	live := []*tss.PartyID{pIDs[5], pIDs[1], stranger, pIDs[4], pIDs[1], pIDs[2]}
+	reversed := []*tss.PartyID{pIDs[2], pIDs[4], pIDs[1], stranger, pIDs[5]}
+	quorum, err := SelectQuorum(key, pIDs, live, testThreshold)
+	if !assert.NoError(t, err) {
+		return
+	}
+	quorum2, err := SelectQuorum(key, pIDs, reversed, testThreshold)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, quorum, testThreshold+1)
+	assert.Equal(t, quorum.Keys(), quorum2.Keys(), "ensure the quorum does not depend on the order of the live parties")
+	for j, Pj := range quorum {
+		assert.Equal(t, j, Pj.Index, "ensure the quorum is indexed")
+		assert.NotNil(t, pIDs.FindByKey(Pj.KeyInt()), "ensure only parties of the keygen are chosen")
+	}
+	for j, Pj := range pIDs {
+		assert.Equal(t, j, Pj.Index, "ensure the keygen party ids are not modified")
+	}
+	assert.NotPanics(t, func() { keygen.BuildLocalSaveDataSubset(key, quorum) })
+
+	// a score takes precedence over the key order
+	score := func(pID *tss.PartyID) int64 {
+		if pID.Id == pIDs[5].Id {
+			return 1
+		}
+		return 0
+	}
+	quorum, err = SelectQuorum(key, pIDs, pIDs, testThreshold, score)
+	if assert.NoError(t, err) {
+		assert.NotNil(t, quorum.FindByKey(pIDs[5].KeyInt()), "ensure the preferred party is chosen")
+		assert.Nil(t, quorum.FindByKey(pIDs[4].KeyInt()), "ensure the quorum is not larger than needed")
+	}
+
+	// too few live parties is an error rather than a panic
+	_, err = SelectQuorum(key, pIDs, []*tss.PartyID{pIDs[0], pIDs[3], stranger}, testThreshold)
+	assert.Error(t, err)
+	_, err = SelectQuorum(key, pIDs[:testParticipants-1], pIDs, testThreshold)
+	assert.Error(t, err, "ensure a party set that does not match the key is refused")
+}
+
+func TestSelectQuorumWeighted(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	// P0 holds 1 share, P1 holds 3 and P2 holds 2
+	key := keygen.LocalPartySaveData{
+		Ks:      []*big.Int{pIDs[0].KeyInt(), pIDs[1].KeyInt(), pIDs[2].KeyInt()},
+		ExtraKs: [][]*big.Int{{}, {big.NewInt(101), big.NewInt(102)}, {big.NewInt(201)}},
+	}
+	preferP0 := func(pID *tss.PartyID) int64 {
+		if pID.Id == pIDs[0].Id {
+			return 1
+		}
+		return 0
+	}
+
+	// the party with the most shares is enough alone, even when another one has a higher score
+	quorum, err := SelectQuorum(key, pIDs, []*tss.PartyID{pIDs[0], pIDs[1]}, 2, preferP0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*big.Int{pIDs[1].KeyInt()}, quorum.Keys())
+	}
+	// 4 shares are held by P1 and P2 rather than by P0 and P1, although P0 has the higher score
+	quorum, err = SelectQuorum(key, pIDs, pIDs, 3, preferP0)
+	if assert.NoError(t, err) {
+		assert.ElementsMatch(t, []*big.Int{pIDs[1].KeyInt(), pIDs[2].KeyInt()}, quorum.Keys())
+	}
+	// the score only orders parties with as many shares
+	quorum, err = SelectQuorum(key, pIDs, []*tss.PartyID{pIDs[0], pIDs[2]}, 2, preferP0)
+	if assert.NoError(t, err) {
+		assert.ElementsMatch(t, []*big.Int{pIDs[0].KeyInt(), pIDs[2].KeyInt()}, quorum.Keys())
+	}
+	_, err = SelectQuorum(key, pIDs, []*tss.PartyID{pIDs[0], pIDs[2]}, 3)
+	assert.Error(t, err, "ensure 3 shares are not enough for threshold 3")
+}
+
+func TestRetryCoordinator(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// every attempt is aborted by its first signer
+	coordinator := NewRetryCoordinator(keys[0], pIDs, testThreshold)
+	_, err = coordinator.Run(pIDs, func(signers tss.SortedPartyIDs) (*SignatureData, *tss.Error) {
+		return nil, tss.NewError(errors.New("abort"), TaskName, 7, signers[1], signers[0])
+	})
+	assert.Error(t, err, "ensure the retries stop when too few parties are left")
+	attempts := coordinator.Attempts()
+	if assert.Len(t, attempts, testParticipants-testThreshold) {
+		for n, attempt := range attempts {
+			assert.Len(t, attempt.Signers, testThreshold+1)
+			assert.NotNil(t, attempt.Err)
+			if assert.Len(t, attempt.Culprits, 1) {
+				assert.Equal(t, pIDs[n].Id, attempt.Culprits[0].Id, "ensure the first signer is excluded")
+			}
+			for _, culprit := range coordinator.Excluded()[:n] {
+				assert.Nil(t, attempt.Signers.FindByKey(culprit.KeyInt()), "ensure a blamed party does not sign again")
+			}
+		}
+	}
+	assert.Len(t, coordinator.Excluded(), testParticipants-testThreshold)
+
+	// an abort that blames nobody is not retried
+	coordinator = NewRetryCoordinator(keys[0], pIDs, testThreshold)
+	_, err = coordinator.Run(pIDs, func(signers tss.SortedPartyIDs) (*SignatureData, *tss.Error) {
+		return nil, tss.NewError(errors.New("abort"), TaskName, 5, signers[0])
+	})
+	assert.Error(t, err)
+	assert.Len(t, coordinator.Attempts(), 1)
+
+	// party keys with leading zero bytes are matched to the key's Ks, so blamed parties stop counting toward the shares left
+	zeroPIDs := tss.GenerateTestPartyIDs(3)
+	for _, Pj := range zeroPIDs {
+		Pj.Key = append([]byte{0, 0}, Pj.Key...)
+	}
+	zeroKey := keygen.LocalPartySaveData{Ks: []*big.Int{zeroPIDs[0].KeyInt(), zeroPIDs[1].KeyInt(), zeroPIDs[2].KeyInt()}}
+	coordinator = NewRetryCoordinator(zeroKey, zeroPIDs, 1)
+	for n := 1; n <= 2; n++ {
+		signers, err := coordinator.NextQuorum(zeroPIDs)
+		if !assert.NoError(t, err) {
+			return
+		}
+		err = coordinator.Abort(tss.NewError(errors.New("abort"), TaskName, 7, signers[1], signers[0]))
+		if n == 1 {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err, "ensure the abort that leaves too few shares stops the retries")
+		}
+	}
+	assert.Len(t, coordinator.Excluded(), 2)
+}
+
+func TestE2EConcurrentRetryWithoutCulprit(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	msg := big.NewInt(42)
+
+	// in the first attempt the first signer broadcasts a round 1 commitment that it cannot open
+	coordinator := NewRetryCoordinator(keys[0], pIDs, testThreshold)
+	data, err := coordinator.Run(pIDs, func(signers tss.SortedPartyIDs) (*SignatureData, *tss.Error) {
+		first := len(coordinator.Attempts()) == 1
+		return runSigning(t, keys, pIDs, signers, msg, func(msg tss.Message) tss.Message {
+			if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound1Message2); !ok || !first || msg.GetFrom().Index != 0 {
+				return msg
+			}
+			return NewSignRound1Message2(msg.GetFrom(), big.NewInt(1))
+		})
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	attempts := coordinator.Attempts()
+	if assert.Len(t, attempts, 2) && assert.Len(t, attempts[0].Culprits, 1) {
+		assert.Equal(t, attempts[0].Signers[0].Id, attempts[0].Culprits[0].Id, "ensure the first signer is blamed")
+		assert.Nil(t, attempts[1].Signers.FindByKey(attempts[0].Culprits[0].KeyInt()), "ensure it does not sign again")
+	}
+	pk := keys[0].ECDSAPub.ToECDSAPubKey()
+	r, s := new(big.Int).SetBytes(data.Signature.R), new(big.Int).SetBytes(data.Signature.S)
+	assert.True(t, ecdsa.Verify(pk, msg.Bytes(), r, s), "ecdsa verify must pass")
+}
+
+func TestE2EConcurrentMtACulpritsInPartyOrder(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	signPIDs := pIDs[:testThreshold+1]
+
+	// the signers 2 and 1 send the first signer a ciphertext that does not match their range proof
+	_, tssErr := runSigning(t, keys, pIDs, signPIDs, big.NewInt(42), func(msg tss.Message) tss.Message {
+		r1msg, ok := msg.(tss.ParsedMessage).Content().(*SignRound1Message1)
+		if !ok || msg.GetFrom().Index == 0 || 2 < msg.GetFrom().Index || msg.GetTo()[0].Index != 0 {
+			return msg
+		}
+		proof, err := r1msg.UnmarshalRangeProofAlice()
+		assert.NoError(t, err)
+		return NewSignRound1Message1(msg.GetTo()[0], msg.GetFrom(), new(big.Int).Add(r1msg.UnmarshalC(), big.NewInt(1)), proof)
+	})
+	if assert.NotNil(t, tssErr) && assert.Len(t, tssErr.Culprits(), 2) {
+		assert.Equal(t, 2, tssErr.Round())
+		assert.Equal(t, signPIDs[1].Id, tssErr.Culprits()[0].Id, "ensure the culprits are in party order")
+		assert.Equal(t, signPIDs[2].Id, tssErr.Culprits()[1].Id, "ensure the culprits are in party order")
+	}
+}
+
+func TestRunTasks(t *testing.T) {
+	for _, workers := range []int{1, 3, 64} {
+		var running, maxRunning int32
+		done := make([]int32, 50)
+		runTasks(workers, len(done), func(k int) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&done[k], 1)
+			atomic.AddInt32(&running, -1)
+		})
+		for k := range done {
+			assert.Equal(t, int32(1), done[k], "ensure task %d ran once", k)
+		}
+		assert.LessOrEqual(t, int(maxRunning), workers, "ensure at most %d tasks ran at once", workers)
+	}
+	runTasks(4, 0, func(int) { t.Fatal("no task should run") })
+}
+
+func TestCollectCulprits(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	round := &base{Parameters: tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), 1), number: 2}
+	errs := []*tss.Error{
+		nil, nil,
+		round.WrapError(errors.New("Bob_mid"), pIDs[2]), nil,
+		round.WrapError(errors.New("Bob_mid"), pIDs[1]), round.WrapError(errors.New("Bob_mid_wc"), pIDs[1]),
+	}
+	culprits := collectCulprits(errs)
+	if assert.Len(t, culprits, 2, "ensure each culprit is listed once") {
+		assert.Equal(t, pIDs[2], culprits[0])
+		assert.Equal(t, pIDs[1], culprits[1])
+	}
+	assert.Empty(t, collectCulprits(make([]*tss.Error, 4)))
+}
+
+// runSigning signs with the given signers and returns a signature or the first error; tamper, if not nil, may rewrite the messages that are sent
+func runSigning(
+	t *testing.T,
+	keys []keygen.LocalPartySaveData,
+	pIDs, signPIDs tss.SortedPartyIDs,
+	msg *big.Int,
+	tamper func(tss.Message) tss.Message,
+) (*SignatureData, *tss.Error) {
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	parties := newTestParties(signPIDs, func(i int, params *tss.Parameters) tss.Party {
+		key := keys[pIDs.FindByKey(signPIDs[i].KeyInt()).Index]
+		return NewLocalParty(msg, params, key, outCh, endCh)
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, tamper, stop)
+
+	var data *SignatureData
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			// the other parties are left waiting for messages that will not come
+			common.Logger.Warnf("Error: %s", err)
+			return nil, err
+
+		case data = <-endCh:
+			ended++
+		}
+	}
+	return data, nil
+}
+
+func TestDigestToInt(t *testing.T) {
+	N := tss.EC().Params().N
+
+	// leading zero bytes are kept in the digest and do not change the integer
+	digest := make([]byte, 32)
+	digest[31] = 42
+	m, err := DigestToInt(digest)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), m)
+
+	// a SHA-512 digest is truncated to its leftmost 256 bits
+	long := make([]byte, 64)
+	for i := range long {
+		long[i] = byte(i + 1)
+	}
+	m, err = DigestToInt(long)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).SetBytes(long[:32]), m)
+
+	// a digest above the order is reduced
+	m, err = DigestToInt(new(big.Int).Add(N, big.NewInt(7)).Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(7), m)
+
+	for _, bad := range [][]byte{nil, make([]byte, 31), make([]byte, 65)} {
+		_, err = DigestToInt(bad)
+		assert.Error(t, err, "a digest of %d bytes should be rejected", len(bad))
+	}
+}
+
+func TestStartRejectsInvalidDigest(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := tss.NewParameters(p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	// a 32-byte hash that lost its leading zero byte
+	P := NewLocalPartyWithDigest(make([]byte, 31), params, keys[0], outCh, endCh)
+	err2 := P.Start()
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "digest must be between")
+	}
+	assert.Equal(t, 0, len(outCh), "no messages should be sent")
+}
+
+func TestE2EConcurrentWithDigest(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	// a digest with leading zero bytes, which a *big.Int would drop
+	digest := make([]byte, 32)
+	copy(digest[2:], common.GetRandomPositiveInt(new(big.Int).Lsh(big.NewInt(1), 240)).Bytes())
+	digest[2] |= 0x80
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		parties = append(parties, NewLocalPartyWithDigest(digest, params, keys[i], outCh, endCh))
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, nil, stop)
+
+	pk := &ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case data := <-endCh:
+			ended++
+			assert.Equal(t, digest, data.Signature.M, "the digest should be returned byte for byte")
+			r, s := new(big.Int).SetBytes(data.Signature.R), new(big.Int).SetBytes(data.Signature.S)
+			assert.True(t, ecdsa.Verify(pk, data.Signature.M, r, s), "ecdsa verify must pass")
+			assert.NoError(t, VerifySignature(keys[0].ECDSAPub, digest, data.Signature))
+			assertChainEncodings(t, data.Signature, keys[0].ECDSAPub)
+		}
+	}
+}
+
+// assertChainEncodings checks that each encoding of a low-S signature with its recovery ID passes its verifier
+func assertChainEncodings(t *testing.T, sig *common.ECSignature, pub *crypto.ECPoint) {
+	s := new(big.Int).SetBytes(sig.S)
+	assert.True(t, s.Cmp(new(big.Int).Rsh(tss.EC().Params().N, 1)) <= 0, "s should be low")
+
+	// ZetaChain mainnet (7000) and a chain ID above 2^64 have a v of several bytes
+	hugeChainID, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	for _, chainID := range []*big.Int{nil, big.NewInt(1), big.NewInt(56), big.NewInt(7000), hugeChainID} {
+		bz, v, err := EncodeEthereum(sig, pub, chainID)
+		if assert.NoError(t, err) {
+			assert.Len(t, bz, 64)
+			assert.NoError(t, VerifyEthereum(bz, v, sig.M, chainID, pub), "the recovery ID should recover the public key")
+		}
+	}
+	_, v, err := EncodeEthereum(sig, pub, big.NewInt(7000))
+	if assert.NoError(t, err) {
+		assert.Equal(t, 7000*2+35+int64(sig.SignatureRecovery[0]), v.Int64())
+	}
+
+	bz, err := EncodeBitcoinDER(sig, pub, 0x01)
+	if assert.NoError(t, err) {
+		sighash, err := VerifyBitcoinDER(bz, sig.M, pub)
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0x01), sighash)
+	}
+
+	bz, err = EncodeCosmos(sig, pub)
+	if assert.NoError(t, err) {
+		assert.Len(t, bz, 64)
+		assert.NoError(t, VerifyCosmos(bz, sig.M, pub))
+	}
+}
+
+func TestChainEncodingsRejectBadSignatures(t *testing.T) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	assert.NoError(t, err)
+	pub, err := crypto.NewECPoint(btcec.S256(), priv.X, priv.Y)
+	assert.NoError(t, err)
+	other := crypto.ScalarBaseMult(btcec.S256(), big.NewInt(2))
+
+	digest := make([]byte, 32)
+	digest[0] = 1
+	compact, err := btcec.SignCompact(btcec.S256(), priv, digest, false)
+	assert.NoError(t, err)
+	sig := &common.ECSignature{
+		R:                 compact[1:33],
+		S:                 compact[33:],
+		SignatureRecovery: []byte{compact[0] - 27},
+		M:                 digest,
+	}
+	assertChainEncodings(t, sig, pub)
+
+	eth, v, err := EncodeEthereum(sig, pub, big.NewInt(7000))
+	assert.NoError(t, err)
+	assert.Error(t, VerifyEthereum(eth, v, digest, big.NewInt(7000), other), "wrong public key")
+	assert.Error(t, VerifyEthereum(eth, v, digest, big.NewInt(7001), pub), "wrong chain ID")
+	assert.Error(t, VerifyEthereum(eth, new(big.Int).Add(v, big.NewInt(2)), digest, big.NewInt(7000), pub), "wrong v")
+	der, err := EncodeBitcoinDER(sig, pub, 0x01)
+	assert.NoError(t, err)
+	_, err = VerifyBitcoinDER(der, digest, other)
+	assert.Error(t, err, "wrong public key")
+	cosmos, err := EncodeCosmos(sig, pub)
+	assert.NoError(t, err)
+	assert.Error(t, VerifyCosmos(cosmos, digest[:31], pub), "wrong digest")
+
+	// the encoders refuse a high S
+	highS := proto.Clone(sig).(*common.ECSignature)
+	highS.S = new(big.Int).Sub(btcec.S256().N, new(big.Int).SetBytes(sig.S)).Bytes()
+	_, err = EncodeCosmos(highS, pub)
+	assert.Error(t, err)
+	_, err = EncodeBitcoinDER(highS, pub, 0x01)
+	assert.Error(t, err)
+
+	// and a signature of a key on another curve
+	p256 := crypto.ScalarBaseMult(elliptic.P256(), big.NewInt(2))
+	_, _, err = EncodeEthereum(sig, p256, nil)
+	assert.Error(t, err)
+	_, err = EncodeBitcoinDER(sig, p256, 0x01)
+	assert.Error(t, err)
+	_, err = EncodeCosmos(sig, p256)
+	assert.Error(t, err)
+}
+
+func TestE2EConcurrentOneRoundPresignatureStore(t *testing.T) {
+	setUp("info")
+	keys, signPIDs, parties := runOneRoundPresigning(t)
+	if parties == nil {
+		return
+	}
+	// a second presignature of the same signers
+	parties2 := runOneRoundPresigningAmong(t, keys, signPIDs)
+	if parties2 == nil {
+		return
+	}
+	var err error
+
+	// P[0] and P[1] keep their presignatures in files, the others in memory. The second presignature is added first by
+	// some parties, so that the order of insertion and of the files does not match the order of the presign sessions.
+	stores := make([]*PresignatureStore, len(parties))
+	var id, id2 string
+	for i := range parties {
+		storage := NewMemoryPresignatureStorage()
+		if i < 2 {
+			storage, err = NewFilePresignatureStorage(t.TempDir())
+			assert.NoError(t, err)
+		}
+		stores[i] = NewPresignatureStore(storage, time.Hour)
+		datas := []*SignatureData{&parties[i].data, &parties2[i].data}
+		if i%2 == 1 {
+			datas[0], datas[1] = datas[1], datas[0]
+		}
+		for _, data := range datas {
+			presignID, err := stores[i].Add(signPIDs, data)
+			assert.NoError(t, err)
+			if data == &parties[i].data {
+				assert.True(t, id == "" || id == presignID, "every signer should get the same id")
+				id = presignID
+			} else {
+				assert.True(t, id2 == "" || id2 == presignID, "every signer should get the same id")
+				id2 = presignID
+			}
+		}
+		_, err = stores[i].Add(signPIDs, &parties[i].data)
+		assert.Error(t, err, "a presignature must not be stored twice")
+		_, err = stores[i].Add(signPIDs[1:], &parties[i].data)
+		assert.Error(t, err, "the signer set must match the presignature")
+	}
+	assert.NotEqual(t, id, id2)
+
+	// the online step: each party gives out its share of s with the presignature that the signers agreed on
+	pk := &ecdsa.PublicKey{Curve: tss.EC(), X: keys[0].ECDSAPub.X(), Y: keys[0].ECDSAPub.Y()}
+	for _, presignID := range []string{id2, id} {
+		msg := common.GetRandomPositiveInt(tss.EC().Params().N)
+		sIs := make([]*big.Int, len(parties))
+		states := make([]*SignatureData, len(parties))
+		for i, store := range stores {
+			ids, err := store.IDs(signPIDs)
+			assert.NoError(t, err)
+			assert.Contains(t, ids, presignID)
+			sIs[i], states[i], err = store.SignShare(signPIDs, presignID, msg)
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, _, err = store.SignShare(signPIDs, presignID, msg)
+			assert.Equal(t, ErrNoPresignature, err, "a presignature must not be handed out twice")
+		}
+		otherSIs := make(map[*tss.PartyID]*big.Int, len(parties)-1)
+		for j := 1; j < len(parties); j++ {
+			otherSIs[signPIDs[j]] = sIs[j]
+		}
+		data, _, err2 := FinalizeGetAndVerifyFinalSig(states[0], pk, msg, signPIDs[0], sIs[0], otherSIs)
+		if assert.Nil(t, err2) {
+			r, s := new(big.Int).SetBytes(data.Signature.R), new(big.Int).SetBytes(data.Signature.S)
+			assert.True(t, ecdsa.Verify(pk, msg.Bytes(), r, s), "ecdsa verify must pass")
+		}
+	}
+	for i, store := range stores {
+		ids, err := store.IDs(signPIDs)
+		assert.NoError(t, err)
+		assert.Empty(t, ids)
+
+		// a consumed presignature cannot be added again, e.g. from a backup
+		for _, data := range []*SignatureData{&parties[i].data, &parties2[i].data} {
+			_, err = store.Add(signPIDs, data)
+			if assert.Error(t, err, "a consumed presignature must not be stored again") {
+				assert.Contains(t, err.Error(), "was already consumed")
+			}
+		}
+	}
+
+	// an expired presignature is discarded
+	msg := big.NewInt(42)
+	store := NewPresignatureStore(NewMemoryPresignatureStorage(), time.Hour)
+	id, err = store.Add(signPIDs, &parties[1].data)
+	assert.NoError(t, err)
+	store.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, _, err = store.SignShare(signPIDs, id, msg)
+	assert.Equal(t, ErrNoPresignature, err)
+
+	// a presignature stored under the key of another signer set or another id is rejected
+	storage := NewMemoryPresignatureStorage()
+	store = NewPresignatureStore(storage, 0)
+	record, err := json.Marshal(&presignatureRecord{
+		Signers: presignatureSigners(parties[1].data.OneRoundData),
+		Data:    mustMarshal(t, parties[1].data.OneRoundData),
+	})
+	assert.NoError(t, err)
+	otherSet := tss.SortPartyIDs(append(tss.UnSortedPartyIDs{}, signPIDs[1:]...))
+	assert.NoError(t, storage.Save(presignatureSetKey(otherSet), id, record))
+	_, _, err = store.SignShare(otherSet, id, msg)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "was made by the signers")
+	}
+	assert.NoError(t, storage.Save(presignatureSetKey(signPIDs), id2, record))
+	_, _, err = store.SignShare(signPIDs, id2, msg)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "has another id")
+	}
+}
+
+func TestE2EConcurrentOnlineParty(t *testing.T) {
+	setUp("info")
+	keys, signPIDs, presigners := runOneRoundPresigning(t)
+	if presigners == nil {
+		return
+	}
+	msg := big.NewInt(42)
+	pk := &ecdsa.PublicKey{Curve: tss.EC(), X: keys[0].ECDSAPub.X(), Y: keys[0].ECDSAPub.Y()}
+	// finalizing clears the state, so keep copies for the second run
+	states := make([]*SignatureData, len(presigners))
+	for i, P := range presigners {
+		states[i] = proto.Clone(&P.data).(*SignatureData)
+	}
+
+	// P[0] takes its presignature from a store, the others are given theirs
+	store := NewPresignatureStore(NewMemoryPresignatureStorage(), 0)
+	id, err := store.Add(signPIDs, &presigners[0].data)
+	assert.NoError(t, err)
+	newParty := func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+		if i == 0 {
+			P, err := store.NewOnlineLocalParty(id, msg, params, keys[i], out, end)
+			assert.NoError(t, err)
+			return P
+		}
+		return NewOnlineLocalParty(msg, params, keys[i], &presigners[i].data, out, end)
+	}
+	results, errs := runOnlineSigning(signPIDs, newParty, nil)
+	if assert.Empty(t, errs) && assert.Len(t, results, len(signPIDs)) {
+		for _, data := range results {
+			r, s := new(big.Int).SetBytes(data.Signature.R), new(big.Int).SetBytes(data.Signature.S)
+			assert.True(t, ecdsa.Verify(pk, msg.Bytes(), r, s), "ecdsa verify must pass")
+			assert.Equal(t, results[0].Signature.Signature, data.Signature.Signature, "all parties should output the same signature")
+		}
+	}
+	_, err = store.NewOnlineLocalParty(id, msg, tss.NewParameters(tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold), keys[0], nil, nil)
+	assert.Equal(t, ErrNoPresignature, err, "a presignature must not be handed out twice")
+
+	// a wrong s_i from P[0] is caught by the other parties, who name P[0] as the culprit
+	newParty = func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+		return NewOnlineLocalParty(msg, params, keys[i], states[i], out, end)
+	}
+	tamper := func(msg tss.Message) tss.Message {
+		if msg.GetFrom().Index != 0 {
+			return msg
+		}
+		r7msg := msg.(tss.ParsedMessage).Content().(*SignRound7Message)
+		sI := new(big.Int).SetBytes(r7msg.GetSI())
+		return NewSignRound7MessageSuccess(msg.GetFrom(), sI.Add(sI, big.NewInt(1)))
+	}
+	results, errs = runOnlineSigning(signPIDs, newParty, tamper)
+	assert.Len(t, results, 1, "only P[0] should finish")
+	assert.Len(t, errs, len(signPIDs)-1)
+	for _, err := range errs {
+		assert.Equal(t, 8, err.Round())
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, signPIDs[0].Id, err.Culprits()[0].Id)
+		}
+	}
+
+	// a party given the state of another signer set fails to start
+	P := NewOnlineLocalParty(msg, tss.NewParameters(tss.NewPeerContext(signPIDs[1:]), signPIDs[1], len(signPIDs)-1, testThreshold-1),
+		keys[1], states[1], make(chan tss.Message, 1), make(chan *SignatureData, 1))
+	assert.Error(t, P.Start())
+}
+
+func TestE2EConcurrentOnlinePartyType7Abort(t *testing.T) {
+	setUp("info")
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+	parties := newTestParties(signPIDs, func(i int, params *tss.Parameters) tss.Party {
+		return NewLocalPartyWithOneRoundSign(params, keys[i], outCh, endCh)
+	})
+
+	// P[0] switches to sigma_0 + 1 as it broadcasts T_0, so that its T_0, S_0 and their proofs are consistent with each other
+	// and every check passes until sum(S_j) != y is found in round 7
+	tamper := func(msg tss.Message) tss.Message {
+		r3msg, ok := msg.(tss.ParsedMessage).Content().(*SignRound3Message)
+		if !ok || msg.GetFrom().Index != 0 {
+			return msg
+		}
+		P := parties[0].(*LocalParty)
+		h, err := crypto.ECBasePoint2(tss.EC())
+		assert.NoError(t, err)
+		sigmaI := new(big.Int).Add(P.temp.sigmaI, big.NewInt(1))
+		TI, err := crypto.ScalarBaseMult(tss.EC(), sigmaI).Add(h.ScalarMult(P.temp.lI))
+		assert.NoError(t, err)
+		tProof, err := zkp.NewTProof(TI, h, sigmaI, P.temp.lI)
+		assert.NoError(t, err)
+		P.temp.sigmaI.Set(sigmaI)
+		P.temp.TI = TI
+		return NewSignRound3Message(msg.GetFrom(), new(big.Int).SetBytes(r3msg.GetDeltaI()), TI, tProof)
+	}
+	stop := make(chan struct{})
+	go test.RunParties(parties, outCh, errCh, tamper, stop)
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			close(stop)
+			assert.FailNow(t, err.Error())
+		case data := <-endCh:
+			assert.NotNil(t, data.GetOneRoundData().GetAbortData(), "the presignature should carry the abort data")
+			ended++
+		}
+	}
+	close(stop)
+
+	// P[1] takes its presignature from a store, which refuses to sign with it
+	store := NewPresignatureStore(NewMemoryPresignatureStorage(), 0)
+	id, err := store.Add(signPIDs, &parties[1].(*LocalParty).data)
+	assert.NoError(t, err)
+	_, state1, err := store.SignShare(signPIDs, id, big.NewInt(42))
+	assert.Equal(t, ErrAbortedPresignature, err)
+
+	// the online phase reveals the abort data in place of s_i and every party names P[0]
+	newParty := func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+		state := &parties[i].(*LocalParty).data
+		if i == 1 {
+			state = state1
+		}
+		return NewOnlineLocalParty(big.NewInt(42), params, keys[i], state, out, end)
+	}
+	results, errs := runOnlineSigning(signPIDs, newParty, nil)
+	assert.Empty(t, results)
+	if assert.Len(t, errs, len(signPIDs)) {
+		for _, err := range errs {
+			assert.Equal(t, 8, err.Round())
+			if assert.Len(t, err.Culprits(), 1) {
+				assert.Equal(t, signPIDs[0].Id, err.Culprits()[0].Id)
+			}
+		}
+	}
+}
+
+func TestE2EConcurrentCloseWipesTempData(t *testing.T) {
+	setUp("info")
+	_, signPIDs, parties := runOneRoundPresigning(t)
+	if parties == nil {
+		return
+	}
+	for _, P := range parties {
+		secrets := []*big.Int{P.temp.gammaI, P.temp.deltaI, P.temp.rAKI}
+		for _, secret := range secrets {
+			assert.NotZero(t, secret.Sign())
+		}
+		oneRound := proto.Clone(P.data.OneRoundData).(*SignatureData_OneRoundData)
+
+		P.Close()
+		P.Close() // closing twice is harmless
+		assert.True(t, test.Wiped(secrets...), "the secrets in the temp data should be wiped")
+		assert.True(t, proto.Equal(oneRound, P.data.OneRoundData), "the one-round data sent through end should be left as is")
+		assert.False(t, P.Running())
+		assert.Error(t, P.Start())
+	}
+
+	// a closed party refuses new messages
+	msg := NewSignRound7MessageSuccess(signPIDs[1], big.NewInt(1))
+	_, err := parties[0].Update(msg)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "closed")
+	}
+}
+
+func TestCloseAfterTimeout(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	xi := new(big.Int).Set(keys[0].Xi)
+
+	// P[0] starts, but the messages of the other parties never come
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := tss.NewParameters(p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, 1)
+	P := NewLocalParty(big.NewInt(42), params, keys[0], outCh, endCh).(*LocalParty)
+	if !assert.Nil(t, P.Start()) {
+		return
+	}
+	secrets := []*big.Int{P.temp.wI, P.temp.gammaI, P.temp.rAKI}
+	for _, secret := range secrets {
+		assert.NotZero(t, secret.Sign())
+	}
+	secretBytes := [][]byte{P.temp.KI, P.temp.r5AbortData.KI, P.temp.r5AbortData.GammaI, P.temp.r7AbortData.KI, P.temp.r7AbortData.KRandI}
+
+	P.Close()
+	assert.True(t, test.Wiped(secrets...), "the secrets in the temp data should be wiped")
+	assert.True(t, test.WipedBytes(secretBytes...), "the secrets in the abort data should be wiped")
+	assert.Equal(t, 0, xi.Cmp(keys[0].Xi), "the key data should be left as is")
+	assert.Equal(t, 0, len(endCh))
+
+	msg := NewSignRound1Message2(signPIDs[1], big.NewInt(1))
+	_, err2 := P.Update(msg)
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "closed")
+	}
+	assert.NotEmpty(t, P.String())
+}
+
+// runOnlineSigning runs the online parties made by newParty until each has ended or failed. tamper may change the messages in transit.
+func runOnlineSigning(
+	signPIDs tss.SortedPartyIDs,
+	newParty func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *SignatureData) tss.Party,
+	tamper func(tss.Message) tss.Message,
+) ([]*SignatureData, []*tss.Error) {
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	parties := newTestParties(signPIDs, func(i int, params *tss.Parameters) tss.Party {
+		return newParty(i, params, outCh, endCh)
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, tamper, stop)
+
+	results := make([]*SignatureData, 0, len(signPIDs))
+	errs := make([]*tss.Error, 0, len(signPIDs))
+	for len(results)+len(errs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			errs = append(errs, err)
+
+		case data := <-endCh:
+			results = append(results, data)
+		}
+	}
+	return results, errs
+}
+
+// runOneRoundPresigning runs the offline phase of one-round signing among a random set of signers
+func runOneRoundPresigning(t *testing.T) ([]keygen.LocalPartySaveData, tss.SortedPartyIDs, []*LocalParty) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return nil, nil, nil
+	}
+	presigners := runOneRoundPresigningAmong(t, keys, signPIDs)
+	if presigners == nil {
+		return nil, nil, nil
+	}
+	return keys, signPIDs, presigners
+}
+
+// runOneRoundPresigningAmong runs the offline phase of one-round signing among signPIDs, whose keys are given in the same order
+func runOneRoundPresigningAmong(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs) []*LocalParty {
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	parties := newTestParties(signPIDs, func(i int, params *tss.Parameters) tss.Party {
+		return NewLocalPartyWithOneRoundSign(params, keys[i], outCh, endCh)
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, nil, stop)
+
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return nil
+
+		case <-endCh:
+			ended++
+		}
+	}
+	presigners := make([]*LocalParty, 0, len(parties))
+	for _, P := range parties {
+		presigners = append(presigners, P.(*LocalParty))
+	}
+	return presigners
+}
+
+func mustMarshal(t *testing.T, m proto.Message) []byte {
+	bz, err := proto.Marshal(m)
+	assert.NoError(t, err)
+	return bz
+}
+
+func TestE2EConcurrentBatch(t *testing.T) {
+	setUp("info")
+
+	digests := make([][]byte, 3)
+	for b := range digests {
+		digests[b] = make([]byte, 32)
+		copy(digests[b], common.GetRandomPositiveInt(new(big.Int).Lsh(big.NewInt(1), 256)).Bytes())
+	}
+	results, parties, keys := runBatchSigning(t, digests, nil)
+	pk := &ecdsa.PublicKey{Curve: tss.EC(), X: keys[0].ECDSAPub.X(), Y: keys[0].ECDSAPub.Y()}
+	for _, P := range parties {
+		assert.Equal(t, make([]*tss.Error, len(digests)), P.Errors())
+	}
+	for _, result := range results {
+		if !assert.Len(t, result, len(digests)) {
+			continue
+		}
+		for b, data := range result {
+			if !assert.NotNil(t, data) {
+				continue
+			}
+			assert.Equal(t, digests[b], data.Signature.M)
+			assert.Equal(t, results[0][b].Signature.Signature, data.Signature.Signature, "all parties should output the same signature")
+			r, s := new(big.Int).SetBytes(data.Signature.R), new(big.Int).SetBytes(data.Signature.S)
+			assert.True(t, ecdsa.Verify(pk, digests[b], r, s), "ecdsa verify must pass")
+		}
+	}
+}
+
+func TestE2EConcurrentBatchIdentifiedAbort(t *testing.T) {
+	setUp("info")
+
+	digests := make([][]byte, 3)
+	for b := range digests {
+		digests[b] = make([]byte, 32)
+		digests[b][31] = byte(b + 1)
+	}
+	// P[0] broadcasts its round 1 commitment of the first digest in place of the one of the second
+	tampered := false
+	tamper := func(msg tss.Message) tss.Message {
+		if tampered || msg.GetFrom().Index != 0 || !msg.IsBroadcast() {
+			return msg
+		}
+		tampered = true
+		messages := msg.(tss.ParsedMessage).Content().(*SignBatchMessage).GetMessages()
+		messages = append([][]byte{}, messages...)
+		messages[1] = messages[0]
+		return NewSignBatchMessage(nil, msg.GetFrom(), true, messages)
+	}
+	results, parties, keys := runBatchSigning(t, digests, tamper)
+	pk := &ecdsa.PublicKey{Curve: tss.EC(), X: keys[0].ECDSAPub.X(), Y: keys[0].ECDSAPub.Y()}
+	for _, result := range results {
+		if !assert.Len(t, result, len(digests)) {
+			continue
+		}
+		assert.Nil(t, result[1], "the second digest should not be signed")
+		for _, b := range []int{0, 2} {
+			if assert.NotNil(t, result[b], "the other digests should be signed") {
+				r, s := new(big.Int).SetBytes(result[b].Signature.R), new(big.Int).SetBytes(result[b].Signature.S)
+				assert.True(t, ecdsa.Verify(pk, digests[b], r, s), "ecdsa verify must pass")
+			}
+		}
+	}
+	for i, P := range parties {
+		errs := P.Errors()
+		assert.Nil(t, errs[0])
+		assert.Nil(t, errs[2])
+		if !assert.NotNil(t, errs[1]) {
+			continue
+		}
+		assert.Contains(t, errs[1].Error(), "digest 1")
+		if i == 0 {
+			continue
+		}
+		// the honest parties identify P[0]
+		if assert.Len(t, errs[1].Culprits(), 1) {
+			assert.Equal(t, 0, errs[1].Culprits()[0].Index)
+		}
+	}
+}
+
+// runBatchSigning signs the digests with a batch party per signer; tamper may rewrite the messages that are sent
+func runBatchSigning(t *testing.T, digests [][]byte, tamper func(tss.Message) tss.Message) (
+	[][]*SignatureData, []*BatchLocalParty, []keygen.LocalPartySaveData) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan []*SignatureData, len(signPIDs))
+
+	parties := newTestParties(signPIDs, func(i int, params *tss.Parameters) tss.Party {
+		return NewBatchLocalParty(digests, params, keys[i], outCh, endCh)
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, tamper, stop)
+
+	results := make([][]*SignatureData, 0, len(signPIDs))
+	for len(results) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			// the error of a digest is also kept in Errors(); the others go on
+			common.Logger.Warnf("Error: %s", err)
+
+		case result := <-endCh:
+			results = append(results, result)
+		}
+	}
+	batchParties := make([]*BatchLocalParty, 0, len(parties))
+	for _, P := range parties {
+		batchParties = append(batchParties, P.(*BatchLocalParty))
+	}
+	return results, batchParties, keys
+}
+
+// newTestParties makes the party of each of signPIDs with newParty, given the parameters of the signing set
+func newTestParties(signPIDs tss.SortedPartyIDs, newParty func(i int, params *tss.Parameters) tss.Party) []tss.Party {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i, signPID := range signPIDs {
+		params := tss.NewParameters(p2pCtx, signPID, len(signPIDs), testThreshold)
+		parties = append(parties, newParty(i, params))
+	}
+	return parties
+}
+
+func TestE2EConcurrentP256(t *testing.T) {
+	setUp("info")
+
+	// the pre-params of the fixtures do not depend on the curve, but their points are on secp256k1
+	fixtures, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	tss.SetCurve(elliptic.P256())
+	defer tss.SetCurve(btcec.S256())
+
+	keys := runKeygen(t, fixtures, pIDs, nil)
+	if keys == nil {
+		return
+	}
+	for _, key := range keys {
+		assert.Equal(t, tss.Nist256p1, key.Curve, "ensure the save data records the curve")
+		assert.True(t, tss.SameCurve(elliptic.P256(), key.ECDSAPub.Curve()))
+	}
+
+	signPIDs, err := SelectQuorum(keys[0], pIDs, pIDs, testThreshold)
+	if !assert.NoError(t, err) {
+		return
+	}
+	digest := sha256.Sum256([]byte("webauthn"))
+	msg := new(big.Int).SetBytes(digest[:])
+	data, tssErr := runSigning(t, keys, pIDs, signPIDs, msg, nil)
+	if !assert.Nil(t, tssErr) {
+		return
+	}
+	pk := &ecdsa.PublicKey{Curve: elliptic.P256(), X: keys[0].ECDSAPub.X(), Y: keys[0].ECDSAPub.Y()}
+	r, s := new(big.Int).SetBytes(data.Signature.R), new(big.Int).SetBytes(data.Signature.S)
+	assert.True(t, ecdsa.Verify(pk, digest[:], r, s), "ecdsa verify must pass")
+	assert.True(t, s.Cmp(new(big.Int).Rsh(elliptic.P256().Params().N, 1)) <= 0, "ensure s is low")
+	assert.NoError(t, VerifySignature(keys[0].ECDSAPub, digest[:], data.Signature))
+	der, err := EncodeDER(data.Signature)
+	if assert.NoError(t, err) {
+		assert.True(t, ecdsa.VerifyASN1(pk, digest[:], der), "ensure the DER signature verifies")
+	}
+
+	// the key cannot be used on another curve
+	tss.SetCurve(btcec.S256())
+	assert.Error(t, keys[0].Validate(), "ensure a P-256 key is refused on secp256k1")
+}
+
+func TestVerifySignatureAndDER(t *testing.T) {
+	defer tss.SetCurve(btcec.S256())
+	for _, curve := range []elliptic.Curve{btcec.S256(), elliptic.P256()} {
+		tss.SetCurve(curve)
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if !assert.NoError(t, err) {
+			return
+		}
+		pub, err := crypto.NewECPoint(curve, priv.X, priv.Y)
+		if !assert.NoError(t, err) {
+			return
+		}
+		digest := sha256.Sum256([]byte("standalone verification"))
+
+		// signatures of crypto/ecdsa round trip through ParseDER and EncodeDER and verify with VerifySignature
+		for i := 0; i < 8; i++ {
+			der, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+			if !assert.NoError(t, err) {
+				return
+			}
+			sig, err := ParseDER(der)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NoError(t, VerifySignature(pub, digest[:], sig))
+			encoded, err := EncodeDER(sig)
+			assert.NoError(t, err)
+			assert.Equal(t, der, encoded)
+		}
+
+		der, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+		assert.NoError(t, err)
+		sig, err := ParseDER(der)
+		if !assert.NoError(t, err) {
+			return
+		}
+		r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+		assert.Error(t, VerifySignature(pub, digest[1:], sig), "wrong digest")
+		assert.Error(t, VerifySignature(crypto.ScalarBaseMult(curve, big.NewInt(2)), digest[:], sig), "wrong public key")
+		withM := proto.Clone(sig).(*common.ECSignature)
+		withM.M = []byte{1}
+		assert.Error(t, VerifySignature(pub, digest[:], withM), "the signature is of another message")
+
+		// the other S verifies as with crypto/ecdsa, and is kept by the DER round trip
+		otherS := proto.Clone(sig).(*common.ECSignature)
+		otherS.S = new(big.Int).Sub(curve.Params().N, s).Bytes()
+		assert.NoError(t, VerifySignature(pub, digest[:], otherS))
+		otherDER, err := EncodeDER(otherS)
+		if assert.NoError(t, err) {
+			assert.True(t, ecdsa.VerifyASN1(&priv.PublicKey, digest[:], otherDER))
+		}
+
+		// malleable or malformed encodings are refused
+		rBz, sBz := asn1Integer(r.Bytes()), asn1Integer(s.Bytes())
+		for name, bad := range map[string][]byte{
+			"trailing data":         append(append([]byte{}, der...), 0),
+			"long form length":      append([]byte{0x30, 0x81, byte(len(rBz) + len(sBz))}, append(rBz, sBz...)...),
+			"padded r":              derSequence(asn1Integer(append([]byte{0, 0}, r.Bytes()...)), sBz),
+			"negative r":            derSequence(mustMarshalInteger(t, new(big.Int).Neg(r)), sBz),
+			"zero s":                derSequence(rBz, []byte{0x02, 0x01, 0x00}),
+			"s of the order":        derSequence(rBz, asn1Integer(curve.Params().N.Bytes())),
+			"truncated":             der[:len(der)-1],
+			"not a sequence":        append([]byte{0x31}, der[1:]...),
+			"one integer too many":  derSequence(rBz, append(append([]byte{}, sBz...), sBz...)),
+			"sequence length short": append([]byte{0x30, der[1] - 1}, der[2:]...),
+		} {
+			_, err := ParseDER(bad)
+			assert.Error(t, err, name)
+		}
+	}
+	_, err := EncodeDER(&common.ECSignature{R: []byte{1}})
+	assert.Error(t, err, "the signature is incomplete")
+}
+
+// asn1Integer returns the DER INTEGER of a big-endian unsigned value, without checking that it is minimal
+func asn1Integer(bz []byte) []byte {
+	if len(bz) == 0 || bz[0]&0x80 != 0 {
+		bz = append([]byte{0}, bz...)
+	}
+	return append([]byte{0x02, byte(len(bz))}, bz...)
+}
+
+func derSequence(items ...[]byte) []byte {
+	var content []byte
+	for _, item := range items {
+		content = append(content, item...)
+	}
+	return append([]byte{0x30, byte(len(content))}, content...)
+}
+
+func mustMarshalInteger(t *testing.T, x *big.Int) []byte {
+	bz, err := asn1.Marshal(x)
+	assert.NoError(t, err)
+	return bz
+}
+
+func TestE2EConcurrentWeighted(t *testing.T) {
+	setUp("info")
+
+	// P0 and P1 hold 3 and 2 shares, so they can sign with t = 3 without any other party
+	fixtures, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	keys := runKeygen(t, fixtures, pIDs, map[int]int{0: 3, 1: 2})
+	if keys == nil {
+		return
+	}
+	signPIDs := tss.SortPartyIDs(tss.UnSortedPartyIDs{
+		tss.NewPartyID(pIDs[0].Id, pIDs[0].Moniker, pIDs[0].KeyInt()),
+		tss.NewPartyID(pIDs[1].Id, pIDs[1].Moniker, pIDs[1].KeyInt()),
+	})
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	msg := common.GetRandomPrimeInt(256)
+	parties := newTestParties(signPIDs, func(i int, params *tss.Parameters) tss.Party {
+		key := keygen.BuildLocalSaveDataSubset(keys[i], signPIDs)
+		return NewLocalParty(msg, params, key, outCh, endCh)
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, nil, stop)
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case data := <-endCh:
+			if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+				pk := keys[0].ECDSAPub.ToECDSAPubKey()
+				r, s := new(big.Int).SetBytes(data.GetSignature().GetR()), new(big.Int).SetBytes(data.GetSignature().GetS())
+				assert.True(t, ecdsa.Verify(pk, msg.Bytes(), r, s), "ecdsa verify must pass")
+				break signing
+			}
+		}
+	}
+}
+
+// runKeygen runs a keygen with the pre-params of the fixtures in which the party at each index of weights holds that many shares; weights may be nil
+func runKeygen(t *testing.T, fixtures []keygen.LocalPartySaveData, pIDs tss.SortedPartyIDs, weights map[int]int) []keygen.LocalPartySaveData {
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	parties := newTestParties(pIDs, func(i int, params *tss.Parameters) tss.Party {
+		for j, weight := range weights {
+			params.SetPartyWeight(pIDs[j], weight)
+		}
+		return keygen.NewLocalParty(params, outCh, endCh, fixtures[i].LocalPreParams)
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, nil, stop)
+
+	keys := make([]keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			return nil
+
+		case key := <-endCh:
+			index, err := key.OriginalIndex()
+			if !assert.NoError(t, err) {
+				return nil
+			}
+			keys[index] = key
+			ended++
+		}
+	}
+	return keys
+}
+
+// BenchmarkRound2MtA measures the Bob_mid and Bob_mid_wc proofs computed by one signer in round 2,
+// run with one goroutine and with one per CPU.
+func BenchmarkRound2MtA(b *testing.B) {
+	benchmarkMtARound(b, func(round *round3) tss.Round { return round.round2 })
+}
+
+// BenchmarkRound3MtA measures the Alice_end and Alice_end_wc verifications of one signer in round 3,
+// run with one goroutine and with one per CPU.
+func BenchmarkRound3MtA(b *testing.B) {
+	benchmarkMtARound(b, func(round *round3) tss.Round { return round })
+}
+
+func benchmarkMtARound(b *testing.B, pick func(*round3) tss.Round) {
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if err != nil {
+		b.Fatal(err)
+	}
+	// the speedup needs more than one CPU
+	workerCounts := []int{1}
+	if 1 < runtime.NumCPU() {
+		workerCounts = append(workerCounts, runtime.NumCPU())
+	}
+	for _, partyCount := range []int{10, 20, 30} {
+		round, out := newMtABenchmarkRound(b, fixtures, partyCount)
+		betas, vJIs := round.temp.betas, round.temp.vJIs
+		for _, workers := range workerCounts {
+			b.Run(fmt.Sprintf("parties=%d/workers=%d", partyCount, workers), func(b *testing.B) {
+				round.SetConcurrency(workers)
+				b.ResetTimer()
+				for n := 0; n < b.N; n++ {
+					// round 3 clears these
+					round.temp.wI = common.GetRandomPositiveInt(tss.EC().Params().N)
+					round.temp.betas, round.temp.vJIs = betas, vJIs
+					round.started = false
+					if err := pick(round).Start(); err != nil {
+						b.Fatal(err)
+					}
+					for len(out) > 0 {
+						<-out
+					}
+				}
+			})
+		}
+	}
+}
+
+// newMtABenchmarkRound returns round 3 of the first of partyCount signers with the messages of rounds 1 and 2 from every peer.
+// The Paillier keys and NTilde of the test fixtures are reused by the peers of committees larger than the fixtures.
+func newMtABenchmarkRound(b *testing.B, fixtures []keygen.LocalPartySaveData, partyCount int) (*round3, chan tss.Message) {
+	q := tss.EC().Params().N
+	pIDs := tss.GenerateTestPartyIDs(partyCount)
+	key := fixtures[0]
+	key.Ks, key.BigXj = make([]*big.Int, partyCount), make([]*crypto.ECPoint, partyCount)
+	key.PaillierPKs = make([]*paillier.PublicKey, partyCount)
+	key.NTildej, key.H1j, key.H2j = make([]*big.Int, partyCount), make([]*big.Int, partyCount), make([]*big.Int, partyCount)
+	for j, Pj := range pIDs {
+		fixture := fixtures[j%len(fixtures)]
+		key.Ks[j] = Pj.KeyInt()
+		key.PaillierPKs[j] = &fixture.PaillierSK.PublicKey
+		key.NTildej[j], key.H1j[j], key.H2j[j] = fixture.NTildei, fixture.H1i, fixture.H2i
+	}
+
+	out := make(chan tss.Message, partyCount)
+	params := tss.NewParameters(tss.NewPeerContext(pIDs), pIDs[0], partyCount, testThreshold)
+	P := NewLocalParty(big.NewInt(1), params, key, out, nil).(*LocalParty)
+	P.keys = key
+	P.temp.gammaI = common.GetRandomPositiveInt(q)
+	P.temp.wI = common.GetRandomPositiveInt(q)
+	kI := common.GetRandomPositiveInt(q)
+	P.temp.KI = kI.Bytes()
+	cI, rI, err := key.PaillierPKs[0].EncryptAndReturnRandomness(kI)
+	if err != nil {
+		b.Fatal(err)
+	}
+	P.temp.bigWs[0] = crypto.ScalarBaseMult(tss.EC(), P.temp.wI)
+	for j := 1; j < partyCount; j++ {
+		wJ, gammaJ, kJ := common.GetRandomPositiveInt(q), common.GetRandomPositiveInt(q), common.GetRandomPositiveInt(q)
+		P.temp.bigWs[j] = crypto.ScalarBaseMult(tss.EC(), wJ)
+		P.temp.betas[j], P.temp.vJIs[j] = common.GetRandomPositiveInt(q), common.GetRandomPositiveInt(q)
+
+		// round 1: the peer is Alice and this signer is Bob
+		cJ, rJ, err := key.PaillierPKs[j].EncryptAndReturnRandomness(kJ)
+		if err != nil {
+			b.Fatal(err)
+		}
+		piJ, err := mta.AliceInit(key.PaillierPKs[j], kJ, cJ, rJ, key.NTildej[0], key.H1j[0], key.H2j[0])
+		if err != nil {
+			b.Fatal(err)
+		}
+		P.temp.signRound1Message1s[j] = NewSignRound1Message1(pIDs[0], pIDs[j], cJ, piJ)
+
+		// round 2: this signer is Alice and the peer is Bob
+		piI, err := mta.AliceInit(key.PaillierPKs[0], kI, cI, rI, key.NTildej[j], key.H1j[j], key.H2j[j])
+		if err != nil {
+			b.Fatal(err)
+		}
+		P.temp.c1Is[j] = cI
+		_, c1JI, _, pi1JI, err := mta.BobMid(key.PaillierPKs[0], piI, gammaJ, cI,
+			key.NTildej[0], key.H1j[0], key.H2j[0], key.NTildej[j], key.H1j[j], key.H2j[j])
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, c2JI, pi2JI, err := mta.BobMidWC(key.PaillierPKs[0], piI, wJ, cI,
+			key.NTildej[0], key.H1j[0], key.H2j[0], key.NTildej[j], key.H1j[j], key.H2j[j], P.temp.bigWs[j])
+		if err != nil {
+			b.Fatal(err)
+		}
+		P.temp.signRound2Messages[j] = NewSignRound2Message(pIDs[0], pIDs[j], c1JI, pi1JI, c2JI, pi2JI)
+	}
+	round1 := P.FirstRound().(*round1)
+	return &round3{&round2{round1}}, out
+}
@@ -31,6 +31,7 @@ var (
 		(*SignRound5Message)(nil),
 		(*SignRound6Message)(nil),
 		(*SignRound7Message)(nil),
+		(*SignBatchMessage)(nil),
 	}
 )
 
@@ -460,3 +461,36 @@ func (m *SignRound7Message_AbortData) UnmarshalSigmaIProof() (*zkp.ECDDHProof, e
 		Z:  new(big.Int).SetBytes(m.GetEcddhProofZ()),
 	}, nil
 }
+
+// ----- //
+
+// NewSignBatchMessage bundles one message of each session of a batch signing; a nil entry marks a session the sender has stopped.
+func NewSignBatchMessage(
+	to []*tss.PartyID,
+	from *tss.PartyID,
+	isBroadcast bool,
+	messages [][]byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          to,
+		IsBroadcast: isBroadcast,
+	}
+	content := &SignBatchMessage{
+		Messages: messages,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignBatchMessage) ValidateBasic() bool {
+	if m == nil || len(m.GetMessages()) == 0 {
+		return false
+	}
+	for _, bz := range m.GetMessages() {
+		if len(bz) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,393 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/paillier"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+const (
+	TaskNameOnline = "signing-online"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*OnlineLocalParty)(nil)
+var _ fmt.Stringer = (*OnlineLocalParty)(nil)
+
+type (
+	// OnlineLocalParty runs the online phase of one-round signing: it broadcasts this party's s_i for the message,
+	// collects the s_i of the other signers and sends the final signature through `end`.
+	// When the presignature failed its round 7 consistency check, the abort data of the state is broadcast instead of s_i,
+	// and the party ends with a Type 7 identified abort.
+	OnlineLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp onlineTempData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *SignatureData
+	}
+
+	onlineTempData struct {
+		signRound7Messages []tss.ParsedMessage
+
+		msg      *big.Int
+		ecdsaPub *ecdsa.PublicKey
+		state    *SignatureData
+		oneRound *SignatureData_OneRoundData // kept once s_i is computed, as the state drops it when the signature is made
+		sI       *big.Int
+		stateErr error // set when the state does not belong to this signing
+	}
+
+	onlineRound struct {
+		*tss.Parameters
+		temp    *onlineTempData
+		out     chan<- tss.Message
+		end     chan<- *SignatureData
+		ok      []bool
+		started bool
+		number  int
+	}
+	onlineFinalization struct {
+		*onlineRound
+	}
+)
+
+var (
+	_ tss.Round = (*onlineRound)(nil)
+	_ tss.Round = (*onlineFinalization)(nil)
+)
+
+// NewOnlineLocalParty constructs the party for the online phase of one-round signing of msg, with the state produced by
+// NewLocalPartyWithOneRoundSign. The parties of params must be the signers of that session.
+// The state must not be used again afterwards; see PresignatureStore.NewOnlineLocalParty to enforce this.
+func NewOnlineLocalParty(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	state *SignatureData,
+	out chan<- tss.Message,
+	end chan<- *SignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &OnlineLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		out:       out,
+		end:       end,
+	}
+	p.temp.signRound7Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.msg = msg
+	p.temp.state = state
+	if key.ECDSAPub != nil {
+		p.temp.ecdsaPub = &ecdsa.PublicKey{Curve: tss.EC(), X: key.ECDSAPub.X(), Y: key.ECDSAPub.Y()}
+	}
+	p.temp.stateErr = p.checkState()
+	return p
+}
+
+func (p *OnlineLocalParty) checkState() error {
+	if p.temp.msg == nil || p.temp.msg.Sign() < 0 || p.temp.msg.Cmp(tss.EC().Params().N) >= 0 {
+		return errors.New("hashed message is not valid")
+	}
+	if p.temp.ecdsaPub == nil {
+		return errors.New("the key data has no ECDSAPub")
+	}
+	oneRound := p.temp.state.GetOneRoundData()
+	if oneRound == nil {
+		return errors.New("the signature data has no OneRoundData; it may have been used already")
+	}
+	if int(oneRound.GetT()) != len(p.params.Parties().IDs())-1 {
+		return fmt.Errorf("the one-round data is for %d signers, not %d", oneRound.GetT()+1, len(p.params.Parties().IDs()))
+	}
+	if abortData := oneRound.GetAbortData(); abortData != nil && len(abortData.GetAbort().GetMuIJ()) != len(p.params.Parties().IDs()) {
+		return errors.New("the abort data of the one-round data is malformed")
+	}
+	return checkPresignatureSigners(presignatureSigners(oneRound), p.params.Parties().IDs())
+}
+
+func (p *OnlineLocalParty) FirstRound() tss.Round {
+	return &onlineRound{p.params, &p.temp, p.out, p.end, make([]bool, len(p.params.Parties().IDs())), false, 7}
+}
+
+func (p *OnlineLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskNameOnline, func(round tss.Round) *tss.Error {
+		if p.temp.stateErr != nil {
+			return round.WrapError(p.temp.stateErr)
+		}
+		return nil
+	})
+}
+
+func (p *OnlineLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskNameOnline)
+}
+
+// Close stops the party and overwrites s_i. Once the party has started, the k_i, r*sigma_i and abort data of the state
+// are also overwritten, as the presignature must not sign another message.
+func (p *OnlineLocalParty) Close() {
+	tss.BaseClose(p, func() {
+		if p.temp.oneRound == nil {
+			return
+		}
+		if p.temp.sI != nil {
+			common.WipeBigInt(p.temp.sI)
+		}
+		common.WipeByteSlices(p.temp.oneRound.GetKI(), p.temp.oneRound.GetRSigmaI())
+		wipeR7AbortData(p.temp.oneRound.GetAbortData().GetAbort())
+	})
+}
+
+func (p *OnlineLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *OnlineLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *OnlineLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	switch msg.Content().(type) {
+	case *SignRound7Message:
+		p.temp.signRound7Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *OnlineLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *OnlineLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// ----- //
+
+func (round *onlineRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.temp.oneRound = round.temp.state.GetOneRoundData()
+
+	// Identifiable Abort Type 7 deferred from round 7 of the presigning (GG20)
+	if abortData := round.temp.oneRound.GetAbortData(); abortData != nil {
+		common.Logger.Warnf("online round: the presignature failed its consistency check, entering Type 7 identified abort")
+		r7msg := NewSignRound7MessageAbort(round.PartyID(), abortData.GetAbort())
+		round.temp.signRound7Messages[i] = r7msg
+		round.out <- r7msg
+		return nil
+	}
+	round.temp.sI = FinalizeGetOurSigShare(round.temp.state, round.temp.msg)
+
+	r7msg := NewSignRound7MessageSuccess(round.PartyID(), round.temp.sI)
+	round.temp.signRound7Messages[i] = r7msg
+	round.out <- r7msg
+	return nil
+}
+
+func (round *onlineRound) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound7Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *onlineRound) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound7Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *onlineRound) NextRound() tss.Round {
+	round.started = false
+	return &onlineFinalization{round}
+}
+
+func (round *onlineRound) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *onlineRound) RoundNumber() int {
+	return round.number
+}
+
+func (round *onlineRound) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (round *onlineRound) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *onlineRound) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskNameOnline, round.number, round.PartyID(), culprits...)
+}
+
+func (round *onlineRound) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// ----- //
+
+func (round *onlineFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 8
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	Ps := round.Parties().IDs()
+
+	// Identifiable Abort Type 7 deferred from round 7 of the presigning (GG20)
+	if round.temp.oneRound.GetAbortData() != nil {
+		common.Logger.Infof("online round 8: Abort Type 7 code path triggered")
+		statement, err := oneRoundType7Statement(round.temp.oneRound, Ps, i)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		culprits := identifyType7Abort(Ps, i, round.temp.signRound7Messages, statement)
+		return round.WrapError(errors.New("presigning round 7 consistency check failed: y != bigSJ products, Type 7 identified abort, culprits known"), culprits...)
+	}
+
+	otherSIs := make(map[*tss.PartyID]*big.Int, len(Ps)-1)
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, msg := range round.temp.signRound7Messages {
+		if j == i {
+			continue
+		}
+		r7msgInner, ok := msg.Content().(*SignRound7Message).GetContent().(*SignRound7Message_SI)
+		if !ok {
+			// every signer checked the same S_j in round 7 of the presigning, and the check passed here
+			culprits = append(culprits, Ps[j])
+			continue
+		}
+		otherSIs[Ps[j]] = new(big.Int).SetBytes(r7msgInner.SI)
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(errors.New("unexpected abort message in the online phase of one-round signing"), culprits...)
+	}
+
+	// checks R^s_j = Rdash_j^m * S_j^r for each s_j, naming the parties whose share is wrong
+	data, _, err := FinalizeGetAndVerifyFinalSig(round.temp.state, round.temp.ecdsaPub, round.temp.msg, round.PartyID(), round.temp.sI, otherSIs)
+	if err != nil {
+		return round.WrapError(err.Cause(), err.Culprits()...)
+	}
+	round.end <- data
+	return nil
+}
+
+func (round *onlineFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *onlineFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *onlineFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// oneRoundType7Statement reads the public values of the presigning that the Type 7 abort data is checked against from the state
+func oneRoundType7Statement(oneRound *SignatureData_OneRoundData, Ps []*tss.PartyID, i int) (*type7Statement, error) {
+	abortData := oneRound.GetAbortData()
+	bigR, err := crypto.NewECPointFromProtobuf(oneRound.GetBigR())
+	if err != nil {
+		return nil, err
+	}
+	statement := &type7Statement{
+		paiPKs: make([]*paillier.PublicKey, len(Ps)),
+		cAs:    make([][]byte, len(Ps)),
+		c2JIs:  make([]*big.Int, len(Ps)),
+		bigWs:  make([]*crypto.ECPoint, len(Ps)),
+		bigR:   bigR,
+		bigSJ:  oneRound.GetBigSJ(),
+	}
+	for j, Pj := range Ps {
+		N, cA, c2JI := abortData.GetPaillierNJ()[Pj.Id], abortData.GetCJ()[Pj.Id], abortData.GetC2JI()[Pj.Id]
+		if !common.NonEmptyBytes(N) || !common.NonEmptyBytes(cA) || (j != i && !common.NonEmptyBytes(c2JI)) {
+			return nil, fmt.Errorf("the abort data of the state is incomplete for %s", Pj)
+		}
+		if statement.bigWs[j], err = crypto.NewECPointFromProtobuf(abortData.GetBigWJ()[Pj.Id]); err != nil {
+			return nil, fmt.Errorf("the abort data of the state is incomplete for %s: %v", Pj, err)
+		}
+		if _, err = crypto.NewECPointFromProtobuf(statement.bigSJ[Pj.Id]); err != nil {
+			return nil, fmt.Errorf("the state has no valid S_j for %s: %v", Pj, err)
+		}
+		statement.paiPKs[j] = &paillier.PublicKey{N: new(big.Int).SetBytes(N)}
+		statement.cAs[j] = cA
+		statement.c2JIs[j] = new(big.Int).SetBytes(c2JI)
+	}
+	return statement, nil
+}
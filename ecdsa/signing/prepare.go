@@ -71,3 +71,69 @@ func PrepareForSigning(i, pax int, xi *big.Int, ks []*big.Int, bigXs []*crypto.E
 	}
 	return
 }
+
+// PrepareForWeightedSigning is PrepareForSigning for a key from a weighted keygen, in which signer j holds the shares
+// with the ids ks[j] and the public shares bigXs[j]. The Lagrange-weighted shares xis of signer i are combined into a
+// single wi, so the signing rounds are still run once per signer.
+func PrepareForWeightedSigning(i int, xis []*big.Int, ks [][]*big.Int, bigXs [][]*crypto.ECPoint) (wi *big.Int, bigWs []*crypto.ECPoint, err error) {
+	modQ := common.ModInt(tss.EC().Params().N)
+	if len(ks) != len(bigXs) {
+		panic(fmt.Errorf("PrepareForWeightedSigning: len(ks) != len(bigXs) (%d != %d)", len(ks), len(bigXs)))
+	}
+	if len(ks) <= i {
+		panic(fmt.Errorf("PrepareForWeightedSigning: len(ks) <= i (%d <= %d)", len(ks), i))
+	}
+	if len(xis) != len(ks[i]) {
+		panic(fmt.Errorf("PrepareForWeightedSigning: len(xis) != len(ks[i]) (%d != %d)", len(xis), len(ks[i])))
+	}
+	allKs := make([]*big.Int, 0, len(ks))
+	for j := range ks {
+		if len(ks[j]) != len(bigXs[j]) || len(ks[j]) == 0 {
+			panic(fmt.Errorf("PrepareForWeightedSigning: ks[%d] and bigXs[%d] must have the same non-zero length", j, j))
+		}
+		allKs = append(allKs, ks[j]...)
+	}
+
+	// the Lagrange coefficient of each share id over all of the signers' share ids
+	coefs := make([]*big.Int, len(allKs))
+	for a, ka := range allKs {
+		coefs[a] = big.NewInt(1)
+		for c, kc := range allKs {
+			if a == c {
+				continue
+			}
+			if ka.Cmp(kc) == 0 {
+				err = fmt.Errorf("the indices of two shares are equal")
+				return
+			}
+			// big.Int Div is calculated as: a/b = a * modInv(b,q)
+			coefs[a] = modQ.Mul(coefs[a], modQ.Mul(kc, modQ.Inverse(new(big.Int).Sub(kc, ka))))
+		}
+	}
+
+	// w_j = sum of the Lagrange-weighted shares of signer j
+	wi = big.NewInt(0)
+	bigWs = make([]*crypto.ECPoint, len(ks))
+	a := 0
+	for j := range ks {
+		for k := range ks[j] {
+			if j == i {
+				wi = modQ.Add(wi, modQ.Mul(xis[k], coefs[a]))
+			}
+			term := bigXs[j][k].ScalarMult(coefs[a])
+			if bigWs[j] == nil {
+				bigWs[j] = term
+			} else if bigWs[j], err = bigWs[j].Add(term); err != nil {
+				return
+			}
+			a++
+		}
+	}
+
+	// assertion: g^w_i == W_i
+	if !crypto.ScalarBaseMult(tss.EC(), wi).Equals(bigWs[i]) {
+		err = fmt.Errorf("assertion failed: g^w_i == W_i")
+		return
+	}
+	return
+}
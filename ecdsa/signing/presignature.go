@@ -0,0 +1,402 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+var (
+	// ErrNoPresignature is returned when the store has no unused, unexpired presignature for a signer set.
+	ErrNoPresignature = errors.New("no unused presignature for this signer set")
+	// ErrAbortedPresignature is returned by SignShare for a presignature that failed its round 7 consistency check.
+	// The culprits are identified by running the online phase with its state, see NewOnlineLocalParty.
+	ErrAbortedPresignature = errors.New("the presignature failed its consistency check and cannot sign")
+)
+
+type (
+	// PresignatureStorage persists the presignatures of a PresignatureStore, grouped by the key of their signer set.
+	PresignatureStorage interface {
+		// Save stores a presignature record under its id, which is a hex string; an id that is stored or was ever
+		// consumed is an error, so that a presignature that is added again cannot sign a second message.
+		Save(setKey, id string, record []byte) error
+		// List returns the ids of the records of the signer set that were not consumed, in any order.
+		List(setKey string) (ids []string, err error)
+		// Consume removes the record id of the signer set and returns it, or returns ErrNoPresignature.
+		// It must be atomic and durable: once it returns, the record may never be returned again, even after a crash,
+		// and the id must be kept as consumed for Save.
+		Consume(setKey, id string) (record []byte, err error)
+	}
+
+	// PresignatureStore keeps the OneRoundData of one-round signing sessions and hands each one out at most once.
+	// Signing the same presignature twice reveals the private key, so its SignShare is the only safe way to use a stored one.
+	PresignatureStore struct {
+		storage PresignatureStorage
+		ttl     time.Duration
+		now     func() time.Time
+	}
+
+	presignatureRecord struct {
+		Signers   []string `json:"signers"`    // the ids of the signers, sorted
+		ExpiresAt int64    `json:"expires_at"` // unix seconds; 0 if the presignature does not expire
+		Data      []byte   `json:"data"`       // the SignatureData_OneRoundData protobuf
+	}
+)
+
+// NewPresignatureStore returns a store backed by storage. Presignatures expire ttl after they are added; a ttl of 0 never expires them.
+func NewPresignatureStore(storage PresignatureStorage, ttl time.Duration) *PresignatureStore {
+	return &PresignatureStore{storage: storage, ttl: ttl, now: time.Now}
+}
+
+// Add stores the OneRoundData of a one-round signing session (see NewLocalPartyWithOneRoundSign) among signers,
+// and returns its id. Every signer of the session gets the same id for its copy, see PresignatureID.
+func (s *PresignatureStore) Add(signers tss.SortedPartyIDs, data *SignatureData) (string, error) {
+	if data == nil || data.GetOneRoundData() == nil {
+		return "", errors.New("the signature data has no OneRoundData")
+	}
+	oneRound := data.GetOneRoundData()
+	ids := presignatureSigners(oneRound)
+	if err := checkPresignatureSigners(ids, signers); err != nil {
+		return "", err
+	}
+	id, err := PresignatureID(data)
+	if err != nil {
+		return "", err
+	}
+	bz, err := proto.Marshal(oneRound)
+	if err != nil {
+		return "", err
+	}
+	record := presignatureRecord{Signers: ids, Data: bz}
+	if s.ttl > 0 {
+		record.ExpiresAt = s.now().Add(s.ttl).Unix()
+	}
+	recordBz, err := json.Marshal(&record)
+	if err != nil {
+		return "", err
+	}
+	return id, s.storage.Save(presignatureSetKey(signers), id, recordBz)
+}
+
+// IDs returns the sorted ids of the presignatures of signers that were not consumed, including expired ones.
+// The signers must agree on the id to sign with, e.g. the first id that all of them hold; see also PresignatureID.
+func (s *PresignatureStore) IDs(signers tss.SortedPartyIDs) ([]string, error) {
+	ids, err := s.storage.List(presignatureSetKey(signers))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// SignShare consumes the presignature id of signers and returns our share s_i of msg, as FinalizeGetOurSigShare does,
+// with the state to give to FinalizeGetAndVerifyFinalSig. The presignature is consumed in the storage before the share is computed.
+// ErrNoPresignature is returned when it was consumed already or has expired, in which case it is discarded.
+// ErrAbortedPresignature is returned with the state when the presignature failed its round 7 consistency check.
+func (s *PresignatureStore) SignShare(signers tss.SortedPartyIDs, id string, msg *big.Int) (sI *big.Int, state *SignatureData, err error) {
+	if msg == nil {
+		return nil, nil, errors.New("msg must not be nil")
+	}
+	if state, err = s.consume(signers, id); err != nil {
+		return nil, nil, err
+	}
+	if state.GetOneRoundData().GetAbortData() != nil {
+		return nil, state, ErrAbortedPresignature
+	}
+	return FinalizeGetOurSigShare(state, msg), state, nil
+}
+
+// consume takes the unused, unexpired presignature id of signers out of the storage
+func (s *PresignatureStore) consume(signers tss.SortedPartyIDs, id string) (*SignatureData, error) {
+	recordBz, err := s.storage.Consume(presignatureSetKey(signers), id)
+	if err != nil {
+		return nil, err
+	}
+	var record presignatureRecord
+	if err = json.Unmarshal(recordBz, &record); err != nil {
+		return nil, fmt.Errorf("a stored presignature is corrupt: %v", err)
+	}
+	if record.ExpiresAt != 0 && s.now().Unix() >= record.ExpiresAt {
+		common.Logger.Debugf("discarded the expired presignature %s", id)
+		return nil, ErrNoPresignature
+	}
+	oneRound := new(SignatureData_OneRoundData)
+	if err = proto.Unmarshal(record.Data, oneRound); err != nil {
+		return nil, fmt.Errorf("a stored presignature is corrupt: %v", err)
+	}
+	// the storage key could collide or be tampered with; the presignature itself must be for these signers and this id
+	if err = checkPresignatureSigners(presignatureSigners(oneRound), signers); err != nil {
+		return nil, err
+	}
+	state := &SignatureData{OneRoundData: oneRound}
+	if storedID, err := PresignatureID(state); err != nil || storedID != id {
+		return nil, fmt.Errorf("the presignature stored as %s has another id", id)
+	}
+	return state, nil
+}
+
+// NewOnlineLocalParty consumes the presignature id of the parties of params and returns the party that runs the online phase
+// of signing msg with it (see NewOnlineLocalParty). The presignature is consumed in the storage before the party is returned.
+func (s *PresignatureStore) NewOnlineLocalParty(
+	id string,
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SignatureData,
+) (tss.Party, error) {
+	state, err := s.consume(params.Parties().IDs(), id)
+	if err != nil {
+		return nil, err
+	}
+	return NewOnlineLocalParty(msg, params, key, state, out, end), nil
+}
+
+// PresignatureID identifies the presignature in the OneRoundData of data by its R and its signers.
+// It is the same for the copy of every signer, so that they can agree on the presignature to sign with.
+func PresignatureID(data *SignatureData) (string, error) {
+	oneRound := data.GetOneRoundData()
+	if oneRound == nil || !oneRound.GetBigR().ValidateBasic() {
+		return "", errors.New("the signature data has no OneRoundData")
+	}
+	in := [][]byte{oneRound.GetBigR().GetX(), oneRound.GetBigR().GetY()}
+	for _, id := range presignatureSigners(oneRound) {
+		in = append(in, []byte(id))
+	}
+	return hex.EncodeToString(common.SHA512_256(in...)), nil
+}
+
+// ----- //
+
+// presignatureSetKey identifies a signer set by the keys of its parties
+func presignatureSetKey(signers tss.SortedPartyIDs) string {
+	keys := make([][]byte, len(signers))
+	for j, Pj := range signers {
+		keys[j] = Pj.Key
+	}
+	return hex.EncodeToString(common.SHA512_256(keys...))
+}
+
+func presignatureSigners(data *SignatureData_OneRoundData) []string {
+	ids := make([]string, 0, len(data.GetBigRBarJ()))
+	for id := range data.GetBigRBarJ() {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func checkPresignatureSigners(ids []string, signers tss.SortedPartyIDs) error {
+	want := make([]string, len(signers))
+	for j, Pj := range signers {
+		want[j] = Pj.Id
+	}
+	sort.Strings(want)
+	if strings.Join(ids, "\x00") != strings.Join(want, "\x00") {
+		return fmt.Errorf("the presignature was made by the signers %v, not %v", ids, want)
+	}
+	return nil
+}
+
+// ----- //
+
+type (
+	memoryPresignatureStorage struct {
+		mtx      sync.Mutex
+		sets     map[string]map[string][]byte
+		consumed map[string]map[string]struct{}
+	}
+
+	filePresignatureStorage struct {
+		dir string
+	}
+)
+
+const (
+	presignatureTmpSuffix      = ".tmp"
+	presignatureConsumedSuffix = ".consumed"
+)
+
+// NewMemoryPresignatureStorage returns a PresignatureStorage that keeps the presignatures in memory, for tests and short-lived processes.
+func NewMemoryPresignatureStorage() PresignatureStorage {
+	return &memoryPresignatureStorage{
+		sets:     make(map[string]map[string][]byte),
+		consumed: make(map[string]map[string]struct{}),
+	}
+}
+
+func (m *memoryPresignatureStorage) Save(setKey, id string, record []byte) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.sets[setKey] == nil {
+		m.sets[setKey] = make(map[string][]byte)
+	}
+	if _, ok := m.sets[setKey][id]; ok {
+		return fmt.Errorf("the presignature %s is already stored", id)
+	}
+	if _, ok := m.consumed[setKey][id]; ok {
+		return fmt.Errorf("the presignature %s was already consumed", id)
+	}
+	m.sets[setKey][id] = record
+	return nil
+}
+
+func (m *memoryPresignatureStorage) List(setKey string) ([]string, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	ids := make([]string, 0, len(m.sets[setKey]))
+	for id := range m.sets[setKey] {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (m *memoryPresignatureStorage) Consume(setKey, id string) ([]byte, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	record, ok := m.sets[setKey][id]
+	if !ok {
+		return nil, ErrNoPresignature
+	}
+	delete(m.sets[setKey], id)
+	if m.consumed[setKey] == nil {
+		m.consumed[setKey] = make(map[string]struct{})
+	}
+	m.consumed[setKey][id] = struct{}{}
+	return record, nil
+}
+
+// NewFilePresignatureStorage returns a PresignatureStorage that keeps each presignature in a file under dir.
+// A presignature is consumed by renaming its file, which is atomic, so several processes may share the directory.
+// The renamed file is emptied and kept as the record that the id was consumed.
+func NewFilePresignatureStorage(dir string) (PresignatureStorage, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &filePresignatureStorage{dir: dir}, nil
+}
+
+func (f *filePresignatureStorage) Save(setKey, id string, record []byte) error {
+	if err := checkPresignatureFileName(id); err != nil {
+		return err
+	}
+	setDir := filepath.Join(f.dir, setKey)
+	if err := os.MkdirAll(setDir, 0700); err != nil {
+		return err
+	}
+	path := filepath.Join(setDir, id)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("the presignature %s is already stored", id)
+	}
+	if _, err := os.Stat(path + presignatureConsumedSuffix); err == nil {
+		return fmt.Errorf("the presignature %s was already consumed", id)
+	}
+	tmp := path + presignatureTmpSuffix
+	if err := writeFileSync(tmp, record); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	return syncDir(setDir)
+}
+
+func (f *filePresignatureStorage) List(setKey string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(f.dir, setKey))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasSuffix(name, presignatureTmpSuffix) || strings.HasSuffix(name, presignatureConsumedSuffix) {
+			continue
+		}
+		ids = append(ids, name)
+	}
+	return ids, nil
+}
+
+func (f *filePresignatureStorage) Consume(setKey, id string) ([]byte, error) {
+	if err := checkPresignatureFileName(id); err != nil {
+		return nil, err
+	}
+	setDir := filepath.Join(f.dir, setKey)
+	path := filepath.Join(setDir, id)
+	consumed := path + presignatureConsumedSuffix
+	if err := os.Rename(path, consumed); err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoPresignature // never stored, or another process took it
+		}
+		return nil, err
+	}
+	// the rename must be on disk before the presignature is used
+	if err := syncDir(setDir); err != nil {
+		return nil, err
+	}
+	record, err := os.ReadFile(consumed)
+	if err != nil {
+		return nil, err
+	}
+	// keep the file so that the id cannot be saved again, but not the secret shares in it
+	if err = os.Truncate(consumed, 0); err != nil {
+		common.Logger.Warnf("could not empty the consumed presignature %s: %v", consumed, err)
+	}
+	return record, nil
+}
+
+// checkPresignatureFileName makes sure that an id names a file in the directory of its set
+func checkPresignatureFileName(id string) error {
+	if _, err := hex.DecodeString(id); err != nil || id == "" {
+		return fmt.Errorf("invalid presignature id %q", id)
+	}
+	return nil
+}
+
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,115 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// QuorumScore ranks a live party for SelectQuorum; parties with a higher score are preferred.
+// Every party must compute the same scores, e.g. from data they have agreed on, or they will not pick the same quorum.
+type QuorumScore func(pID *tss.PartyID) int64
+
+// SelectQuorum picks the signers among the `live` parties: the fewest parties that hold threshold+1 shares of `key`
+// between them. `parties` is the full party set of the keygen. The choice only depends on the live set and the scores,
+// so every party that is given the same ones picks the same quorum. Parties are taken by descending share count, so that
+// a party of a weighted key with many shares is preferred to several with fewer; among parties with as many shares,
+// by descending score when `optionalScore` is given, and ties are broken by ascending key.
+// The returned ids are new instances, sorted and indexed for the signing tss.PeerContext; `parties` and `live` are not modified.
+// An error is returned when `parties` does not match the key or the live parties do not hold enough shares.
+func SelectQuorum(
+	key keygen.LocalPartySaveData,
+	parties tss.SortedPartyIDs,
+	live []*tss.PartyID,
+	threshold int,
+	optionalScore ...QuorumScore,
+) (tss.SortedPartyIDs, error) {
+	if 1 < len(optionalScore) {
+		return nil, errors.New("SelectQuorum: expected 0 or 1 item in `optionalScore`")
+	}
+	if threshold < 0 {
+		return nil, fmt.Errorf("SelectQuorum: invalid threshold %d", threshold)
+	}
+	if len(parties) != len(key.Ks) {
+		return nil, fmt.Errorf("SelectQuorum: the key was made by %d parties, not %d", len(key.Ks), len(parties))
+	}
+	weights := shareCounts(key)
+	for _, Pj := range parties {
+		if _, ok := weights[partyKey(Pj)]; !ok {
+			return nil, fmt.Errorf("SelectQuorum: party %s does not hold a share of the key", Pj)
+		}
+	}
+
+	// the live parties of the keygen, once each; peers that do not hold a share are ignored
+	candidates := make(tss.SortedPartyIDs, 0, len(live))
+	seen := make(map[string]struct{}, len(live))
+	for _, pID := range live {
+		if pID == nil {
+			continue
+		}
+		Pj := parties.FindByKey(pID.KeyInt())
+		if Pj == nil {
+			continue
+		}
+		if _, dupe := seen[partyKey(Pj)]; dupe {
+			continue
+		}
+		seen[partyKey(Pj)] = struct{}{}
+		candidates = append(candidates, Pj)
+	}
+
+	scores := make(map[string]int64, len(candidates))
+	if 0 < len(optionalScore) && optionalScore[0] != nil {
+		for _, Pj := range candidates {
+			scores[partyKey(Pj)] = optionalScore[0](Pj)
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		if wa, wb := weights[partyKey(candidates[a])], weights[partyKey(candidates[b])]; wa != wb {
+			return wa > wb
+		}
+		if sa, sb := scores[partyKey(candidates[a])], scores[partyKey(candidates[b])]; sa != sb {
+			return sa > sb
+		}
+		return candidates[a].KeyInt().Cmp(candidates[b].KeyInt()) < 0
+	})
+
+	quorum := make(tss.UnSortedPartyIDs, 0, threshold+1)
+	for shares := 0; shares < threshold+1; {
+		if len(quorum) == len(candidates) {
+			return nil, fmt.Errorf("SelectQuorum: the %d live parties hold %d shares, but %d are needed", len(candidates), shares, threshold+1)
+		}
+		Pj := candidates[len(quorum)]
+		quorum = append(quorum, tss.NewPartyID(Pj.Id, Pj.Moniker, Pj.KeyInt()))
+		shares += weights[partyKey(Pj)]
+	}
+	return tss.SortPartyIDs(quorum), nil
+}
+
+// shareCounts returns the number of shares held by each party of the keygen of `key`, by partyKey
+func shareCounts(key keygen.LocalPartySaveData) map[string]int {
+	counts := make(map[string]int, len(key.Ks))
+	for j, kj := range key.Ks {
+		count := 1
+		if key.IsWeighted() {
+			count += len(key.ExtraKs[j])
+		}
+		counts[string(kj.Bytes())] = count
+	}
+	return counts
+}
+
+// partyKey is the form of a party's key that the maps of SelectQuorum and RetryCoordinator are keyed by.
+// It is the key without leading zero bytes, as in key.Ks, whatever the bytes of PartyID.Key are.
+func partyKey(pID *tss.PartyID) string {
+	return string(pID.KeyInt().Bytes())
+}
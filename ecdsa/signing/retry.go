@@ -0,0 +1,156 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+type (
+	// RetryCoordinator chooses the signers of successive attempts to sign a message, leaving out the parties blamed
+	// in the identified aborts of the previous attempts. Every party should be told of the same aborts, e.g. the culprits
+	// of the round 6 or round 7 abort that all the honest parties agree on, so that they all choose the same signers.
+	RetryCoordinator struct {
+		key       keygen.LocalPartySaveData
+		parties   tss.SortedPartyIDs
+		threshold int
+		score     []QuorumScore
+
+		excluded map[string]*tss.PartyID
+		attempts []*SigningAttempt
+	}
+
+	// SigningAttempt records one attempt of a RetryCoordinator
+	SigningAttempt struct {
+		Signers tss.SortedPartyIDs
+		// Err is nil until the attempt is reported as failed
+		Err *tss.Error
+		// Culprits are the parties of the keygen that were excluded because of Err
+		Culprits []*tss.PartyID
+	}
+)
+
+// NewRetryCoordinator constructs a RetryCoordinator for `key`, whose keygen was run by `parties`.
+// The signers of each attempt are chosen with SelectQuorum and `optionalScore`.
+func NewRetryCoordinator(
+	key keygen.LocalPartySaveData,
+	parties tss.SortedPartyIDs,
+	threshold int,
+	optionalScore ...QuorumScore,
+) *RetryCoordinator {
+	return &RetryCoordinator{
+		key:       key,
+		parties:   parties,
+		threshold: threshold,
+		score:     optionalScore,
+		excluded:  make(map[string]*tss.PartyID),
+	}
+}
+
+// NextQuorum chooses the signers of the next attempt among the `live` parties that have not been blamed yet
+func (c *RetryCoordinator) NextQuorum(live []*tss.PartyID) (tss.SortedPartyIDs, error) {
+	if n := len(c.attempts); 0 < n && c.attempts[n-1].Err == nil {
+		return nil, errors.New("the previous attempt has not been reported as failed")
+	}
+	eligible := make([]*tss.PartyID, 0, len(live))
+	for _, pID := range live {
+		if pID == nil {
+			continue
+		}
+		if _, blamed := c.excluded[partyKey(pID)]; !blamed {
+			eligible = append(eligible, pID)
+		}
+	}
+	signers, err := SelectQuorum(c.key, c.parties, eligible, c.threshold, c.score...)
+	if err != nil {
+		return nil, fmt.Errorf("attempt %d: %v", len(c.attempts)+1, err)
+	}
+	c.attempts = append(c.attempts, &SigningAttempt{Signers: signers})
+	return signers, nil
+}
+
+// Abort reports that the last attempt failed with `err` and excludes its culprits from the next attempts.
+// It returns an error when there should be no other attempt: the abort names no culprit, or the parties that
+// have not been blamed no longer hold threshold+1 shares.
+func (c *RetryCoordinator) Abort(err *tss.Error) error {
+	n := len(c.attempts)
+	if n == 0 || c.attempts[n-1].Err != nil {
+		return errors.New("no attempt is in progress")
+	}
+	if err == nil {
+		return errors.New("Abort() called with a nil error")
+	}
+	attempt := c.attempts[n-1]
+	attempt.Err = err
+	for _, culprit := range err.Culprits() {
+		if culprit == nil || attempt.Signers.FindByKey(culprit.KeyInt()) == nil {
+			continue // only a signer of this attempt can be blamed for it
+		}
+		Pj := c.parties.FindByKey(culprit.KeyInt())
+		if _, blamed := c.excluded[partyKey(Pj)]; blamed {
+			continue
+		}
+		c.excluded[partyKey(Pj)] = Pj
+		attempt.Culprits = append(attempt.Culprits, Pj)
+	}
+	if len(attempt.Culprits) == 0 {
+		return fmt.Errorf("attempt %d failed without a culprit, not retrying: %v", n, err)
+	}
+	shares := 0
+	for k, count := range shareCounts(c.key) {
+		if _, blamed := c.excluded[k]; !blamed {
+			shares += count
+		}
+	}
+	if shares < c.threshold+1 {
+		return fmt.Errorf("after %d attempts the %d parties that were not blamed hold %d shares, but %d are needed: %v",
+			n, len(c.parties)-len(c.excluded), shares, c.threshold+1, err)
+	}
+	return nil
+}
+
+// Run signs with `sign` until an attempt succeeds, choosing each attempt's signers among the `live` parties with
+// NextQuorum and reporting each failure with Abort. It returns the error that ended the retries otherwise;
+// Attempts() has the history in either case.
+func (c *RetryCoordinator) Run(
+	live []*tss.PartyID,
+	sign func(signers tss.SortedPartyIDs) (*SignatureData, *tss.Error),
+) (*SignatureData, error) {
+	for {
+		signers, err := c.NextQuorum(live)
+		if err != nil {
+			return nil, err
+		}
+		data, tssErr := sign(signers)
+		if tssErr == nil {
+			return data, nil
+		}
+		if err = c.Abort(tssErr); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Attempts returns the attempts made so far, oldest first
+func (c *RetryCoordinator) Attempts() []*SigningAttempt {
+	return append([]*SigningAttempt{}, c.attempts...)
+}
+
+// Excluded returns the parties of the keygen that have been blamed so far, sorted by key
+func (c *RetryCoordinator) Excluded() tss.SortedPartyIDs {
+	excluded := make(tss.SortedPartyIDs, 0, len(c.excluded))
+	for _, Pj := range c.parties {
+		if _, blamed := c.excluded[partyKey(Pj)]; blamed {
+			excluded = append(excluded, Pj)
+		}
+	}
+	return excluded
+}
@@ -53,6 +53,7 @@ func (round *round1) Start() *tss.Error {
 
 	gammaI := common.GetRandomPositiveInt(tss.EC().Params().N)
 	kI := common.GetRandomPositiveInt(tss.EC().Params().N)
+	defer common.WipeBigInt(kI)
 	round.temp.gammaI = gammaI
 	round.temp.r5AbortData.GammaI = gammaI.Bytes()
 
@@ -70,11 +71,11 @@ func (round *round1) Start() *tss.Error {
 	}
 
 	// set "k"-related temporary variables, also used for identified aborts later in the protocol
+	// each gets its own copy, so that Close can wipe the abort data without touching the one-round data
 	{
-		kIBz := kI.Bytes()
-		round.temp.KI = kIBz // now part of the OneRoundData struct
-		round.temp.r5AbortData.KI = kIBz
-		round.temp.r7AbortData.KI = kIBz
+		round.temp.KI = kI.Bytes() // now part of the OneRoundData struct
+		round.temp.r5AbortData.KI = kI.Bytes()
+		round.temp.r7AbortData.KI = kI.Bytes()
 		round.temp.cAKI = cA // used for the ZK proof in round 5
 		round.temp.rAKI = rA
 		round.temp.r7AbortData.KRandI = rA.Bytes()
@@ -138,6 +139,9 @@ func (round *round1) NextRound() tss.Round {
 func (round *round1) prepare() error {
 	i := round.PartyID().Index
 	xi, ks, bigXs := round.key.Xi, round.key.Ks, round.key.BigXj
+	if round.key.IsWeighted() {
+		return round.prepareWeighted()
+	}
 	if round.Threshold()+1 > len(ks) {
 		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
@@ -149,3 +153,25 @@ func (round *round1) prepare() error {
 	}
 	return nil
 }
+
+// helper to call into PrepareForWeightedSigning() for a key from a weighted keygen
+func (round *round1) prepareWeighted() error {
+	i := round.PartyID().Index
+	key := round.key
+	if allKs, _ := key.AllShareIDs(); round.Threshold()+1 > len(allKs) {
+		return fmt.Errorf("t+1=%d is not satisfied by the share count of %d", round.Threshold()+1, len(allKs))
+	}
+	ks, bigXs := make([][]*big.Int, len(key.Ks)), make([][]*crypto.ECPoint, len(key.Ks))
+	for j := range key.Ks {
+		ks[j] = append([]*big.Int{key.Ks[j]}, key.ExtraKs[j]...)
+		bigXs[j] = append([]*crypto.ECPoint{key.BigXj[j]}, key.ExtraBigXj[j]...)
+	}
+	xis := append([]*big.Int{key.Xi}, key.ExtraXi...)
+	wI, bigWs, err := PrepareForWeightedSigning(i, xis, ks, bigXs)
+	if err != nil {
+		return err
+	}
+	round.temp.wI = wI
+	round.temp.bigWs = bigWs
+	return nil
+}
@@ -8,7 +8,6 @@ package signing
 
 import (
 	"errors"
-	"sync"
 
 	errorspkg "github.com/pkg/errors"
 
@@ -27,22 +26,22 @@ func (round *round2) Start() *tss.Error {
 	i := round.PartyID().Index
 	round.ok[i] = true
 
-	errChs := make(chan *tss.Error, (len(round.Parties().IDs())-1)*2)
-	wg := sync.WaitGroup{}
-	wg.Add((len(round.Parties().IDs()) - 1) * 2)
-	for j, Pj := range round.Parties().IDs() {
+	// Bob_mid and Bob_mid_wc for each peer j are tasks 2j and 2j+1, run on a bounded number of goroutines
+	Ps := round.Parties().IDs()
+	errs := make([]*tss.Error, len(Ps)*2)
+	runTasks(round.Concurrency(), len(Ps)*2, func(k int) {
+		j, Pj := k/2, Ps[k/2]
 		if j == i {
-			continue
+			return
 		}
-		// Bob_mid
-		go func(j int, Pj *tss.PartyID) {
-			defer wg.Done()
-			r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
-			rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
-			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "MtA: UnmarshalRangeProofAlice failed"), Pj)
-				return
-			}
+		r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
+		rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
+		if err != nil {
+			errs[k] = round.WrapError(errorspkg.Wrapf(err, "MtA: UnmarshalRangeProofAlice failed"), Pj)
+			return
+		}
+		if k%2 == 0 {
+			// Bob_mid
 			betaJI, c1JI, _, pi1JI, err := mta.BobMid(
 				round.key.PaillierPKs[j],
 				rangeProofAliceJ,
@@ -55,7 +54,7 @@ func (round *round2) Start() *tss.Error {
 				round.key.H1j[i],
 				round.key.H2j[i])
 			if err != nil {
-				errChs <- round.WrapError(err, Pj)
+				errs[k] = round.WrapError(err, Pj)
 				return
 			}
 			// should be thread safe as these are pre-allocated
@@ -63,45 +62,30 @@ func (round *round2) Start() *tss.Error {
 			round.temp.r5AbortData.BetaJI[j] = betaJI.Bytes()
 			round.temp.pI1JIs[j] = pi1JI
 			round.temp.c1JIs[j] = c1JI
-		}(j, Pj)
+			return
+		}
 		// Bob_mid_wc
-		go func(j int, Pj *tss.PartyID) {
-			defer wg.Done()
-			r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
-			rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
-			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "MtA: UnmarshalRangeProofAlice failed"), Pj)
-				return
-			}
-			vJI, c2JI, pi2JI, err := mta.BobMidWC(
-				round.key.PaillierPKs[j],
-				rangeProofAliceJ,
-				round.temp.wI,
-				r1msg.UnmarshalC(),
-				round.key.NTildej[j],
-				round.key.H1j[j],
-				round.key.H2j[j],
-				round.key.NTildej[i],
-				round.key.H1j[i],
-				round.key.H2j[i],
-				round.temp.bigWs[i])
-			if err != nil {
-				errChs <- round.WrapError(err, Pj)
-				return
-			}
-			round.temp.vJIs[j] = vJI
-			round.temp.pI2JIs[j] = pi2JI
-			round.temp.c2JIs[j] = c2JI
-		}(j, Pj)
-	}
-	// consume error channels; wait for goroutines
-	wg.Wait()
-	close(errChs)
-	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
-	for err := range errChs {
-		culprits = append(culprits, err.Culprits()...)
-	}
-	if len(culprits) > 0 {
+		vJI, c2JI, pi2JI, err := mta.BobMidWC(
+			round.key.PaillierPKs[j],
+			rangeProofAliceJ,
+			round.temp.wI,
+			r1msg.UnmarshalC(),
+			round.key.NTildej[j],
+			round.key.H1j[j],
+			round.key.H2j[j],
+			round.key.NTildej[i],
+			round.key.H1j[i],
+			round.key.H2j[i],
+			round.temp.bigWs[i])
+		if err != nil {
+			errs[k] = round.WrapError(err, Pj)
+			return
+		}
+		round.temp.vJIs[j] = vJI
+		round.temp.pI2JIs[j] = pi2JI
+		round.temp.c2JIs[j] = c2JI
+	})
+	if culprits := collectCulprits(errs); len(culprits) > 0 {
 		return round.WrapError(errors.New("MtA: failed to verify Bob_mid or Bob_mid_wc"), culprits...)
 	}
 	// create and send messages
@@ -9,7 +9,6 @@ package signing
 import (
 	"errors"
 	"math/big"
-	"sync"
 
 	errorspkg "github.com/pkg/errors"
 
@@ -36,20 +35,20 @@ func (round *round3) Start() *tss.Error {
 	muIJRecs := make([]*big.Int, len(round.Parties().IDs())) // raw recovered
 	muRandIJ := make([]*big.Int, len(round.Parties().IDs()))
 
-	errChs := make(chan *tss.Error, (len(round.Parties().IDs())-1)*2)
-	wg := sync.WaitGroup{}
-	wg.Add((len(round.Parties().IDs()) - 1) * 2)
-	for j, Pj := range round.Parties().IDs() {
+	// Alice_end and Alice_end_wc for each peer j are tasks 2j and 2j+1, run on a bounded number of goroutines
+	Ps := round.Parties().IDs()
+	errs := make([]*tss.Error, len(Ps)*2)
+	runTasks(round.Concurrency(), len(Ps)*2, func(k int) {
+		j, Pj := k/2, Ps[k/2]
 		if j == i {
-			continue
+			return
 		}
-		// Alice_end
-		go func(j int, Pj *tss.PartyID) {
-			defer wg.Done()
-			r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
+		r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
+		if k%2 == 0 {
+			// Alice_end
 			proofBob, err := r2msg.UnmarshalProofBob()
 			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "MtA: UnmarshalProofBob failed"), Pj)
+				errs[k] = round.WrapError(errorspkg.Wrapf(err, "MtA: UnmarshalProofBob failed"), Pj)
 				return
 			}
 			alphaIJ, err := mta.AliceEnd(
@@ -62,49 +61,38 @@ func (round *round3) Start() *tss.Error {
 				round.key.NTildej[i],
 				round.key.PaillierSK)
 			if err != nil {
-				errChs <- round.WrapError(err, Pj)
+				errs[k] = round.WrapError(err, Pj)
 				return
 			}
 			alphaIJs[j] = alphaIJ
 			round.temp.r5AbortData.AlphaIJ[j] = alphaIJ.Bytes()
-		}(j, Pj)
+			return
+		}
 		// Alice_end_wc
-		go func(j int, Pj *tss.PartyID) {
-			defer wg.Done()
-			r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
-			proofBobWC, err := r2msg.UnmarshalProofBobWC()
-			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "MtA: UnmarshalProofBobWC failed"), Pj)
-				return
-			}
-			muIJ, muIJRec, muIJRand, err := mta.AliceEndWC(
-				round.key.PaillierPKs[i],
-				proofBobWC,
-				round.temp.bigWs[j],
-				round.temp.c1Is[j],
-				new(big.Int).SetBytes(r2msg.GetC2()),
-				round.key.NTildej[i],
-				round.key.H1j[i],
-				round.key.H2j[i],
-				round.key.PaillierSK)
-			if err != nil {
-				errChs <- round.WrapError(err, Pj)
-				return
-			}
-			muIJs[j] = muIJ       // mod q'd
-			muIJRecs[j] = muIJRec // raw recovered
-			muRandIJ[j] = muIJRand
-		}(j, Pj)
-	}
-
-	// consume error channels; wait for goroutines
-	wg.Wait()
-	close(errChs)
-	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
-	for err := range errChs {
-		culprits = append(culprits, err.Culprits()...)
-	}
-	if len(culprits) > 0 {
+		proofBobWC, err := r2msg.UnmarshalProofBobWC()
+		if err != nil {
+			errs[k] = round.WrapError(errorspkg.Wrapf(err, "MtA: UnmarshalProofBobWC failed"), Pj)
+			return
+		}
+		muIJ, muIJRec, muIJRand, err := mta.AliceEndWC(
+			round.key.PaillierPKs[i],
+			proofBobWC,
+			round.temp.bigWs[j],
+			round.temp.c1Is[j],
+			new(big.Int).SetBytes(r2msg.GetC2()),
+			round.key.NTildej[i],
+			round.key.H1j[i],
+			round.key.H2j[i],
+			round.key.PaillierSK)
+		if err != nil {
+			errs[k] = round.WrapError(err, Pj)
+			return
+		}
+		muIJs[j] = muIJ       // mod q'd
+		muIJRecs[j] = muIJRec // raw recovered
+		muRandIJ[j] = muIJRand
+	})
+	if culprits := collectCulprits(errs); len(culprits) > 0 {
 		return round.WrapError(errors.New("failed to calculate Alice_end or Alice_end_wc"), culprits...)
 	}
 	// for identifying aborts in round 7: muIJs, revealed during Type 7 identified abort
@@ -119,8 +107,7 @@ func (round *round3) Start() *tss.Error {
 	sigmaI := modN.Mul(kI, round.temp.wI)
 
 	// clear wI from temp memory
-	round.temp.wI.Set(zero)
-	round.temp.wI = zero
+	common.WipeBigInts(round.temp.wI, kI)
 
 	for j := range round.Parties().IDs() {
 		if j == i {
@@ -132,7 +119,13 @@ func (round *round3) Start() *tss.Error {
 		deltaI.Mod(deltaI, q)
 		sigmaI.Mod(sigmaI, q)
 	}
-	// nil sensitive data for gc
+	// wipe sensitive data, not used from here
+	common.WipeBigInts(alphaIJs...)
+	common.WipeBigInts(muIJs...)
+	common.WipeBigInts(muIJRecs...)
+	common.WipeBigInts(muRandIJ...)
+	common.WipeBigInts(round.temp.betas...)
+	common.WipeBigInts(round.temp.vJIs...)
 	round.temp.betas, round.temp.vJIs = nil, nil
 
 	// gg20: calculate T_i = g^sigma_i h^l_i
@@ -79,6 +79,7 @@ func (round *round5) Start() *tss.Error {
 
 	// all parties broadcast Rdash_i = k_i * R
 	kI := new(big.Int).SetBytes(round.temp.KI)
+	defer common.WipeBigInt(kI)
 	bigRBarI := bigR.ScalarMult(kI)
 
 	// compute ZK proof of consistency between R_i and E_i(k_i)
@@ -33,10 +33,7 @@ func (round *round6) Start() *tss.Error {
 	bigR, _ := crypto.NewECPointFromProtobuf(round.temp.BigR)
 
 	sigmaI := round.temp.sigmaI
-	defer func() {
-		round.temp.sigmaI.Set(zero)
-		round.temp.sigmaI = zero
-	}()
+	defer common.WipeBigInt(round.temp.sigmaI)
 
 	errs := make(map[*tss.PartyID]error)
 	bigRBarJProducts := (*crypto.ECPoint)(nil)
@@ -107,7 +104,8 @@ func (round *round6) Start() *tss.Error {
 			return nil
 		}
 	}
-	// wipe sensitive data for gc, not used from here
+	// wipe sensitive data, not used from here
+	wipeR5AbortData(&round.temp.r5AbortData)
 	round.temp.r5AbortData = SignRound6Message_AbortData{}
 
 	round.temp.BigRBarJ = BigRBarJ
@@ -134,7 +132,7 @@ func (round *round6) Start() *tss.Error {
 		return round.WrapError(err, Pi)
 	}
 	// wipe sensitive data for gc
-	round.temp.lI.Set(zero)
+	common.WipeBigInt(round.temp.lI)
 	round.temp.TI, round.temp.lI = nil, nil
 
 	r6msg := NewSignRound6MessageSuccess(Pi, bigSI, stPf)
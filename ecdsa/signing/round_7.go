@@ -12,6 +12,7 @@ import (
 	"math/big"
 
 	"github.com/hashicorp/go-multierror"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/crypto"
@@ -160,16 +161,23 @@ func (round *round7) Start() *tss.Error {
 	round.temp.rI = bigR
 	round.temp.BigSJ = bigSJ
 	if y := round.key.ECDSAPub; !bigSJProducts.Equals(y) {
-		round.abortingT7 = true
-		common.Logger.Warnf("round 7: consistency check failed: y != bigSJ products, entering Type 7 identified abort")
+		// In one-round mode the abort is deferred to the online phase, where the abort data is revealed in place of s_i.
+		// Every signer has checked the same S_j, so all of them reach the online phase with abort data.
+		if round.temp.m == nil {
+			common.Logger.Warnf("round 7: consistency check failed: y != bigSJ products, deferring the Type 7 identified abort to the online phase")
+			round.temp.AbortData = round.type7AbortData()
+		} else {
+			round.abortingT7 = true
+			common.Logger.Warnf("round 7: consistency check failed: y != bigSJ products, entering Type 7 identified abort")
 
-		// If we abort here, one-round mode won't matter now - we will proceed to round "8" anyway.
-		r7msg := NewSignRound7MessageAbort(Pi, &round.temp.r7AbortData)
-		round.temp.signRound7Messages[i] = r7msg
-		round.out <- r7msg
-		return nil
+			r7msg := NewSignRound7MessageAbort(Pi, &round.temp.r7AbortData)
+			round.temp.signRound7Messages[i] = r7msg
+			round.out <- r7msg
+			return nil
+		}
 	}
-	// wipe sensitive data for gc, not used from here
+	// wipe sensitive data, not used from here
+	wipeR7AbortData(&round.temp.r7AbortData)
 	round.temp.r7AbortData = SignRound7Message_AbortData{}
 
 	// PRE-PROCESSING FINISHED
@@ -218,10 +226,34 @@ func (round *round7) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round7) NextRound() tss.Round {
 	// If we are in one-round signing mode (msg is nil), we will exit out with the current state here and there are no further rounds.
-	if !round.abortingT7 && round.temp.m == nil {
+	if round.temp.m == nil {
 		return nil
 	}
 	// Continuing the full online protocol.
 	round.started = false
 	return &finalization{round}
 }
+
+// type7AbortData copies the abort data of this party and the public values that the online phase checks it against
+func (round *round7) type7AbortData() *SignatureData_AbortData {
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	data := &SignatureData_AbortData{
+		Abort:      proto.Clone(&round.temp.r7AbortData).(*SignRound7Message_AbortData),
+		PaillierNJ: make(map[string][]byte, len(Ps)),
+		CJ:         make(map[string][]byte, len(Ps)),
+		C2JI:       make(map[string][]byte, len(Ps)-1),
+		BigWJ:      make(map[string]*common.ECPoint, len(Ps)),
+	}
+	for j, Pj := range Ps {
+		if j == i {
+			data.PaillierNJ[Pj.Id] = round.key.PaillierSK.N.Bytes()
+		} else {
+			data.PaillierNJ[Pj.Id] = round.key.PaillierPKs[j].N.Bytes()
+			data.C2JI[Pj.Id] = round.temp.c2JIs[j].Bytes()
+		}
+		data.CJ[Pj.Id] = round.temp.signRound1Message1s[j].Content().(*SignRound1Message1).GetC()
+		data.BigWJ[Pj.Id] = round.temp.bigWs[j].ToProtobufPoint()
+	}
+	return data
+}
@@ -0,0 +1,97 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Verification of the signatures returned in SignatureData.Signature outside of a signing session,
+// and their conversion to and from the ASN.1 DER form of RFC 3279 used by X.509, TLS and Go's crypto/ecdsa.
+
+// derSignature is the ASN.1 ECDSA-Sig-Value: SEQUENCE { r INTEGER, s INTEGER }
+type derSignature struct {
+	R, S *big.Int
+}
+
+// VerifySignature checks sig against msg, the message that was given to NewLocalParty, and pub, the ECDSAPub of the key data.
+// The signature is checked on the curve of pub; both a low and a high S are accepted, as by crypto/ecdsa.
+func VerifySignature(pub *crypto.ECPoint, msg []byte, sig *common.ECSignature) error {
+	if pub == nil {
+		return errors.New("the public key is nil")
+	}
+	if sig == nil || len(sig.GetR()) == 0 || len(sig.GetS()) == 0 {
+		return errors.New("the signature is incomplete")
+	}
+	r, s := new(big.Int).SetBytes(sig.GetR()), new(big.Int).SetBytes(sig.GetS())
+	if err := checkScalars(r, s, pub.Curve().Params().N); err != nil {
+		return err
+	}
+	if len(sig.GetM()) != 0 && new(big.Int).SetBytes(sig.GetM()).Cmp(new(big.Int).SetBytes(msg)) != 0 {
+		return errors.New("the signature is of another message")
+	}
+	pk := &ecdsa.PublicKey{Curve: pub.Curve(), X: pub.X(), Y: pub.Y()}
+	if !ecdsa.Verify(pk, msg, r, s) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
+
+// EncodeDER returns the DER encoding of the r and s of a signature.
+func EncodeDER(sig *common.ECSignature) ([]byte, error) {
+	if sig == nil || len(sig.GetR()) == 0 || len(sig.GetS()) == 0 {
+		return nil, errors.New("the signature is incomplete")
+	}
+	r, s := new(big.Int).SetBytes(sig.GetR()), new(big.Int).SetBytes(sig.GetS())
+	if err := checkScalars(r, s, tss.EC().Params().N); err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(derSignature{r, s})
+}
+
+// ParseDER returns the signature of a DER encoding, with R, S and Signature set as by the finalize step.
+// The parsing is strict: a BER form, an integer with extra leading bytes, a negative or out of range integer,
+// or trailing data is refused, so each signature has exactly one accepted encoding.
+// The recovery ID and the message cannot be recovered from the encoding and are left empty.
+func ParseDER(der []byte) (*common.ECSignature, error) {
+	var parsed derSignature
+	rest, err := asn1.Unmarshal(der, &parsed)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after the DER signature")
+	}
+	if err = checkScalars(parsed.R, parsed.S, tss.EC().Params().N); err != nil {
+		return nil, err
+	}
+	// encoding/asn1 accepts some non-canonical forms, so the signature must encode back to the same bytes
+	if canonical, err := asn1.Marshal(parsed); err != nil || !bytes.Equal(canonical, der) {
+		return nil, errors.New("the DER signature is not in its canonical form")
+	}
+	signature := new(common.ECSignature)
+	signature.R, signature.S = parsed.R.Bytes(), parsed.S.Bytes()
+	signature.Signature = append(parsed.R.Bytes(), parsed.S.Bytes()...)
+	return signature, nil
+}
+
+// ----- //
+
+func checkScalars(r, s, N *big.Int) error {
+	if r.Sign() <= 0 || r.Cmp(N) >= 0 || s.Sign() <= 0 || s.Cmp(N) >= 0 {
+		return errors.New("the signature r and s must be in [1, N-1]")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"sync"
+
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// runTasks calls task(k) for each k in [0, count) on at most workers goroutines, and returns once every call has returned.
+func runTasks(workers, count int, task func(k int)) {
+	if count < workers {
+		workers = count
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	next := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for k := range next {
+				task(k)
+			}
+		}()
+	}
+	for k := 0; k < count; k++ {
+		next <- k
+	}
+	close(next)
+	wg.Wait()
+}
+
+// collectCulprits returns the culprits of the errors in the order of the errors, each party once,
+// so that the same failures give the same error whatever order the tasks finished in.
+func collectCulprits(errs []*tss.Error) []*tss.PartyID {
+	culprits := make([]*tss.PartyID, 0, len(errs))
+	seen := make(map[string]bool, len(errs))
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		for _, culprit := range err.Culprits() {
+			if key := string(culprit.GetKey()); !seen[key] {
+				seen[key] = true
+				culprits = append(culprits, culprit)
+			}
+		}
+	}
+	return culprits
+}
@@ -0,0 +1,27 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+)
+
+// PubKeyBytes returns the 32-byte Ed25519 encoding of the EDDSAPub.
+func (save LocalPartySaveData) PubKeyBytes() ([]byte, error) {
+	if save.EDDSAPub == nil {
+		return nil, errors.New("save data has no EDDSAPub")
+	}
+	return save.EDDSAPub.Ed25519Bytes()
+}
+
+// SolanaAddress returns the base58 Solana address of the EDDSAPub.
+func (save LocalPartySaveData) SolanaAddress() (string, error) {
+	if save.EDDSAPub == nil {
+		return "", errors.New("save data has no EDDSAPub")
+	}
+	return save.EDDSAPub.SolanaAddress()
+}
@@ -0,0 +1,108 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+const (
+	// ChainCodeLen is the length of the chain code of an ExtendedPubKey
+	ChainCodeLen = 32
+
+	// HardenedKeyStart is the first hardened index; a hardened child needs the whole secret key and cannot be derived
+	HardenedKeyStart = uint32(0x80000000)
+)
+
+// ExtendedPubKey is an Ed25519 public key with the chain code that its children are derived from.
+// The chain code of the root key is chosen when the key is made, for example at random, and is kept with the key data;
+// every party must use the same one.
+type ExtendedPubKey struct {
+	PubKey    *crypto.ECPoint
+	ChainCode []byte
+}
+
+// DeriveChildPubKey derives the key at path from parent with the public child key derivation of BIP32-Ed25519
+// (Khovratovich and Law, 2017). Only non-hardened indexes can be derived, as the derivation needs the public key alone.
+// For each index i < 2^31 of the path, with A the 32-byte encoding of the parent key and c its chain code:
+//
+//	Z = HMAC-SHA512(c, 0x02 || A || le32(i)), and the tweak is 8 * le(Z[0:28])
+//	the child key is A + tweak*G and its chain code is HMAC-SHA512(c, 0x03 || A || le32(i))[32:64]
+//
+// delta is the sum of the tweaks, so that child = parent + delta*G. The parties keep their shares of the root key,
+// and the signers give delta to signing.NewLocalPartyWithKDD to sign with the child key.
+// An empty path returns the parent and 0.
+func DeriveChildPubKey(parent *ExtendedPubKey, path []uint32) (delta *big.Int, child *ExtendedPubKey, err error) {
+	if parent == nil || parent.PubKey == nil || !tss.SameCurve(parent.PubKey.Curve(), edwards.Edwards()) {
+		return nil, nil, errors.New("DeriveChildPubKey: the parent is not an Ed25519 key")
+	}
+	if len(parent.ChainCode) != ChainCodeLen {
+		return nil, nil, fmt.Errorf("DeriveChildPubKey: the chain code must be %d bytes, got %d", ChainCodeLen, len(parent.ChainCode))
+	}
+	modN := common.ModInt(edwards.Edwards().Params().N)
+	delta = big.NewInt(0)
+	child = &ExtendedPubKey{PubKey: parent.PubKey, ChainCode: append([]byte{}, parent.ChainCode...)}
+	for _, index := range path {
+		if index >= HardenedKeyStart {
+			return nil, nil, fmt.Errorf("DeriveChildPubKey: the hardened index %d cannot be derived from a public key", index)
+		}
+		var tweak *big.Int
+		if tweak, child, err = deriveChildStep(child, index); err != nil {
+			return nil, nil, err
+		}
+		delta = modN.Add(delta, tweak)
+	}
+	return delta, child, nil
+}
+
+// deriveChildStep derives the child at index of parent, and returns the tweak that was added to the key
+func deriveChildStep(parent *ExtendedPubKey, index uint32) (*big.Int, *ExtendedPubKey, error) {
+	pubBz, err := parent.PubKey.Ed25519Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	data := make([]byte, 1+len(pubBz)+4)
+	data[0] = 0x02
+	copy(data[1:], pubBz)
+	binary.LittleEndian.PutUint32(data[1+len(pubBz):], index)
+
+	mac := hmac.New(sha512.New, parent.ChainCode)
+	_, _ = mac.Write(data)
+	z := mac.Sum(nil)
+	data[0] = 0x03
+	mac = hmac.New(sha512.New, parent.ChainCode)
+	_, _ = mac.Write(data)
+	chainCode := mac.Sum(nil)[32:]
+
+	// tweak = 8 * ZL, with ZL the little-endian integer of the first 28 bytes of Z
+	zL := make([]byte, 28)
+	for k := range zL {
+		zL[k] = z[27-k]
+	}
+	tweak := new(big.Int).Lsh(new(big.Int).SetBytes(zL), 3)
+	tweak.Mod(tweak, edwards.Edwards().Params().N)
+
+	childPub, err := parent.PubKey.Add(crypto.ScalarBaseMult(edwards.Edwards(), tweak))
+	if err != nil {
+		return nil, nil, err
+	}
+	if childPub.X().Sign() == 0 && childPub.Y().Cmp(big.NewInt(1)) == 0 {
+		return nil, nil, fmt.Errorf("DeriveChildPubKey: the child at index %d is the identity", index)
+	}
+	return tweak, &ExtendedPubKey{PubKey: childPub, ChainCode: chainCode}, nil
+}
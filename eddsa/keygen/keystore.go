@@ -0,0 +1,63 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto/keystore"
+)
+
+const (
+	keystoreScheme = "eddsa"
+)
+
+// Encrypt serializes the save data into a keystore encrypted with a key derived from the passphrase.
+// The share ID, Ks and EdDSA public key stay readable through keystore.ReadHeader.
+func Encrypt(saveData LocalPartySaveData, passphrase []byte) ([]byte, error) {
+	if saveData.EDDSAPub == nil || saveData.ShareID == nil {
+		return nil, errors.New("Encrypt: the save data is incomplete")
+	}
+	// the JSON plaintext holds Xi and the extra shares of a weighted party; overwrite it once it is sealed
+	secret, err := json.Marshal(&saveData)
+	defer common.WipeBytes(secret)
+	if err != nil {
+		return nil, err
+	}
+	header := keystore.Header{
+		Scheme:     keystoreScheme,
+		ShareID:    saveData.ShareID,
+		Ks:         saveData.Ks,
+		PublicKeyX: saveData.EDDSAPub.X(),
+		PublicKeyY: saveData.EDDSAPub.Y(),
+	}
+	return keystore.Seal(header, secret, passphrase)
+}
+
+// Decrypt restores the save data from a keystore produced by Encrypt.
+// The save data is checked with Validate, so that an authentic keystore of a corrupted share is rejected.
+func Decrypt(blob, passphrase []byte) (LocalPartySaveData, error) {
+	var saveData LocalPartySaveData
+	header, secret, err := keystore.Open(blob, passphrase)
+	if err != nil {
+		return saveData, err
+	}
+	defer common.WipeBytes(secret)
+	if header.Scheme != keystoreScheme {
+		return saveData, fmt.Errorf("Decrypt: expected a %s keystore, got %q", keystoreScheme, header.Scheme)
+	}
+	if err = json.Unmarshal(secret, &saveData); err != nil {
+		return LocalPartySaveData{}, err
+	}
+	if err = saveData.Validate(); err != nil {
+		return LocalPartySaveData{}, fmt.Errorf("Decrypt: %v", err)
+	}
+	return saveData, nil
+}
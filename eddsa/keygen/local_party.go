@@ -50,6 +50,7 @@ type (
 		KGCs          []cmt.HashCommitment
 		vs            vss.Vs
 		shares        vss.Shares
+		extraShares   []vss.Shares // shares for the ExtraKs of each party (weighted keygen)
 		deCommitPolyG cmt.HashDeCommitment
 	}
 )
@@ -92,6 +93,11 @@ func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
 
+// Close stops the party and overwrites u_i, the shares made from it and the shares received from the other parties.
+func (p *LocalParty) Close() {
+	tss.BaseClose(p, p.temp.wipe)
+}
+
 func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
 	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
 	if err != nil {
@@ -159,3 +165,20 @@ func (p *LocalParty) PartyID() *tss.PartyID {
 func (p *LocalParty) String() string {
 	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
 }
+
+// wipe overwrites the secrets in the temp data; the save data is left as is, as it is shared with the data sent through `end`
+func (temp *localTempData) wipe() {
+	common.WipeBigInt(temp.ui)
+	temp.shares.Wipe()
+	for _, shares := range temp.extraShares {
+		shares.Wipe()
+	}
+	for _, msg := range temp.kgRound2Message1s {
+		if msg == nil {
+			continue
+		}
+		r2msg1 := msg.Content().(*KGRound2Message1)
+		common.WipeBytes(r2msg1.GetShare())
+		common.WipeByteSlices(r2msg1.GetExtraShares()...)
+	}
+}
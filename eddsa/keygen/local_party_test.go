@@ -7,6 +7,10 @@
 package keygen
 
 import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -15,12 +19,14 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/agl/ed25519/edwards25519"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/keystore"
 	"github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/test"
 	"github.com/zeta-chain/tss-lib/tss"
@@ -194,6 +200,20 @@ keygen:
 				}
 				t.Log("Public key distribution test done.")
 
+				// closing the parties wipes u_i and the shares, but not the save data
+				for _, Pj := range parties {
+					ui := Pj.temp.ui
+					Pj.Close()
+					assert.True(t, test.Wiped(ui), "u_i should be wiped")
+					for _, msg := range Pj.temp.kgRound2Message1s {
+						if msg != nil {
+							assert.True(t, test.WipedBytes(msg.Content().(*KGRound2Message1).GetShare()), "the received shares should be wiped")
+						}
+					}
+					assert.NotZero(t, Pj.data.Xi.Sign(), "x_i should be left as is")
+					assert.Error(t, Pj.Start())
+				}
+
 				// test sign/verify
 				data := make([]byte, 32)
 				for i := range data {
@@ -213,6 +233,92 @@ keygen:
 	}
 }
 
+func TestE2EConcurrentWeighted(t *testing.T) {
+	setUp("info")
+
+	tss.SetCurve(edwards.Edwards())
+
+	threshold := testThreshold
+	_, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// P0 and P1 hold 3 and 2 shares, so together they satisfy t+1 = 4 on their own
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), threshold)
+		params.SetPartyWeight(pIDs[0], 3)
+		params.SetPartyWeight(pIDs[1], 2)
+		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	saves := make([]LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			saves[index] = save
+			ended++
+		}
+	}
+	for i, save := range saves {
+		assert.NoError(t, save.Validate())
+		assert.True(t, save.IsWeighted())
+		assert.True(t, save.EDDSAPub.Equals(saves[0].EDDSAPub), "all parties should agree on the public key")
+		assert.Equal(t, parties[i].params.PartyWeight(i)-1, len(save.ExtraXi))
+	}
+
+	// the shares of P0 and P1 alone reconstruct the private key
+	shares := make(vss.Shares, 0, 5)
+	for j, save := range saves[:2] {
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+		for k, xik := range save.ExtraXi {
+			shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ExtraKs[j][k], Share: xik})
+		}
+	}
+	u, err := shares.ReConstruct()
+	assert.NoError(t, err, "vss.ReConstruct should not throw error")
+	assert.True(t, crypto.ScalarBaseMult(tss.EC(), u).Equals(saves[0].EDDSAPub), "the reconstructed key should match the public key")
+
+	// a tampered extra share is caught by Validate
+	saves[0].ExtraXi[0] = new(big.Int).Add(saves[0].ExtraXi[0], big.NewInt(1))
+	assert.Error(t, saves[0].Validate())
+}
+
 func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	fixtureFileName := makeTestFixtureFilePath(index)
 
@@ -238,3 +344,230 @@ func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	}
 	//
 }
+
+func TestEncryptDecryptSaveData(t *testing.T) {
+	tss.SetCurve(edwards.Edwards())
+
+	fixtures, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	key := fixtures[0]
+
+	blob, err := Encrypt(key, []byte("correct horse battery staple"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(blob), key.Xi.String(), "the secret share must not appear in the clear")
+
+	header, err := keystore.ReadHeader(blob)
+	assert.NoError(t, err)
+	assert.Equal(t, "eddsa", header.Scheme)
+	assert.Equal(t, 0, header.ShareID.Cmp(key.ShareID))
+	assert.Equal(t, 0, header.PublicKeyY.Cmp(key.EDDSAPub.Y()))
+
+	decrypted, err := Decrypt(blob, []byte("correct horse battery staple"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, decrypted.Xi.Cmp(key.Xi))
+	assert.True(t, decrypted.EDDSAPub.Equals(key.EDDSAPub))
+	assert.Equal(t, len(key.BigXj), len(decrypted.BigXj))
+
+	_, err = Decrypt(blob, []byte("wrong"))
+	assert.Error(t, err)
+
+	// an authentic keystore of a corrupted share is rejected
+	key.Xi = new(big.Int).Add(key.Xi, big.NewInt(1))
+	blob, err = Encrypt(key, []byte("correct horse battery staple"))
+	assert.NoError(t, err)
+	_, err = Decrypt(blob, []byte("correct horse battery staple"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Xi*G does not match")
+	}
+}
+
+func TestValidateSaveData(t *testing.T) {
+	tss.SetCurve(edwards.Edwards())
+
+	load := func() LocalPartySaveData {
+		fixtures, _, err := LoadKeygenTestFixtures(testParticipants)
+		if !assert.NoError(t, err, "should load keygen fixtures") {
+			t.FailNow()
+		}
+		return fixtures[1]
+	}
+	assert.NoError(t, load().Validate())
+	assert.NoError(t, load().ValidateThreshold(testThreshold))
+	assert.Error(t, load().ValidateThreshold(testThreshold-1), "the fixtures were generated with testThreshold")
+
+	cases := map[string]func(key *LocalPartySaveData){
+		"xi":        func(key *LocalPartySaveData) { key.Xi = new(big.Int).Add(key.Xi, big.NewInt(1)) },
+		"dup ks":    func(key *LocalPartySaveData) { key.Ks[2] = key.Ks[3] },
+		"share id":  func(key *LocalPartySaveData) { key.ShareID = big.NewInt(1) },
+		"big xj":    func(key *LocalPartySaveData) { key.BigXj[4] = key.BigXj[5] },
+		"eddsa pub": func(key *LocalPartySaveData) { key.EDDSAPub = key.BigXj[0] },
+	}
+	for name, corrupt := range cases {
+		key := load()
+		corrupt(&key)
+		assert.Error(t, key.Validate(), name)
+	}
+}
+
+func TestUpstreamSaveDataRoundTrip(t *testing.T) {
+	tss.SetCurve(edwards.Edwards())
+
+	fixtures, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	key := fixtures[0]
+
+	bz, err := ExportUpstreamSaveData(key)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bz), `"Curve":"ed25519"`)
+
+	imported, err := ImportUpstreamSaveData(bz)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, imported.Xi.Cmp(key.Xi))
+	assert.True(t, imported.EDDSAPub.Equals(key.EDDSAPub))
+
+	// the v1 layout without curve names is also accepted
+	bz, err = json.Marshal(&key)
+	assert.NoError(t, err)
+	_, err = ImportUpstreamSaveData(bz)
+	assert.NoError(t, err)
+}
+
+func TestDeriveChildPubKey(t *testing.T) {
+	tss.SetCurve(edwards.Edwards())
+
+	keys, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	parent := &ExtendedPubKey{PubKey: keys[0].EDDSAPub, ChainCode: make([]byte, ChainCodeLen)}
+	for i := range parent.ChainCode {
+		parent.ChainCode[i] = byte(i)
+	}
+
+	delta, child, err := DeriveChildPubKey(parent, []uint32{0, 1, 2})
+	if !assert.NoError(t, err) {
+		return
+	}
+	expected, err := parent.PubKey.Add(crypto.ScalarBaseMult(edwards.Edwards(), delta))
+	assert.NoError(t, err)
+	assert.True(t, expected.Equals(child.PubKey), "the child should be parent + delta*G")
+	assert.Len(t, child.ChainCode, ChainCodeLen)
+
+	// deriving step by step gives the same key and the sum of the deltas
+	delta1, mid, err := DeriveChildPubKey(parent, []uint32{0, 1})
+	assert.NoError(t, err)
+	delta2, child2, err := DeriveChildPubKey(mid, []uint32{2})
+	assert.NoError(t, err)
+	assert.True(t, child.PubKey.Equals(child2.PubKey))
+	assert.Equal(t, child.ChainCode, child2.ChainCode)
+	sum := new(big.Int).Add(delta1, delta2)
+	assert.Zero(t, delta.Cmp(sum.Mod(sum, edwards.Edwards().Params().N)))
+
+	_, other, err := DeriveChildPubKey(parent, []uint32{0, 1, 3})
+	assert.NoError(t, err)
+	assert.False(t, child.PubKey.Equals(other.PubKey), "different paths should give different keys")
+
+	delta0, same, err := DeriveChildPubKey(parent, nil)
+	assert.NoError(t, err)
+	assert.Zero(t, delta0.Sign())
+	assert.True(t, parent.PubKey.Equals(same.PubKey))
+
+	_, _, err = DeriveChildPubKey(parent, []uint32{0, HardenedKeyStart})
+	assert.Error(t, err, "a hardened index cannot be derived from a public key")
+	_, _, err = DeriveChildPubKey(&ExtendedPubKey{PubKey: parent.PubKey, ChainCode: []byte{1, 2, 3}}, []uint32{0})
+	assert.Error(t, err, "the chain code must be 32 bytes")
+}
+
+// TestDeriveChildPubKeyPrivateDerivation checks DeriveChildPubKey against the private derivation of BIP32-Ed25519, in
+// which a wallet that holds the extended private key (kL, kR, c) computes kL' = kL + 8*ZL and the child key kL'*B.
+// The private side uses the curve arithmetic of agl/ed25519 and the signature is checked by crypto/ed25519, so that
+// neither depends on the edwards package that DeriveChildPubKey uses.
+// The expected bytes pin the encoding of every step: the 0x02 and 0x03 prefixes, the little-endian index and the 28 bytes of ZL.
+func TestDeriveChildPubKeyPrivateDerivation(t *testing.T) {
+	tss.SetCurve(edwards.Edwards())
+
+	// a root kL with the bits set as BIP32-Ed25519 requires: the lowest 3 and the highest cleared, the second highest set
+	// and the third highest cleared
+	kL, _ := hex.DecodeString("40a8a0a5a3e4c1d7e41b9b5a41b0f5c1b6b2a6e3d9f8a7c2b1e0d9c8b7a69f5f")
+	chainCode, _ := hex.DecodeString("0b5d2c6e8f1a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4")
+	assert.Zero(t, kL[0]&0x07)
+	assert.Equal(t, byte(0x40), kL[31]&0xe0)
+	path := []uint32{0, 1, HardenedKeyStart - 1}
+
+	privToPub := func(kL []byte) []byte {
+		var scalar, pub [32]byte
+		copy(scalar[:], kL)
+		var point edwards25519.ExtendedGroupElement
+		edwards25519.GeScalarMultBase(&point, &scalar)
+		point.ToBytes(&pub)
+		return pub[:]
+	}
+	littleEndian := func(bz []byte) *big.Int {
+		be := make([]byte, len(bz))
+		for k := range bz {
+			be[len(bz)-1-k] = bz[k]
+		}
+		return new(big.Int).SetBytes(be)
+	}
+	toLittleEndian := func(x *big.Int) []byte {
+		bz := make([]byte, 32)
+		be := x.Bytes()
+		for k := range be {
+			bz[k] = be[len(be)-1-k]
+		}
+		return bz
+	}
+
+	parentPub, err := crypto.NewECPointFromEd25519(privToPub(kL))
+	if !assert.NoError(t, err) {
+		return
+	}
+	// the private derivation, written from the paper
+	childKL, childC, pub := littleEndian(kL), chainCode, privToPub(kL)
+	for _, index := range path {
+		data := append([]byte{0x02}, pub...)
+		data = append(data, byte(index), byte(index>>8), byte(index>>16), byte(index>>24))
+		mac := hmac.New(sha512.New, childC)
+		mac.Write(data)
+		z := mac.Sum(nil)
+		data[0] = 0x03
+		mac = hmac.New(sha512.New, childC)
+		mac.Write(data)
+		childC = mac.Sum(nil)[32:]
+		childKL.Add(childKL, new(big.Int).Lsh(littleEndian(z[:28]), 3))
+		pub = privToPub(toLittleEndian(childKL))
+	}
+
+	delta, child, err := DeriveChildPubKey(&ExtendedPubKey{PubKey: parentPub, ChainCode: chainCode}, path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	childPub, err := child.PubKey.Ed25519Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, pub, childPub, "the child key should be kL'*B")
+	assert.Equal(t, childC, child.ChainCode)
+	expectedDelta := new(big.Int).Sub(childKL, littleEndian(kL))
+	assert.Zero(t, delta.Cmp(expectedDelta.Mod(expectedDelta, edwards.Edwards().Params().N)), "delta should be kL' - kL")
+
+	assert.Equal(t, "3281919948705821bace6570c8ba8b857c1a37183e8e2c4b9a0ae9fb763e3897", hex.EncodeToString(childPub))
+	assert.Equal(t, "9d952b241f24b7776bea4a9bd7e9fd1ec46c98efdf71bdd273035bad9c79a9ed", hex.EncodeToString(child.ChainCode))
+
+	// an Ed25519 signature made with kL', as a BIP32-Ed25519 wallet signs, verifies under the derived key with the
+	// implementation of the Go standard library
+	L := edwards.Edwards().Params().N
+	msg := []byte("BIP32-Ed25519")
+	nonce := sha512.Sum512(append([]byte("kR"), msg...))
+	r := new(big.Int).Mod(littleEndian(nonce[:]), L)
+	bigR := privToPub(toLittleEndian(r))
+	challenge := sha512.Sum512(append(append(append([]byte{}, bigR...), childPub...), msg...))
+	k := new(big.Int).Mod(littleEndian(challenge[:]), L)
+	sigS := new(big.Int).Mul(k, childKL)
+	sigS.Add(sigS, r).Mod(sigS, L)
+	sig := append(append([]byte{}, bigR...), toLittleEndian(sigS)...)
+	assert.True(t, ed25519.Verify(childPub, msg, sig), "crypto/ed25519 should accept a signature by kL' under the derived key")
+	assert.False(t, ed25519.Verify(privToPub(kL), msg, sig), "crypto/ed25519 should reject it under the parent key")
+}
@@ -52,17 +52,24 @@ func (m *KGRound1Message) UnmarshalCommitment() *big.Int {
 
 // ----- //
 
+// extraShares are the shares for the extra share ids of a party with a weight above one (weighted keygen)
 func NewKGRound2Message1(
 	to, from *tss.PartyID,
 	share *vss.Share,
+	extraShares ...*vss.Share,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		To:          []*tss.PartyID{to},
 		IsBroadcast: false,
 	}
+	extraShareBzs := make([][]byte, len(extraShares))
+	for k, extraShare := range extraShares {
+		extraShareBzs[k] = extraShare.Share.Bytes()
+	}
 	content := &KGRound2Message1{
-		Share: share.Share.Bytes(),
+		Share:       share.Share.Bytes(),
+		ExtraShares: extraShareBzs,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -77,6 +84,10 @@ func (m *KGRound2Message1) UnmarshalShare() *big.Int {
 	return new(big.Int).SetBytes(m.Share)
 }
 
+func (m *KGRound2Message1) UnmarshalExtraShares() []*big.Int {
+	return common.ByteSlicesToBigInts(m.GetExtraShares())
+}
+
 // ----- //
 
 func NewKGRound2Message2(
@@ -42,9 +42,17 @@ func (round *round1) Start() *tss.Error {
 	ui := common.GetRandomPositiveInt(tss.EC().Params().N)
 	round.temp.ui = ui
 
-	// 2. compute the vss shares
+	// 2. compute the vss shares; a party with a weight above one also gets a share for each of its extra ids
 	ids := round.Parties().IDs().Keys()
-	vs, shares, err := vss.Create(round.Threshold(), ui, ids)
+	allIDs := append([]*big.Int{}, ids...)
+	if round.Params().IsWeighted() {
+		round.save.ExtraKs = make([][]*big.Int, len(ids))
+		for j, kj := range ids {
+			round.save.ExtraKs[j] = vss.ExtraIndexes(tss.EC(), kj, round.PartyWeight(j)-1)
+			allIDs = append(allIDs, round.save.ExtraKs[j]...)
+		}
+	}
+	vs, shares, err := vss.Create(round.Threshold(), ui, allIDs)
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
@@ -68,7 +76,15 @@ func (round *round1) Start() *tss.Error {
 	// - our set of Shamir shares
 	round.save.ShareID = ids[i]
 	round.temp.vs = vs
-	round.temp.shares = shares
+	round.temp.shares = shares[:len(ids)]
+	if round.save.IsWeighted() {
+		round.temp.extraShares = make([]vss.Shares, len(ids))
+		offset := len(ids)
+		for j := range ids {
+			round.temp.extraShares[j] = shares[offset : offset+len(round.save.ExtraKs[j])]
+			offset += len(round.save.ExtraKs[j])
+		}
+	}
 
 	round.temp.deCommitPolyG = cmt.D
 
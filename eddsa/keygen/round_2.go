@@ -11,6 +11,7 @@ import (
 
 	errors2 "github.com/pkg/errors"
 
+	"github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/crypto/zkp"
 	"github.com/zeta-chain/tss-lib/tss"
 )
@@ -34,7 +35,11 @@ func (round *round2) Start() *tss.Error {
 	// 3. p2p send share ij to Pj
 	shares := round.temp.shares
 	for j, Pj := range round.Parties().IDs() {
-		r2msg1 := NewKGRound2Message1(Pj, round.PartyID(), shares[j])
+		var extraShares vss.Shares
+		if round.save.IsWeighted() {
+			extraShares = round.temp.extraShares[j]
+		}
+		r2msg1 := NewKGRound2Message1(Pj, round.PartyID(), shares[j], extraShares...)
 		// do not send to this Pj, but store for round 3
 		if j == i {
 			round.temp.kgRound2Message1s[j] = r2msg1
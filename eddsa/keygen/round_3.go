@@ -39,9 +39,11 @@ func (round *round3) Start() *tss.Error {
 		}
 		r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
 		share := r2msg1.UnmarshalShare()
-		xi = new(big.Int).Add(xi, share)
+		xi.Add(xi, share)
+		common.WipeBigInt(share)
 	}
 	round.save.Xi = new(big.Int).Mod(xi, tss.EC().Params().N)
+	common.WipeBigInt(xi)
 
 	// 2-3.
 	Vc := make(vss.Vs, round.Threshold()+1)
@@ -49,6 +51,11 @@ func (round *round3) Start() *tss.Error {
 		Vc[c] = round.temp.vs[c] // ours
 	}
 
+	var ourExtraKs []*big.Int
+	if round.save.IsWeighted() {
+		ourExtraKs = round.save.ExtraKs[PIdx]
+	}
+
 	// 4-12.
 	type vssOut struct {
 		unWrappedErr error
@@ -105,6 +112,19 @@ func (round *round3) Start() *tss.Error {
 				ch <- vssOut{errors.New("vss verify failed"), nil}
 				return
 			}
+			// weighted keygen: verify the shares for our extra ids
+			extraShares := r2msg1.UnmarshalExtraShares()
+			if len(extraShares) != len(ourExtraKs) {
+				ch <- vssOut{errors.New("got the wrong number of extra shares"), nil}
+				return
+			}
+			for k, extraShare := range extraShares {
+				PjExtraShare := vss.Share{Threshold: round.Threshold(), ID: ourExtraKs[k], Share: extraShare}
+				if ok = PjExtraShare.Verify(round.Threshold(), PjVs); !ok {
+					ch <- vssOut{errors.New("vss verify of an extra share failed"), nil}
+					return
+				}
+			}
 			// (9) handled above
 			ch <- vssOut{nil, PjVs}
 		}(j, chs[j])
@@ -135,6 +155,21 @@ func (round *round3) Start() *tss.Error {
 			return round.WrapError(multiErr, culprits...)
 		}
 	}
+	// calculate the shares for our extra ids (weighted keygen)
+	if round.save.IsWeighted() {
+		round.save.ExtraXi = make([]*big.Int, len(ourExtraKs))
+		for k := range ourExtraKs {
+			xik := new(big.Int).Set(round.temp.extraShares[PIdx][k].Share)
+			for j := range Ps {
+				if j == PIdx {
+					continue
+				}
+				r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+				xik = xik.Add(xik, r2msg1.UnmarshalExtraShares()[k])
+			}
+			round.save.ExtraXi[k] = new(big.Int).Mod(xik, tss.EC().Params().N)
+		}
+	}
 	{
 		var err error
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
@@ -181,6 +216,20 @@ func (round *round3) Start() *tss.Error {
 		}
 		round.save.BigXj = bigXj
 	}
+	// compute the public shares for the extra ids of each Pj (weighted keygen)
+	if round.save.IsWeighted() {
+		round.save.ExtraBigXj = make([][]*crypto.ECPoint, len(Ps))
+		for j, extraKs := range round.save.ExtraKs {
+			round.save.ExtraBigXj[j] = make([]*crypto.ECPoint, len(extraKs))
+			for k, kjk := range extraKs {
+				BigXjk, err := evaluateVs(Vc, kjk)
+				if err != nil {
+					return round.WrapError(errors2.Wrapf(err, "computing an extra BigXj"), Ps[j])
+				}
+				round.save.ExtraBigXj[j][k] = BigXjk
+			}
+		}
+	}
 
 	// 18. compute and SAVE the EDDSA public key `y`
 	eddsaPubKey, err := crypto.NewECPoint(tss.EC(), Vc[0].X(), Vc[0].Y())
@@ -209,3 +258,18 @@ func (round *round3) Update() (bool, *tss.Error) {
 func (round *round3) NextRound() tss.Round {
 	return nil // finished!
 }
+
+// evaluateVs evaluates the polynomial committed to by Vs at id in the exponent
+func evaluateVs(Vs vss.Vs, id *big.Int) (*crypto.ECPoint, error) {
+	modQ := common.ModInt(tss.EC().Params().N)
+	var err error
+	result := Vs[0]
+	z := big.NewInt(1)
+	for c := 1; c < len(Vs); c++ {
+		z = modQ.Mul(z, id)
+		if result, err = result.Add(Vs[c].ScalarMult(z)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
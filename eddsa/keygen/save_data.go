@@ -9,9 +9,11 @@ package keygen
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
 	"github.com/zeta-chain/tss-lib/tss"
 )
 
@@ -19,6 +21,9 @@ type (
 	LocalSecrets struct {
 		// secret fields (not shared, but stored locally)
 		Xi, ShareID *big.Int // xi, kj
+
+		// the shares at ExtraKs[i] held by a party with a weight above one (weighted keygen)
+		ExtraXi []*big.Int
 	}
 
 	// Everything in LocalPartySaveData is saved locally to user's HD when done
@@ -33,6 +38,10 @@ type (
 
 		// the EdDSA public key
 		EDDSAPub *crypto.ECPoint // y
+
+		// the share ids and public shares beyond Ks[j], BigXj[j] of each party with a weight above one (weighted keygen); nil otherwise
+		ExtraKs    [][]*big.Int
+		ExtraBigXj [][]*crypto.ECPoint
 	}
 )
 
@@ -42,6 +51,122 @@ func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
 	return
 }
 
+// Validate checks that the save data is self-consistent so that a corrupted or mismatched share is detected before a protocol is started.
+// The threshold is not stored, so the BigXj must interpolate to EDDSAPub for the lowest threshold that fits all of them.
+func (save LocalPartySaveData) Validate() error {
+	_, err := save.validate()
+	return err
+}
+
+// ValidateThreshold checks the save data as Validate does, and that the BigXj lie on a polynomial of degree threshold,
+// so that a key from a keygen with another threshold than the protocol parameters is rejected before it is started.
+// The signing and re-sharing parties call it in their constructors and return its error from Start(), so that a corrupted
+// share fails before any message is sent instead of several rounds in.
+func (save LocalPartySaveData) ValidateThreshold(threshold int) error {
+	degree, err := save.validate()
+	if err != nil {
+		return err
+	}
+	if degree != threshold {
+		return fmt.Errorf("save data was generated with threshold %d, not %d", degree, threshold)
+	}
+	return nil
+}
+
+// validate returns the degree of the polynomial that the BigXj lie on
+func (save LocalPartySaveData) validate() (int, error) {
+	n := len(save.Ks)
+	if n == 0 || save.Xi == nil || save.ShareID == nil || save.EDDSAPub == nil {
+		return 0, errors.New("save data is incomplete")
+	}
+	if len(save.BigXj) != n {
+		return 0, errors.New("save data has inconsistent party counts")
+	}
+	i, err := validateKsAndShareID(save.Ks, save.ShareID)
+	if err != nil {
+		return 0, err
+	}
+	if err := save.validateExtraShares(i); err != nil {
+		return 0, err
+	}
+	ks, bigXs := save.AllShareIDs()
+	if _, err := validateKsAndShareID(ks, save.ShareID); err != nil {
+		return 0, err
+	}
+	for j := range bigXs {
+		if bigXs[j] == nil || !bigXs[j].IsOnCurve() {
+			return 0, fmt.Errorf("save data public share %d is invalid", j)
+		}
+	}
+	if !crypto.ScalarBaseMult(tss.EC(), save.Xi).Equals(save.BigXj[i]) {
+		return 0, errors.New("save data Xi*G does not match BigXj[i]")
+	}
+	for k, xik := range save.ExtraXi {
+		if xik == nil || !crypto.ScalarBaseMult(tss.EC(), xik).Equals(save.ExtraBigXj[i][k]) {
+			return 0, fmt.Errorf("save data ExtraXi[%d]*G does not match ExtraBigXj[i][%d]", k, k)
+		}
+	}
+	degree, err := vss.PublicSharesThreshold(tss.EC(), ks, bigXs, save.EDDSAPub)
+	if err != nil {
+		return 0, fmt.Errorf("save data BigXj do not match EDDSAPub: %v", err)
+	}
+	return degree, nil
+}
+
+// IsWeighted returns true if the key was generated by a weighted keygen in which a party may hold several shares.
+func (save LocalPartySaveData) IsWeighted() bool {
+	return save.ExtraKs != nil
+}
+
+// AllShareIDs returns the share ids and public shares of every party, the Ks and BigXj followed by the ExtraKs and ExtraBigXj in party order.
+func (save LocalPartySaveData) AllShareIDs() ([]*big.Int, []*crypto.ECPoint) {
+	ks, bigXs := append([]*big.Int{}, save.Ks...), append([]*crypto.ECPoint{}, save.BigXj...)
+	for j := range save.ExtraKs {
+		ks = append(ks, save.ExtraKs[j]...)
+		bigXs = append(bigXs, save.ExtraBigXj[j]...)
+	}
+	return ks, bigXs
+}
+
+func (save LocalPartySaveData) validateExtraShares(i int) error {
+	if !save.IsWeighted() {
+		if save.ExtraBigXj != nil || len(save.ExtraXi) != 0 {
+			return errors.New("save data has extra shares but no ExtraKs")
+		}
+		return nil
+	}
+	if len(save.ExtraKs) != len(save.Ks) || len(save.ExtraBigXj) != len(save.Ks) {
+		return errors.New("save data has inconsistent party counts in ExtraKs, ExtraBigXj")
+	}
+	for j := range save.ExtraKs {
+		if len(save.ExtraKs[j]) != len(save.ExtraBigXj[j]) {
+			return fmt.Errorf("save data has inconsistent share counts for party %d", j)
+		}
+	}
+	if len(save.ExtraXi) != len(save.ExtraKs[i]) {
+		return errors.New("save data ExtraXi does not match ExtraKs[i]")
+	}
+	return nil
+}
+
+// validateKsAndShareID checks that the Ks are unique and non-zero and returns the index of shareID within them.
+func validateKsAndShareID(ks []*big.Int, shareID *big.Int) (int, error) {
+	for j, kj := range ks {
+		if kj == nil {
+			return -1, fmt.Errorf("save data Ks[%d] is missing", j)
+		}
+	}
+	if _, err := vss.CheckIndexes(tss.EC(), ks); err != nil {
+		return -1, fmt.Errorf("save data Ks are invalid: %v", err)
+	}
+	for j, kj := range ks {
+		if kj.Cmp(shareID) == 0 {
+			return j, nil
+		}
+	}
+	return -1, errors.New("save data ShareID was not found in Ks")
+}
+
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
 func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) LocalPartySaveData {
 	keysToIndices := make(map[string]int, len(sourceData.Ks))
@@ -58,6 +183,14 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 		}
 		newData.Ks[j] = sourceData.Ks[savedIdx]
 		newData.BigXj[j] = sourceData.BigXj[savedIdx]
+		if sourceData.IsWeighted() {
+			if newData.ExtraKs == nil {
+				newData.ExtraKs = make([][]*big.Int, sortedIDs.Len())
+				newData.ExtraBigXj = make([][]*crypto.ECPoint, sortedIDs.Len())
+			}
+			newData.ExtraKs[j] = sourceData.ExtraKs[savedIdx]
+			newData.ExtraBigXj[j] = sourceData.ExtraBigXj[savedIdx]
+		}
 	}
 	return newData
 }
@@ -0,0 +1,77 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+type (
+	// upstreamECPoint is the JSON layout of a point in the upstream bnb-chain tss-lib v2
+	upstreamECPoint struct {
+		Curve  tss.CurveName
+		Coords [2]*big.Int
+	}
+
+	// upstreamSaveData is the JSON layout of LocalPartySaveData in the upstream bnb-chain tss-lib v2
+	upstreamSaveData struct {
+		Xi, ShareID *big.Int
+		Ks          []*big.Int
+		BigXj       []*upstreamECPoint
+		EDDSAPub    *upstreamECPoint
+	}
+)
+
+// ImportUpstreamSaveData converts save data written as JSON by the upstream bnb-chain tss-lib (v1 or v2) and validates it.
+// The points must be on the curve currently set with tss.SetCurve; a point recorded on another curve is rejected.
+func ImportUpstreamSaveData(bz []byte) (LocalPartySaveData, error) {
+	var save LocalPartySaveData
+	if err := json.Unmarshal(bz, &save); err != nil {
+		return save, err
+	}
+	if save.EDDSAPub == nil {
+		return save, errors.New("ImportUpstreamSaveData: the save data is missing EDDSAPub")
+	}
+	if err := save.Validate(); err != nil {
+		return save, fmt.Errorf("ImportUpstreamSaveData: %v", err)
+	}
+	return save, nil
+}
+
+// ExportUpstreamSaveData validates the save data and serializes it to the JSON layout of the upstream bnb-chain tss-lib v2.
+func ExportUpstreamSaveData(save LocalPartySaveData) ([]byte, error) {
+	if err := save.Validate(); err != nil {
+		return nil, fmt.Errorf("ExportUpstreamSaveData: %v", err)
+	}
+	if save.IsWeighted() {
+		return nil, errors.New("ExportUpstreamSaveData: upstream tss-lib does not support keys from a weighted keygen")
+	}
+	curveName, ok := tss.GetCurveName(tss.EC())
+	if !ok {
+		return nil, errors.New("ExportUpstreamSaveData: the curve in use has no registered name")
+	}
+	toUpstream := func(p *crypto.ECPoint) *upstreamECPoint {
+		return &upstreamECPoint{Curve: curveName, Coords: [2]*big.Int{p.X(), p.Y()}}
+	}
+	out := upstreamSaveData{
+		Xi:       save.Xi,
+		ShareID:  save.ShareID,
+		Ks:       save.Ks,
+		BigXj:    make([]*upstreamECPoint, len(save.BigXj)),
+		EDDSAPub: toUpstream(save.EDDSAPub),
+	}
+	for j, Xj := range save.BigXj {
+		out.BigXj[j] = toUpstream(Xj)
+	}
+	return json.Marshal(&out)
+}
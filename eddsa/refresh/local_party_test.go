@@ -0,0 +1,196 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh_test
+
+import (
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/eddsa/keygen"
+	. "github.com/zeta-chain/tss-lib/eddsa/refresh"
+	"github.com/zeta-chain/tss-lib/eddsa/signing"
+	"github.com/zeta-chain/tss-lib/test"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	tss.SetCurve(edwards.Edwards())
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	oldKeys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: refresh
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for j, pID := range pIDs {
+		params := tss.NewParameters(p2pCtx, pID, len(pIDs), threshold)
+		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	var ended int32
+refresh:
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = save
+			if atomic.AddInt32(&ended, 1) == int32(len(pIDs)) {
+				t.Logf("Refresh done. Refreshed %d participants", ended)
+				break refresh
+			}
+		}
+	}
+
+	// the public key is unchanged and every share has been re-randomised
+	for j, key := range newKeys {
+		assert.True(t, key.EDDSAPub.Equals(oldKeys[0].EDDSAPub), "ensure the EDDSAPub is unchanged")
+		assert.NotEqual(t, 0, key.Xi.Cmp(oldKeys[j].Xi), "ensure xj has been refreshed")
+		for k, BigXk := range key.BigXj {
+			assert.True(t, BigXk.Equals(newKeys[k].BigXj[k]), "ensure all parties agree on BigX_k")
+		}
+		gXj := crypto.ScalarBaseMult(tss.EC(), key.Xi)
+		assert.True(t, key.BigXj[j].Equals(gXj), "ensure BigX_j == g^x_j")
+	}
+
+	// the refreshed shares reconstruct the same secret
+	shares := make(vss.Shares, 0, threshold+1)
+	for j := 0; j <= threshold; j++ {
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: newKeys[j].ShareID, Share: newKeys[j].Xi})
+	}
+	secret, err := shares.ReConstruct()
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.EC(), secret).Equals(oldKeys[0].EDDSAPub), "ensure the refreshed shares reconstruct the secret key")
+
+	// PHASE: signing with a subset of the refreshed keys
+	signPIDs := pIDs[:threshold+1]
+	signP2pCtx := tss.NewPeerContext(signPIDs)
+	signParties := make([]*signing.LocalParty, 0, len(signPIDs))
+
+	signErrCh := make(chan *tss.Error, len(signPIDs))
+	signOutCh := make(chan tss.Message, len(signPIDs))
+	signEndCh := make(chan *signing.SignatureData, len(signPIDs))
+
+	msg := big.NewInt(200)
+	for j, signPID := range signPIDs {
+		params := tss.NewParameters(signP2pCtx, signPID, len(signPIDs), threshold)
+		P := signing.NewLocalParty(msg, params, newKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
+		signParties = append(signParties, P)
+		go func(P *signing.LocalParty) {
+			if err := P.Start(); err != nil {
+				signErrCh <- err
+			}
+		}(P)
+	}
+
+	var signEnded int32
+	for {
+		select {
+		case err := <-signErrCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-signOutCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range signParties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, signErrCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(signParties[dest[0].Index], msg, signErrCh)
+			}
+
+		case signData := <-signEndCh:
+			if atomic.AddInt32(&signEnded, 1) == int32(len(signPIDs)) {
+				t.Logf("Signing done. Received sign data from %d participants", signEnded)
+
+				pk := edwards.PublicKey{
+					Curve: tss.EC(),
+					X:     oldKeys[0].EDDSAPub.X(),
+					Y:     oldKeys[0].EDDSAPub.Y(),
+				}
+				newSig, err := edwards.ParseSignature(signData.Signature.Signature)
+				if !assert.NoError(t, err) {
+					return
+				}
+				ok := edwards.Verify(&pk, msg.Bytes(), newSig.R, newSig.S)
+				assert.True(t, ok, "eddsa verify must pass")
+				t.Log("EDDSA signing test done.")
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zeta-chain/tss-lib/crypto"
+	cmts "github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/eddsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the proactive share refresh protocol, modelled on the EDDSA keygen
+func newRound1(params *tss.Parameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, input, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	if round.input.Xi == nil || round.input.EDDSAPub == nil {
+		return round.WrapError(errors.New("the save data given to the refresh party is incomplete"), Pi)
+	}
+	if round.Threshold()+1 > len(round.input.Ks) {
+		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(round.input.Ks)), Pi)
+	}
+
+	// 1. compute the vss shares of zero
+	ids := round.Parties().IDs().Keys()
+	vs, shares, err := vss.CreateZeroSecret(round.Threshold(), ids)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 2. make commitment -> (C, D)
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitment(pGFlat...)
+
+	// 3. carry over the public data that does not change in a refresh
+	round.save.ShareID = round.input.ShareID
+	round.save.EDDSAPub = round.input.EDDSAPub
+	copy(round.save.Ks, round.input.Ks)
+
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.deCommitPolyG = cmt.D
+
+	// BROADCAST commitment
+	{
+		msg := NewRFRound1Message(Pi, cmt.C)
+		round.temp.rfRound1Messages[i] = msg
+		round.out <- msg
+	}
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RFRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.rfRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		// vss check is in round 3
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
@@ -0,0 +1,81 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// 1. store r1 message pieces
+	for j, msg := range round.temp.rfRound1Messages {
+		r1msg := msg.Content().(*RFRound1Message)
+		round.temp.RFCs[j] = r1msg.UnmarshalCommitment()
+	}
+
+	// 2. p2p send share ij to Pj
+	shares := round.temp.shares
+	for j, Pj := range round.Parties().IDs() {
+		r2msg1 := NewRFRound2Message1(Pj, round.PartyID(), shares[j])
+		// do not send to this Pj, but store for round 3
+		if j == i {
+			round.temp.rfRound2Message1s[j] = r2msg1
+			continue
+		}
+		round.out <- r2msg1
+	}
+
+	// 3. BROADCAST de-commitments of the zero poly*G
+	r2msg2 := NewRFRound2Message2(round.PartyID(), round.temp.deCommitPolyG)
+	round.temp.rfRound2Message2s[i] = r2msg2
+	round.out <- r2msg2
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RFRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*RFRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.rfRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		msg2 := round.temp.rfRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
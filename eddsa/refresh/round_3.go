@@ -0,0 +1,183 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/vss"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 1-2.
+	Vc := make(vss.Vs, round.Threshold())
+	for c := range Vc {
+		Vc[c] = round.temp.vs[c] // ours
+	}
+
+	// 3-7.
+	type vssOut struct {
+		unWrappedErr error
+		pjVs         vss.Vs
+	}
+	chs := make([]chan vssOut, len(Ps))
+	for i := range chs {
+		if i == PIdx {
+			continue
+		}
+		chs[i] = make(chan vssOut)
+	}
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		go func(j int, ch chan<- vssOut) {
+			RFCj := round.temp.RFCs[j]
+			r2msg2 := round.temp.rfRound2Message2s[j].Content().(*RFRound2Message2)
+			RFDj := r2msg2.UnmarshalDeCommitment()
+			cmtDeCmt := commitments.HashCommitDecommit{C: RFCj, D: RFDj}
+			ok, flatPolyGs := cmtDeCmt.DeCommit()
+			if !ok || len(flatPolyGs) != round.Threshold()*2 {
+				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
+				return
+			}
+			PjVs, err := crypto.UnFlattenECPoints(tss.EC(), flatPolyGs)
+			if err != nil {
+				ch <- vssOut{err, nil}
+				return
+			}
+			for i, PjV := range PjVs {
+				PjVs[i] = PjV.EightInvEight()
+			}
+			r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RFRound2Message1)
+			PjShare := vss.Share{
+				Threshold: round.Threshold(),
+				ID:        round.PartyID().KeyInt(),
+				Share:     r2msg1.UnmarshalShare(),
+			}
+			if ok = PjShare.VerifyZeroSecret(round.Threshold(), PjVs); !ok {
+				ch <- vssOut{errors.New("vss verify failed"), nil}
+				return
+			}
+			ch <- vssOut{nil, PjVs}
+		}(j, chs[j])
+	}
+
+	// 8. calculate the new xi = xi + sum of the shares of zero (deferred for performance)
+	modQ := common.ModInt(tss.EC().Params().N)
+	xi := new(big.Int).Add(round.input.Xi, round.temp.shares[PIdx].Share)
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RFRound2Message1)
+		xi = xi.Add(xi, r2msg1.UnmarshalShare())
+	}
+	round.save.Xi = modQ.Add(xi, big.NewInt(0))
+	common.WipeBigInt(xi)
+
+	// consume unbuffered channels (end the goroutines)
+	vssResults := make([]vssOut, len(Ps))
+	{
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		var multiErr error
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			vssResults[j] = <-chs[j]
+			// collect culprits to error out with
+			if err := vssResults[j].unWrappedErr; err != nil {
+				culprits = append(culprits, Pj)
+				multiErr = multierror.Append(multiErr, err)
+			}
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(multiErr, culprits...)
+		}
+	}
+	{
+		var err error
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			PjVs := vssResults[j].pjVs
+			for c := range Vc {
+				Vc[c], err = Vc[c].Add(PjVs[c])
+				if err != nil {
+					culprits = append(culprits, Pj)
+				}
+			}
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...)
+		}
+	}
+
+	// 9-12. compute the new Xj = Xj + sum(Vc * kj^c) for each Pj
+	{
+		var err error
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, Pj := range Ps {
+			kj := Pj.KeyInt()
+			BigXj := round.input.BigXj[j]
+			z := new(big.Int).SetInt64(int64(1))
+			for c := 1; c <= round.Threshold(); c++ {
+				z = modQ.Mul(z, kj)
+				BigXj, err = BigXj.Add(Vc[c-1].ScalarMult(z))
+				if err != nil {
+					culprits = append(culprits, Pj)
+				}
+			}
+			round.save.BigXj[j] = BigXj
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), culprits...)
+		}
+	}
+
+	// 13. sanity check our refreshed share against the public data
+	if !crypto.ScalarBaseMult(tss.EC(), round.save.Xi).Equals(round.save.BigXj[PIdx]) {
+		return round.WrapError(errors.New("assertion failed: the refreshed xi*G != Xi"), round.PartyID())
+	}
+
+	round.end <- *round.save
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
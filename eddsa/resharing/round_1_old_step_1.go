@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/crypto"
 	"github.com/zeta-chain/tss-lib/crypto/commitments"
 	"github.com/zeta-chain/tss-lib/crypto/vss"
@@ -48,6 +49,7 @@ func (round *round1) Start() *tss.Error {
 	}
 	newKs := round.NewParties().IDs().Keys()
 	wi := signing.PrepareForSigning(i, len(round.OldParties().IDs()), xi, ks)
+	defer common.WipeBigInt(wi)
 
 	// 2.
 	vi, shares, err := vss.Create(round.NewThreshold(), wi, newKs)
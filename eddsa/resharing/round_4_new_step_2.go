@@ -76,7 +76,7 @@ func (round *round4) Start() *tss.Error {
 			return round.WrapError(errors.New("share from old committee did not pass Verify()"), round.Parties().IDs()[j])
 		}
 
-		newXi = new(big.Int).Add(newXi, sharej.Share)
+		newXi.Add(newXi, sharej.Share)
 	}
 
 	// 9-12.
@@ -9,6 +9,7 @@ package resharing
 import (
 	"errors"
 
+	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/tss"
 )
 
@@ -30,7 +31,7 @@ func (round *round5) Start() *tss.Error {
 		round.save.Ks = round.temp.newKs
 
 	} else if round.IsOldCommittee() {
-		round.input.Xi.SetInt64(0)
+		common.WipeBigInt(round.input.Xi)
 	}
 
 	round.end <- *round.save
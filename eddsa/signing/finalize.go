@@ -12,7 +12,6 @@ import (
 	"math/big"
 
 	"github.com/agl/ed25519/edwards25519"
-	"github.com/decred/dcrd/dcrec/edwards/v2"
 
 	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/tss"
@@ -38,6 +37,12 @@ func (round *finalization) Start() *tss.Error {
 		edwards25519.ScMulAdd(&tmpSumS, sumS, bigIntToEncodedBytes(big.NewInt(1)), sjBytes)
 		sumS = &tmpSumS
 	}
+	// the signers hold shares of the root key, so the child key signs with s + lambda*delta
+	if delta := round.temp.keyDerivationDelta; delta != nil && delta.Sign() != 0 {
+		var tweakedS [32]byte
+		edwards25519.ScMulAdd(&tweakedS, round.temp.lambda, bigIntToEncodedBytes(delta), sumS)
+		sumS = &tweakedS
+	}
 	s := encodedBytesToBigInt(sumS)
 
 	// save the signature for final output
@@ -45,17 +50,11 @@ func (round *finalization) Start() *tss.Error {
 	signature.Signature = append(bigIntToEncodedBytes(round.temp.r)[:], sumS[:]...)
 	signature.R = round.temp.r.Bytes()
 	signature.S = s.Bytes()
-	signature.M = round.temp.m.Bytes()
+	signature.M = round.temp.m
 	round.data.Signature = signature
 
-	pk := edwards.PublicKey{
-		Curve: tss.EC(),
-		X:     round.key.EDDSAPub.X(),
-		Y:     round.key.EDDSAPub.Y(),
-	}
-
-	ok := edwards.Verify(&pk, round.temp.m.Bytes(), round.temp.r, s)
-	if !ok {
+	encodedPubKey := ecPointToEncodedBytes(round.temp.pubKey.X(), round.temp.pubKey.Y())
+	if ok := verify(encodedPubKey, round.temp.m, signature.Signature, &round.temp.opts); !ok {
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
 	round.end <- round.data
@@ -28,9 +28,11 @@ type (
 		*tss.BaseParty
 		params *tss.Parameters
 
-		keys keygen.LocalPartySaveData
-		temp localTempData
-		data SignatureData
+		keys    keygen.LocalPartySaveData
+		keyErr  error // set when the key data failed to validate
+		optsErr error // set when the options or the message do not fit the variant
+		temp    localTempData
+		data    SignatureData
 
 		// outbound messaging
 		out chan<- tss.Message
@@ -46,9 +48,16 @@ type (
 	localTempData struct {
 		localMessageStore
 
+		// the message, signed byte for byte, and the variant of Ed25519
+		m    []byte
+		opts Options
+
+		// the key that signs, EDDSAPub or its child, and the delta of the child key that is added to s
+		pubKey             *crypto.ECPoint
+		keyDerivationDelta *big.Int
+
 		// temp data (thrown away after sign) / round 1
 		wi,
-		m,
 		ri *big.Int
 		pointRi  *crypto.ECPoint
 		deCommit cmt.HashDeCommitment
@@ -58,34 +67,97 @@ type (
 		si  *[32]byte
 
 		// round 3
-		r *big.Int
+		r      *big.Int
+		lambda *[32]byte
 	}
 )
 
+// NewLocalParty constructs a party that signs the big-endian bytes of msg with Ed25519.
+// A *big.Int drops the leading zero bytes of a message; use NewLocalPartyWithMessage to sign the bytes of a message as they are.
 func NewLocalParty(
 	msg *big.Int,
 	params *tss.Parameters,
 	key keygen.LocalPartySaveData,
 	out chan<- tss.Message,
 	end chan<- *SignatureData,
+) tss.Party {
+	var m []byte
+	if msg != nil {
+		m = msg.Bytes()
+	}
+	return newLocalParty(m, Options{}, nil, params, key, out, end)
+}
+
+// NewLocalPartyWithMessage constructs a party that signs msg byte for byte with Ed25519, as ed25519.Sign does.
+func NewLocalPartyWithMessage(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SignatureData,
+) tss.Party {
+	return newLocalParty(msg, Options{}, nil, params, key, out, end)
+}
+
+// NewLocalPartyWithOptions constructs a party that signs msg with the Ed25519, Ed25519ctx or Ed25519ph variant of opts.
+// For Ed25519ph, msg is the SHA-512 digest of the data to sign. Start returns an error if opts or msg do not fit the variant.
+func NewLocalPartyWithOptions(
+	msg []byte,
+	opts Options,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SignatureData,
+) tss.Party {
+	return newLocalParty(msg, opts, nil, params, key, out, end)
+}
+
+// NewLocalPartyWithKDD constructs a party that signs msg as NewLocalPartyWithOptions does, but with the child key
+// EDDSAPub + keyDerivationDelta*G. The delta of a derivation path is returned by keygen.DeriveChildPubKey.
+func NewLocalPartyWithKDD(
+	msg []byte,
+	opts Options,
+	keyDerivationDelta *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SignatureData,
+) tss.Party {
+	return newLocalParty(msg, opts, keyDerivationDelta, params, key, out, end)
+}
+
+func newLocalParty(
+	msg []byte,
+	opts Options,
+	keyDerivationDelta *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SignatureData,
 ) tss.Party {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
 		BaseParty: new(tss.BaseParty),
 		params:    params,
-		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		keys:      key,
 		temp:      localTempData{},
 		data:      SignatureData{},
 		out:       out,
 		end:       end,
 	}
+	if p.keyErr = key.ValidateThreshold(params.Threshold()); p.keyErr == nil {
+		p.keys = keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	}
 	// msgs init
 	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
 
 	// temp data init
-	p.temp.m = msg
+	p.temp.m = append([]byte{}, msg...)
+	p.temp.opts = opts
+	p.temp.keyDerivationDelta = keyDerivationDelta
+	p.optsErr = opts.validate(msg)
 	p.temp.cjs = make([]*big.Int, partyCount)
 	return p
 }
@@ -100,6 +172,12 @@ func (p *LocalParty) Start() *tss.Error {
 		if !ok {
 			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
 		}
+		if p.keyErr != nil {
+			return round.WrapError(fmt.Errorf("invalid key data: %v", p.keyErr))
+		}
+		if p.optsErr != nil {
+			return round.WrapError(p.optsErr)
+		}
 		if err := round1.prepare(); err != nil {
 			return round.WrapError(err)
 		}
@@ -111,6 +189,12 @@ func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
 
+// Close stops the party and overwrites w_i, r_i and s_i.
+// The key data given to NewLocalParty and the signature sent through `end` are left as is.
+func (p *LocalParty) Close() {
+	tss.BaseClose(p, p.temp.wipe)
+}
+
 func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
 	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
 	if err != nil {
@@ -164,3 +248,10 @@ func (p *LocalParty) PartyID() *tss.PartyID {
 func (p *LocalParty) String() string {
 	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
 }
+
+func (temp *localTempData) wipe() {
+	common.WipeBigInts(temp.wi, temp.ri)
+	if temp.si != nil {
+		common.WipeBytes(temp.si[:])
+	}
+}
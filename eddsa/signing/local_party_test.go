@@ -7,8 +7,14 @@
 package signing
 
 import (
+	gocrypto "crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
 	"math/big"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -18,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
 	"github.com/zeta-chain/tss-lib/eddsa/keygen"
 	"github.com/zeta-chain/tss-lib/test"
 	"github.com/zeta-chain/tss-lib/tss"
@@ -133,11 +140,369 @@ signing:
 
 				ok := edwards.Verify(&pk, msg.Bytes(), newSig.R, newSig.S)
 				assert.True(t, ok, "eddsa verify must pass")
+				assert.NoError(t, VerifySignature(keys[0].EDDSAPub, msg.Bytes(), parties[0].data.Signature))
+				pubBz := ecPointToEncodedBytes(pkX, pkY)
+				assert.True(t, ed25519.Verify(pubBz[:], msg.Bytes(), parties[0].data.Signature.Signature), "ensure crypto/ed25519 accepts the signature")
 				t.Log("EDDSA signing test done.")
 				// END EDDSA verify
 
+				// closing the parties wipes their secrets, but not the signature
+				signature := append([]byte{}, parties[0].data.Signature.Signature...)
+				for _, p := range parties {
+					wi, ri, si := p.temp.wi, p.temp.ri, p.temp.si
+					p.Close()
+					assert.True(t, test.Wiped(wi, ri), "w_i and r_i should be wiped")
+					assert.True(t, test.WipedBytes(si[:]), "s_i should be wiped")
+					assert.Error(t, p.Start())
+				}
+				assert.Equal(t, signature, parties[0].data.Signature.Signature)
+
 				break signing
 			}
 		}
 	}
 }
+
+func TestVerifySignature(t *testing.T) {
+	pubBz, priv, err := ed25519.GenerateKey(rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+	edPub, err := edwards.ParsePubKey(pubBz)
+	if !assert.NoError(t, err) {
+		return
+	}
+	pub, err := crypto.NewECPoint(edwards.Edwards(), edPub.X, edPub.Y)
+	if !assert.NoError(t, err) {
+		return
+	}
+	msg := []byte("standalone verification")
+	sig := &common.ECSignature{Signature: ed25519.Sign(priv, msg)}
+	assert.NoError(t, VerifySignature(pub, msg, sig), "ensure a crypto/ed25519 signature verifies")
+
+	assert.Error(t, VerifySignature(pub, msg[1:], sig), "wrong message")
+	assert.Error(t, VerifySignature(pub, msg, &common.ECSignature{Signature: sig.Signature[:63]}), "short signature")
+	otherPub, err := crypto.NewECPoint(edwards.Edwards(), edwards.Edwards().Gx, edwards.Edwards().Gy)
+	assert.NoError(t, err)
+	assert.Error(t, VerifySignature(otherPub, msg, sig), "wrong public key")
+
+	// S + L is the same scalar but another encoding; both verifiers refuse it
+	var sBz [32]byte
+	copy(sBz[:], sig.Signature[32:])
+	sPlusL := bigIntToEncodedBytes(new(big.Int).Add(encodedBytesToBigInt(&sBz), edwards.Edwards().Params().N))
+	malleated := append(append([]byte{}, sig.Signature[:32]...), sPlusL[:]...)
+	assert.False(t, ed25519.Verify(pubBz, msg, malleated))
+	assert.Error(t, VerifySignature(pub, msg, &common.ECSignature{Signature: malleated}), "non-canonical S")
+}
+
+func TestVerifySignatureWithOptions(t *testing.T) {
+	parsePub := func(pubHex string) *crypto.ECPoint {
+		pubBz, _ := hex.DecodeString(pubHex)
+		pub, err := crypto.NewECPointFromEd25519(pubBz)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return pub
+	}
+	mustDecode := func(s string) []byte {
+		bz, _ := hex.DecodeString(s)
+		return bz
+	}
+	abcDigest := sha512.Sum512([]byte("abc"))
+	// the Ed25519ctx and Ed25519ph test vectors of RFC 8032 sections 7.2 and 7.3
+	variants := []struct {
+		name string
+		pub  *crypto.ECPoint
+		msg  []byte
+		sig  string
+		opts Options
+	}{
+		{"Ed25519ctx", parsePub("dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292"), mustDecode("f726936d19c800494e3fdaff20b276a8"),
+			"55a4cc2f70a54e04288c5f4cd1e45a7bb520b36292911876cada7323198dd87a8b36950b95130022907a7fb7c4e9b2d5f6cca685a587b4b21f4b888e4e7edb0d",
+			Options{Context: "foo"}},
+		{"Ed25519ph", parsePub("ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf"), abcDigest[:],
+			"98a70222f0b8121aa9d30f813d683f809e462b469c7ff87639499bb94e6dae4131f85042463c2a355a2003d062adf5aaa10b8c61e636062aaad11c2a26083406",
+			Options{Hash: gocrypto.SHA512}},
+	}
+	for _, variant := range variants {
+		sig := &common.ECSignature{Signature: mustDecode(variant.sig)}
+		assert.NoError(t, VerifySignatureWithOptions(variant.pub, variant.msg, sig, variant.opts), variant.name)
+		assert.Error(t, VerifySignatureWithOptions(variant.pub, variant.msg, sig, Options{Hash: variant.opts.Hash, Context: "bar"}),
+			"%s: ensure the context is signed", variant.name)
+		assert.Error(t, VerifySignatureWithOptions(variant.pub, variant.msg, sig, Options{}), "%s checked as Ed25519", variant.name)
+	}
+
+	// a plain Ed25519 signature is not accepted as a variant one
+	pubBz, priv, err := ed25519.GenerateKey(rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+	pub, err := crypto.NewECPointFromEd25519(pubBz)
+	if !assert.NoError(t, err) {
+		return
+	}
+	sig := &common.ECSignature{Signature: ed25519.Sign(priv, abcDigest[:])}
+	assert.NoError(t, VerifySignatureWithOptions(pub, abcDigest[:], sig, Options{}))
+	assert.Error(t, VerifySignatureWithOptions(pub, abcDigest[:], sig, Options{Context: "foo"}))
+	assert.Error(t, VerifySignatureWithOptions(pub, abcDigest[:], sig, Options{Hash: gocrypto.SHA512}))
+
+	assert.Error(t, VerifySignatureWithOptions(pub, []byte("not a digest"), &common.ECSignature{}, Options{Hash: gocrypto.SHA512}))
+	assert.Error(t, VerifySignatureWithOptions(pub, abcDigest[:], &common.ECSignature{}, Options{Hash: gocrypto.SHA256}))
+	assert.Error(t, VerifySignatureWithOptions(pub, nil, &common.ECSignature{}, Options{Context: strings.Repeat("c", MaxContextLen+1)}))
+}
+
+func TestE2EConcurrentMessageVariants(t *testing.T) {
+	setUp("info")
+
+	tss.SetCurve(edwards.Edwards())
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	encodedPub := ecPointToEncodedBytes(keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y())
+	pubBz := ed25519.PublicKey(encodedPub[:])
+
+	// a Solana transaction message may start with zero bytes, which must be signed as they are
+	msg := []byte{0, 0, 1, 0, 3, 5, 8}
+	digest := sha512.Sum512(msg)
+	variants := []struct {
+		name string
+		msg  []byte
+		opts Options
+	}{
+		{"Ed25519", msg, Options{}},
+		{"Ed25519 of an empty message", []byte{}, Options{}},
+		{"Ed25519ctx", msg, Options{Context: "solana"}},
+		{"Ed25519ph", digest[:], Options{Hash: gocrypto.SHA512}},
+		{"Ed25519ph with a context", digest[:], Options{Hash: gocrypto.SHA512, Context: "solana"}},
+	}
+	for _, variant := range variants {
+		data, err := runSigning(t, keys, signPIDs, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+			if variant.opts == (Options{}) {
+				return NewLocalPartyWithMessage(variant.msg, params, key, out, end)
+			}
+			return NewLocalPartyWithOptions(variant.msg, variant.opts, params, key, out, end)
+		})
+		if !assert.Nil(t, err, variant.name) {
+			continue
+		}
+		sig := data.GetSignature()
+		assert.Equal(t, variant.msg, sig.GetM(), "%s: the message should be kept byte for byte", variant.name)
+		assert.NoError(t, VerifySignatureWithOptions(keys[0].EDDSAPub, variant.msg, sig, variant.opts), variant.name)
+		if variant.opts == (Options{}) {
+			assert.True(t, ed25519.Verify(pubBz, variant.msg, sig.GetSignature()), variant.name)
+		} else {
+			assert.False(t, ed25519.Verify(pubBz, variant.msg, sig.GetSignature()), "%s: a variant signature is not a plain Ed25519 one", variant.name)
+		}
+	}
+
+	// options that do not fit the message fail in Start
+	_, err2 := runSigning(t, keys, signPIDs, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+		return NewLocalPartyWithOptions(msg, Options{Hash: gocrypto.SHA512}, params, key, out, end)
+	})
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "SHA-512 digest")
+	}
+}
+
+func TestE2EConcurrentKeyDerivation(t *testing.T) {
+	setUp("info")
+
+	tss.SetCurve(edwards.Edwards())
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	chainCode := make([]byte, keygen.ChainCodeLen)
+	_, _ = rand.Read(chainCode)
+	delta, child, err := keygen.DeriveChildPubKey(&keygen.ExtendedPubKey{PubKey: keys[0].EDDSAPub, ChainCode: chainCode}, []uint32{44, 501, 0, 0})
+	if !assert.NoError(t, err) {
+		return
+	}
+	childBz, err := child.PubKey.Ed25519Bytes()
+	assert.NoError(t, err)
+
+	msg := []byte("a message signed with a derived key")
+	digest := sha512.Sum512(msg)
+	variants := []struct {
+		name string
+		msg  []byte
+		opts Options
+	}{
+		{"Ed25519", msg, Options{}},
+		{"Ed25519ph", digest[:], Options{Hash: gocrypto.SHA512}},
+	}
+	for _, variant := range variants {
+		data, err := runSigning(t, keys, signPIDs, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+			return NewLocalPartyWithKDD(variant.msg, variant.opts, delta, params, key, out, end)
+		})
+		if !assert.Nil(t, err, variant.name) {
+			continue
+		}
+		sig := data.GetSignature()
+		assert.NoError(t, VerifySignatureWithOptions(child.PubKey, variant.msg, sig, variant.opts), variant.name)
+		assert.Error(t, VerifySignatureWithOptions(keys[0].EDDSAPub, variant.msg, sig, variant.opts),
+			"%s: the signature should not verify under the parent key", variant.name)
+		if variant.opts == (Options{}) {
+			assert.True(t, ed25519.Verify(childBz, variant.msg, sig.GetSignature()), "%s: crypto/ed25519 should accept the signature under the child key", variant.name)
+		}
+	}
+
+	// a delta that is not reduced fails in Start
+	_, err2 := runSigning(t, keys, signPIDs, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *SignatureData) tss.Party {
+		return NewLocalPartyWithKDD(msg, Options{}, tss.EC().Params().N, params, key, out, end)
+	})
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "key derivation delta")
+	}
+}
+
+func TestE2EConcurrentWeighted(t *testing.T) {
+	setUp("info")
+
+	tss.SetCurve(edwards.Edwards())
+
+	// P0 and P1 hold 3 and 2 shares, so they can sign with t = 3 without any other party
+	keys, pIDs := runWeightedKeygen(t, map[int]int{0: 3, 1: 2})
+	if keys == nil {
+		return
+	}
+	signPIDs := tss.SortPartyIDs(tss.UnSortedPartyIDs{
+		tss.NewPartyID(pIDs[0].Id, pIDs[0].Moniker, pIDs[0].KeyInt()),
+		tss.NewPartyID(pIDs[1].Id, pIDs[1].Moniker, pIDs[1].KeyInt()),
+	})
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	msg := big.NewInt(200)
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		key := keygen.BuildLocalSaveDataSubset(keys[i], signPIDs)
+		parties = append(parties, NewLocalParty(msg, params, key, outCh, endCh))
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, nil, stop)
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case data := <-endCh:
+			if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+				pk := edwards.PublicKey{
+					Curve: tss.EC(),
+					X:     keys[0].EDDSAPub.X(),
+					Y:     keys[0].EDDSAPub.Y(),
+				}
+				sig, err := edwards.ParseSignature(data.GetSignature().GetSignature())
+				if assert.NoError(t, err) {
+					assert.True(t, edwards.Verify(&pk, msg.Bytes(), sig.R, sig.S), "eddsa verify must pass")
+				}
+				break signing
+			}
+		}
+	}
+}
+
+func TestPrepareForWeightedSigningRejectsEqualShareIDs(t *testing.T) {
+	tss.SetCurve(edwards.Edwards())
+
+	xis := []*big.Int{big.NewInt(5), big.NewInt(6)}
+	_, err := PrepareForWeightedSigning(0, xis, [][]*big.Int{{big.NewInt(1), big.NewInt(2)}, {big.NewInt(3)}})
+	assert.NoError(t, err)
+	// signer 1 claims a share id that signer 0 holds as well
+	_, err = PrepareForWeightedSigning(0, xis, [][]*big.Int{{big.NewInt(1), big.NewInt(2)}, {big.NewInt(2)}})
+	assert.Error(t, err)
+}
+
+// runWeightedKeygen runs a keygen over the fixture parties in which the party at each index of weights holds that many shares
+func runWeightedKeygen(t *testing.T, weights map[int]int) ([]keygen.LocalPartySaveData, tss.SortedPartyIDs) {
+	_, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return nil, nil
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]tss.Party, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan keygen.LocalPartySaveData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		for j, weight := range weights {
+			params.SetPartyWeight(pIDs[j], weight)
+		}
+		parties = append(parties, keygen.NewLocalParty(params, outCh, endCh))
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, nil, stop)
+
+	keys := make([]keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			return nil, nil
+
+		case key := <-endCh:
+			index, err := key.OriginalIndex()
+			if !assert.NoError(t, err) {
+				return nil, nil
+			}
+			keys[index] = key
+			ended++
+		}
+	}
+	return keys, pIDs
+}
+
+// runSigning runs the parties made by newParty until each has ended, or until the first error
+func runSigning(
+	t *testing.T,
+	keys []keygen.LocalPartySaveData,
+	signPIDs tss.SortedPartyIDs,
+	newParty func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *SignatureData) tss.Party,
+) (*SignatureData, *tss.Error) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, newParty(params, keys[i], outCh, endCh))
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, nil, stop)
+
+	var data *SignatureData
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			common.Logger.Warnf("Error: %s", err)
+			return nil, err
+
+		case data = <-endCh:
+			ended++
+		}
+	}
+	return data, nil
+}
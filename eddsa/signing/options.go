@@ -0,0 +1,103 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/agl/ed25519/edwards25519"
+)
+
+// MaxContextLen is the length of the longest context string of Ed25519ph and Ed25519ctx
+const MaxContextLen = 255
+
+// dom2Prefix starts the domain separation of Ed25519ph and Ed25519ctx in RFC 8032 section 5.1
+const dom2Prefix = "SigEd25519 no Ed25519 collisions"
+
+// Options selects the variant of RFC 8032 that is signed or verified, with the same meaning as ed25519.Options of Go 1.20:
+//   - Hash 0 and no Context: Ed25519, the message is signed as is.
+//   - Hash 0 and a Context: Ed25519ctx.
+//   - Hash crypto.SHA512: Ed25519ph, with an optional Context. The message is the SHA-512 digest of the data to sign.
+type Options struct {
+	Hash    crypto.Hash
+	Context string
+}
+
+// validate checks the options and that msg is a digest for Ed25519ph
+func (opts *Options) validate(msg []byte) error {
+	switch opts.Hash {
+	case 0:
+	case crypto.SHA512:
+		if len(msg) != sha512.Size {
+			return fmt.Errorf("the message of Ed25519ph must be a %d-byte SHA-512 digest, got %d bytes", sha512.Size, len(msg))
+		}
+	default:
+		return errors.New("the hash of Ed25519ph must be crypto.SHA512")
+	}
+	if MaxContextLen < len(opts.Context) {
+		return fmt.Errorf("the context must be at most %d bytes, got %d", MaxContextLen, len(opts.Context))
+	}
+	return nil
+}
+
+// dom2 returns dom2(phflag, context) of RFC 8032, which is empty for Ed25519
+func (opts *Options) dom2() []byte {
+	if opts.Hash == 0 && opts.Context == "" {
+		return nil
+	}
+	phflag := byte(0)
+	if opts.Hash == crypto.SHA512 {
+		phflag = 1
+	}
+	dom := append([]byte(dom2Prefix), phflag, byte(len(opts.Context)))
+	return append(dom, opts.Context...)
+}
+
+// challenge returns k = SHA-512(dom2 || R || A || msg) reduced modulo the order, the scalar that the key signs
+func challenge(encodedR, encodedPubKey *[32]byte, msg []byte, opts *Options) *[32]byte {
+	h := sha512.New()
+	_, _ = h.Write(opts.dom2())
+	_, _ = h.Write(encodedR[:])
+	_, _ = h.Write(encodedPubKey[:])
+	_, _ = h.Write(msg)
+
+	var k [64]byte
+	h.Sum(k[:0])
+	kReduced := new([32]byte)
+	edwards25519.ScReduce(kReduced, &k)
+	return kReduced
+}
+
+// verify checks the 64-byte R || S signature sig of msg under the encoded public key, as in RFC 8032 section 5.1.7.
+// The caller checks that S is reduced modulo the order.
+func verify(encodedPubKey *[32]byte, msg, sig []byte, opts *Options) bool {
+	if len(sig) != SignatureLen {
+		return false
+	}
+	var A edwards25519.ExtendedGroupElement
+	if !A.FromBytes(encodedPubKey) {
+		return false
+	}
+	// R' = [S]B - [k]A
+	edwards25519.FeNeg(&A.X, &A.X)
+	edwards25519.FeNeg(&A.T, &A.T)
+
+	var encodedR, s [32]byte
+	copy(encodedR[:], sig[:32])
+	copy(s[:], sig[32:])
+	k := challenge(&encodedR, encodedPubKey, msg, opts)
+
+	var R edwards25519.ProjectiveGroupElement
+	edwards25519.GeDoubleScalarMultVartime(&R, k, &A, &s)
+	var checkR [32]byte
+	R.ToBytes(&checkR)
+	return subtle.ConstantTimeCompare(encodedR[:], checkR[:]) == 1
+}
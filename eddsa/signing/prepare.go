@@ -25,7 +25,7 @@ func PrepareForSigning(i, pax int, xi *big.Int, ks []*big.Int) (wi *big.Int) {
 	}
 
 	// 1-4.
-	wi = xi
+	wi = new(big.Int).Set(xi)
 	for j := 0; j < pax; j++ {
 		if j == i {
 			continue
@@ -42,3 +42,42 @@ func PrepareForSigning(i, pax int, xi *big.Int, ks []*big.Int) (wi *big.Int) {
 
 	return
 }
+
+// PrepareForWeightedSigning is PrepareForSigning for a key from a weighted keygen, in which signer j holds the shares
+// with the ids ks[j]. The Lagrange-weighted shares xis of signer i are combined into a single wi, so the signing
+// rounds are still run once per signer.
+func PrepareForWeightedSigning(i int, xis []*big.Int, ks [][]*big.Int) (wi *big.Int, err error) {
+	modQ := common.ModInt(tss.EC().Params().N)
+	if len(ks) <= i {
+		panic(fmt.Errorf("PrepareForWeightedSigning: len(ks) <= i (%d <= %d)", len(ks), i))
+	}
+	if len(xis) != len(ks[i]) {
+		panic(fmt.Errorf("PrepareForWeightedSigning: len(xis) != len(ks[i]) (%d != %d)", len(xis), len(ks[i])))
+	}
+	allKs, offset := make([]*big.Int, 0, len(ks)), 0
+	for j := range ks {
+		if j == i {
+			offset = len(allKs)
+		}
+		allKs = append(allKs, ks[j]...)
+	}
+
+	// w_i = sum of x_ik * the Lagrange coefficient of ks[i][k] over all of the signers' share ids
+	wi = big.NewInt(0)
+	for k, ksik := range ks[i] {
+		coef := big.NewInt(1)
+		for c, kc := range allKs {
+			if c == offset+k {
+				continue
+			}
+			if kc.Cmp(ksik) == 0 {
+				err = fmt.Errorf("the indices of two shares are equal")
+				return
+			}
+			// big.Int Div is calculated as: a/b = a * modInv(b,q)
+			coef = modQ.Mul(coef, modQ.Mul(kc, modQ.Inverse(new(big.Int).Sub(kc, ksik))))
+		}
+		wi = modQ.Add(wi, modQ.Mul(xis[k], coef))
+	}
+	return
+}
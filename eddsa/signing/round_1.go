@@ -9,6 +9,7 @@ package signing
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/crypto"
@@ -88,6 +89,12 @@ func (round *round1) prepare() error {
 	xi := round.key.Xi
 	ks := round.key.Ks
 
+	if err := round.prepareSigningKey(); err != nil {
+		return err
+	}
+	if round.key.IsWeighted() {
+		return round.prepareWeighted()
+	}
 	if round.Threshold()+1 > len(ks) {
 		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
@@ -96,3 +103,41 @@ func (round *round1) prepare() error {
 	round.temp.wi = wi
 	return nil
 }
+
+// helper to call into PrepareForWeightedSigning() for a key from a weighted keygen
+func (round *round1) prepareWeighted() error {
+	i := round.PartyID().Index
+	key := round.key
+	if allKs, _ := key.AllShareIDs(); round.Threshold()+1 > len(allKs) {
+		return fmt.Errorf("t+1=%d is not satisfied by the share count of %d", round.Threshold()+1, len(allKs))
+	}
+	ks := make([][]*big.Int, len(key.Ks))
+	for j := range key.Ks {
+		ks[j] = append([]*big.Int{key.Ks[j]}, key.ExtraKs[j]...)
+	}
+	xis := append([]*big.Int{key.Xi}, key.ExtraXi...)
+	wi, err := PrepareForWeightedSigning(i, xis, ks)
+	if err != nil {
+		return err
+	}
+	round.temp.wi = wi
+	return nil
+}
+
+// prepareSigningKey sets the key that signs: EDDSAPub, or its child EDDSAPub + delta*G when a key derivation delta is given
+func (round *round1) prepareSigningKey() error {
+	delta := round.temp.keyDerivationDelta
+	round.temp.pubKey = round.key.EDDSAPub
+	if delta == nil || delta.Sign() == 0 {
+		return nil
+	}
+	if delta.Sign() < 0 || tss.EC().Params().N.Cmp(delta) <= 0 {
+		return errors.New("the key derivation delta must be in [0, N)")
+	}
+	pubKey, err := round.key.EDDSAPub.Add(crypto.ScalarBaseMult(tss.EC(), delta))
+	if err != nil {
+		return fmt.Errorf("failed to derive the child key: %v", err)
+	}
+	round.temp.pubKey = pubKey
+	return nil
+}
@@ -7,11 +7,10 @@
 package signing
 
 import (
-	"crypto/sha512"
-
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/pkg/errors"
 
+	"github.com/zeta-chain/tss-lib/common"
 	"github.com/zeta-chain/tss-lib/crypto"
 	"github.com/zeta-chain/tss-lib/crypto/commitments"
 	"github.com/zeta-chain/tss-lib/tss"
@@ -29,6 +28,7 @@ func (round *round3) Start() *tss.Error {
 	// 1. init R
 	var R edwards25519.ExtendedGroupElement
 	riBytes := bigIntToEncodedBytes(round.temp.ri)
+	defer common.WipeBytes(riBytes[:])
 	edwards25519.GeScalarMultBase(&R, riBytes)
 
 	// 2-6. compute R
@@ -70,27 +70,21 @@ func (round *round3) Start() *tss.Error {
 	// 7. compute lambda
 	var encodedR [32]byte
 	R.ToBytes(&encodedR)
-	encodedPubKey := ecPointToEncodedBytes(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
-
-	// h = hash512(k || A || M)
-	h := sha512.New()
-	h.Reset()
-	_, _ = h.Write(encodedR[:])
-	_, _ = h.Write(encodedPubKey[:])
-	_, _ = h.Write(round.temp.m.Bytes())
+	encodedPubKey := ecPointToEncodedBytes(round.temp.pubKey.X(), round.temp.pubKey.Y())
 
-	var lambda [64]byte
-	h.Sum(lambda[:0])
-	var lambdaReduced [32]byte
-	edwards25519.ScReduce(&lambdaReduced, &lambda)
+	// h = hash512(dom2 || R || A || M), where dom2 is empty for plain Ed25519
+	lambdaReduced := challenge(&encodedR, encodedPubKey, round.temp.m, &round.temp.opts)
 
 	// 8. compute si
 	var localS [32]byte
-	edwards25519.ScMulAdd(&localS, &lambdaReduced, bigIntToEncodedBytes(round.temp.wi), riBytes)
+	wiBytes := bigIntToEncodedBytes(round.temp.wi)
+	edwards25519.ScMulAdd(&localS, lambdaReduced, wiBytes, riBytes)
+	common.WipeBytes(wiBytes[:])
 
 	// 9. store r3 message pieces
 	round.temp.si = &localS
 	round.temp.r = encodedBytesToBigInt(&encodedR)
+	round.temp.lambda = lambdaReduced
 
 	// 10. broadcast si to other parties
 	r3msg := NewSignRound3Message(round.PartyID(), encodedBytesToBigInt(&localS))
@@ -0,0 +1,54 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// SignatureLen is the length of the R || S encoding of an Ed25519 signature in ECSignature.Signature
+const SignatureLen = 64
+
+// VerifySignature checks sig against msg and pub, the EDDSAPub of the key data, as an Ed25519 signature of RFC 8032.
+// An S that is not reduced modulo the order is refused, so each signature has one accepted encoding.
+// Ed25519 signatures have no DER form; ECSignature.Signature is the 64-byte encoding used by every verifier.
+func VerifySignature(pub *crypto.ECPoint, msg []byte, sig *common.ECSignature) error {
+	return VerifySignatureWithOptions(pub, msg, sig, Options{})
+}
+
+// VerifySignatureWithOptions is VerifySignature for the variant of opts; for Ed25519ph, msg is the SHA-512 digest of the data.
+func VerifySignatureWithOptions(pub *crypto.ECPoint, msg []byte, sig *common.ECSignature, opts Options) error {
+	if pub == nil || !tss.SameCurve(pub.Curve(), edwards.Edwards()) {
+		return errors.New("the public key is not an Ed25519 key")
+	}
+	if err := opts.validate(msg); err != nil {
+		return err
+	}
+	if sig == nil || len(sig.GetSignature()) != SignatureLen {
+		return fmt.Errorf("an Ed25519 signature must be %d bytes", SignatureLen)
+	}
+	var sBz [32]byte
+	copy(sBz[:], sig.GetSignature()[32:])
+	if encodedBytesToBigInt(&sBz).Cmp(edwards.Edwards().Params().N) >= 0 {
+		return errors.New("the signature S is not reduced modulo the order")
+	}
+	if len(sig.GetM()) != 0 && !bytes.Equal(sig.GetM(), msg) {
+		return errors.New("the signature is of another message")
+	}
+	if !verify(ecPointToEncodedBytes(pub.X(), pub.Y()), msg, sig.GetSignature(), &opts) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
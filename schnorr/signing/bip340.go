@@ -0,0 +1,101 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/zeta-chain/tss-lib/crypto"
+)
+
+const (
+	// SignatureLen is the length of a BIP340 signature, R.x || s
+	SignatureLen = 64
+
+	tagChallenge = "BIP0340/challenge"
+	tagTapTweak  = "TapTweak"
+)
+
+// TaggedHash returns the BIP340 tagged hash SHA256(SHA256(tag) || SHA256(tag) || msgs...)
+func TaggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	_, _ = h.Write(tagHash[:])
+	_, _ = h.Write(tagHash[:])
+	for _, msg := range msgs {
+		_, _ = h.Write(msg)
+	}
+	return h.Sum(nil)
+}
+
+// TaprootOutputKey returns the BIP341 output key Q = lift_x(P) + t*G of the internal key P and the tweak
+// t = hash_TapTweak(P.x || merkleRoot). An empty merkleRoot commits to no script tree, as BIP86 does for key path only outputs.
+// The x-only encoding of Q is the witness program of the P2TR output, e.g. with Q.BitcoinP2TRAddressUntweaked.
+func TaprootOutputKey(internalKey *crypto.ECPoint, merkleRoot []byte) (outputKey *crypto.ECPoint, tweak *big.Int, err error) {
+	if len(merkleRoot) != 0 && len(merkleRoot) != 32 {
+		return nil, nil, errors.New("TaprootOutputKey: the merkle root must be 32 bytes")
+	}
+	px, err := internalKey.XOnly()
+	if err != nil {
+		return nil, nil, err
+	}
+	P, err := crypto.NewECPointFromXOnly(px)
+	if err != nil {
+		return nil, nil, err
+	}
+	tweak = new(big.Int).SetBytes(TaggedHash(tagTapTweak, px, merkleRoot))
+	if tweak.Cmp(btcec.S256().N) >= 0 {
+		return nil, nil, errors.New("TaprootOutputKey: the tweak is not less than the curve order")
+	}
+	if outputKey, err = P.Add(crypto.ScalarBaseMult(btcec.S256(), tweak)); err != nil {
+		return nil, nil, errors.New("TaprootOutputKey: the output key is the point at infinity")
+	}
+	return outputKey, tweak, nil
+}
+
+// Verify checks a 64-byte BIP340 signature of msg under the 32-byte x-only public key
+func Verify(pubKey, msg, sig []byte) bool {
+	if len(pubKey) != 32 || len(sig) != SignatureLen {
+		return false
+	}
+	P, err := crypto.NewECPointFromXOnly(pubKey)
+	if err != nil {
+		return false
+	}
+	ec := btcec.S256()
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	if r.Cmp(ec.P) >= 0 || s.Cmp(ec.N) >= 0 {
+		return false
+	}
+	e := challenge(sig[:32], pubKey, msg)
+
+	// R = s*G - e*P must not be infinite, must have an even Y and must have the X of the signature
+	sG := crypto.ScalarBaseMult(ec, s)
+	eP := P.ScalarMult(e)
+	var R *crypto.ECPoint
+	switch {
+	case eP == nil:
+		R = sG
+	case sG == nil:
+		R = eP.Neg()
+	default:
+		if R, err = sG.Sub(eP); err != nil {
+			return false
+		}
+	}
+	return R != nil && R.Y().Bit(0) == 0 && R.X().Cmp(r) == 0
+}
+
+// challenge returns e = int(hash_BIP0340/challenge(R.x || P.x || msg)) mod n
+func challenge(rx, px, msg []byte) *big.Int {
+	e := new(big.Int).SetBytes(TaggedHash(tagChallenge, rx, px, msg))
+	return e.Mod(e, btcec.S256().N)
+}
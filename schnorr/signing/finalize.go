@@ -0,0 +1,95 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	modN := common.ModInt(tss.EC().Params().N)
+
+	// 1. verify each sj*G = Rj + e*Wj, with Wj negated along with the key, and sum the sj
+	eKey := round.temp.e
+	if round.temp.keySign < 0 {
+		eKey = modN.Neg(eKey)
+	}
+	s := round.temp.tweakTerm
+	if s.Sign() != 0 {
+		s = modN.Mul(round.temp.e, s)
+	}
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		sj := round.temp.signRound3Messages[j].Content().(*SchnorrSignRound3Message).UnmarshalS()
+		if sj.Cmp(tss.EC().Params().N) >= 0 || !sharePointEquals(sj, round.temp.bigRjs[j], round.temp.bigWs[j].ScalarMult(eKey)) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		s = modN.Add(s, sj)
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(errors.New("sj*G != Rj + e*Wj"), culprits...)
+	}
+
+	// 2. the signature is R.x || s
+	sigBz := make([]byte, SignatureLen)
+	copy(sigBz, round.temp.rx)
+	s.FillBytes(sigBz[32:])
+	if !Verify(round.temp.pubKeyX, round.temp.m, sigBz) {
+		return round.WrapError(errors.New("signature verification failed"))
+	}
+
+	// save the signature for final output
+	signature := new(common.ECSignature)
+	signature.Signature = sigBz
+	signature.R = round.temp.rx
+	signature.S = sigBz[32:]
+	signature.M = round.temp.m
+	round.data.Signature = signature
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// sharePointEquals reports whether sj*G = Rj + eWj; eWj is nil when it is the point at infinity
+func sharePointEquals(sj *big.Int, Rj, eWj *crypto.ECPoint) bool {
+	expected := Rj
+	if eWj != nil {
+		var err error
+		if expected, err = Rj.Add(eWj); err != nil {
+			return sj.Sign() == 0 // Rj = -e*Wj
+		}
+	}
+	return crypto.ScalarBaseMult(tss.EC(), sj).Equals(expected)
+}
@@ -0,0 +1,226 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	cmt "github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	// LocalParty makes a BIP340 Schnorr signature with the shares of a secp256k1 key from the ECDSA keygen.
+	// It follows the commit-reveal flow of the EdDSA signing: each signer commits to its nonce point, reveals it with
+	// a proof of knowledge, and then broadcasts its share of s.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys   keygen.LocalPartySaveData
+		keyErr error // set when the key data failed to validate
+		temp   localTempData
+		data   SchnorrSignatureData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *SchnorrSignatureData
+	}
+
+	localMessageStore struct {
+		signRound1Messages,
+		signRound2Messages,
+		signRound3Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// the message and, when taproot is set, the merkle root of the script tree
+		m          []byte
+		taproot    bool
+		merkleRoot []byte
+
+		// the x-only key that signs; the sign applied to every w_j and the term added to s for the tweak
+		pubKeyX   []byte
+		keySign   int
+		tweakTerm *big.Int
+
+		// temp data (thrown away after sign) / round 1
+		wi       *big.Int
+		bigWs    []*crypto.ECPoint
+		ki       *big.Int
+		pointKi  *crypto.ECPoint
+		deCommit cmt.HashDeCommitment
+
+		// round 2
+		cjs []*big.Int
+
+		// round 3
+		bigRjs []*crypto.ECPoint
+		rx     []byte
+		e      *big.Int
+	}
+)
+
+// NewLocalParty constructs a party that signs msg with BIP340 under the x-only key of `key`.
+// The ECDSAPub of the key is negated if it has an odd Y, as BIP340 requires.
+func NewLocalParty(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SchnorrSignatureData,
+) tss.Party {
+	return newLocalParty(msg, false, nil, params, key, out, end)
+}
+
+// NewTaprootLocalParty constructs a party that signs msg with BIP340 under the BIP341 output key of `key`, for a taproot
+// key path spend. merkleRoot is the 32-byte root of the script tree, or nil for an output without scripts (BIP86).
+func NewTaprootLocalParty(
+	msg []byte,
+	merkleRoot []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SchnorrSignatureData,
+) tss.Party {
+	return newLocalParty(msg, true, merkleRoot, params, key, out, end)
+}
+
+func newLocalParty(
+	msg []byte,
+	taproot bool,
+	merkleRoot []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SchnorrSignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      key,
+		temp:      localTempData{},
+		data:      SchnorrSignatureData{},
+		out:       out,
+		end:       end,
+	}
+	if p.keyErr = key.ValidateThreshold(params.Threshold()); p.keyErr == nil {
+		p.keys = keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	}
+	// msgs init
+	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
+
+	// temp data init
+	p.temp.m = msg
+	p.temp.taproot, p.temp.merkleRoot = taproot, merkleRoot
+	p.temp.cjs = make([]*big.Int, partyCount)
+	p.temp.bigRjs = make([]*crypto.ECPoint, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.keys, &p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*round1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if p.keyErr != nil {
+			return round.WrapError(fmt.Errorf("invalid key data: %v", p.keyErr))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+// Close stops the party and overwrites w_i and k_i.
+// The key data given to NewLocalParty and the signature sent through `end` are left as is.
+func (p *LocalParty) Close() {
+	tss.BaseClose(p, p.temp.wipe)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return p.BaseParty.ValidateMessage(msg)
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *SchnorrSignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+
+	case *SchnorrSignRound2Message:
+		p.temp.signRound2Messages[fromPIdx] = msg
+
+	case *SchnorrSignRound3Message:
+		p.temp.signRound3Messages[fromPIdx] = msg
+
+	default: // unrecognised message, just ignore!
+		common.Logger.Warnf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+func (temp *localTempData) wipe() {
+	common.WipeBigInts(temp.wi, temp.ki)
+}
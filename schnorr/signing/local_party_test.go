@@ -0,0 +1,266 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	"github.com/zeta-chain/tss-lib/test"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// the signing test vectors of BIP340, https://github.com/bitcoin/bips/blob/master/bip-0340/test-vectors.csv
+var bip340SigningVectors = []struct {
+	secretKey, publicKey, auxRand, msg, sig string
+}{
+	{
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+	},
+	{
+		"B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF",
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+	},
+	{
+		"0B432B2677937381AEF05BB02A66ECD012773062CF3FA2549E44F58ED2401710",
+		"25D1DFF95105F5253C4022F628A996AD3A0D95FBF21D468A1B33F8C160D8F517",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		"7EB0509757E246F19449885651611CB965ECC1A187DD51B64FDA1EDC9637D5EC97582B9CB13DB3933705B32BA982AF5AF25FD78881EBB32771FC5922EFC66EA3",
+	},
+}
+
+func TestBIP340Vectors(t *testing.T) {
+	for n, v := range bip340SigningVectors {
+		d, pub, aux, msg, sig := mustHex(v.secretKey), mustHex(v.publicKey), mustHex(v.auxRand), mustHex(v.msg), mustHex(v.sig)
+		pubKey, err := crypto.ScalarBaseMult(btcec.S256(), new(big.Int).SetBytes(d)).XOnly()
+		assert.NoError(t, err)
+		assert.Equal(t, pub, pubKey, "vector %d: public key", n)
+		assert.Equal(t, sig, referenceSign(d, msg, aux), "vector %d: signature", n)
+		assert.True(t, Verify(pub, msg, sig), "vector %d: verify", n)
+
+		// a signature of another message, with a flipped bit or with s >= n is refused
+		assert.False(t, Verify(pub, append([]byte{1}, msg...), sig), "vector %d: another message", n)
+		bad := append([]byte{}, sig...)
+		bad[40] ^= 1
+		assert.False(t, Verify(pub, msg, bad), "vector %d: flipped bit", n)
+		bad = append([]byte{}, sig...)
+		copy(bad[32:], btcec.S256().N.Bytes())
+		assert.False(t, Verify(pub, msg, bad), "vector %d: s = n", n)
+	}
+
+	// verification vector 4 of BIP340 was made with a nonce whose R.x has many leading zeros
+	assert.True(t, Verify(
+		mustHex("D69C3509BB99E412E68B0FE8544E72837DFA30746D8BE2AA65975F29D22DC7B9"),
+		mustHex("4DF3C3F68FCC83B27E9D42C90431A72499F17875C81A599B566C9889B9696703"),
+		mustHex("00000000000000000000003B78CE563F89A0ED9414F5AA28AD0D96D6795F9C6376AFB1548AF603B3EB45C9F8207DEE1060CB71C04E80F593060B07D28308D7F4")))
+
+	// a public key that is not on the curve is refused
+	v := bip340SigningVectors[1]
+	notOnCurve := mustHex("EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34")
+	_, err := crypto.NewECPointFromXOnly(notOnCurve)
+	assert.Error(t, err)
+	assert.False(t, Verify(notOnCurve, mustHex(v.msg), mustHex(v.sig)))
+}
+
+// the first test vector of BIP86, https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki
+func TestTaprootOutputKey(t *testing.T) {
+	internalKey, err := crypto.NewECPointFromXOnly(mustHex("cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	outputKey, _, err := TaprootOutputKey(internalKey, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	x, _ := outputKey.XOnly()
+	assert.Equal(t, mustHex("a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"), x)
+	addr, err := outputKey.BitcoinP2TRAddressUntweaked("bc")
+	assert.NoError(t, err)
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", addr)
+
+	_, _, err = TaprootOutputKey(internalKey, []byte{1, 2, 3})
+	assert.Error(t, err, "ensure a merkle root that is not 32 bytes is refused")
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	msg := mustHex(bip340SigningVectors[1].msg)
+	merkleRoot := TaggedHash("TapBranch", []byte("script tree"))
+
+	// the fixture key and its negation cover both parities of the Y of the public key
+	for _, keys := range [][]keygen.LocalPartySaveData{keys, negateKeys(keys)} {
+		pubKey, _ := keys[0].ECDSAPub.XOnly()
+		sig, tssErr := runSigning(t, keys, signPIDs, msg, false, nil, nil)
+		if assert.Nil(t, tssErr) {
+			assert.Len(t, sig.GetSignature().GetSignature(), SignatureLen)
+			assert.True(t, Verify(pubKey, msg, sig.GetSignature().GetSignature()), "bip340 verify must pass")
+		}
+
+		for _, root := range [][]byte{nil, merkleRoot} {
+			outputKey, _, err := TaprootOutputKey(keys[0].ECDSAPub, root)
+			if !assert.NoError(t, err) {
+				continue
+			}
+			outputKeyX, _ := outputKey.XOnly()
+			sig, tssErr = runSigning(t, keys, signPIDs, msg, true, root, nil)
+			if assert.Nil(t, tssErr) {
+				assert.True(t, Verify(outputKeyX, msg, sig.GetSignature().GetSignature()), "bip340 verify with the output key must pass")
+				assert.False(t, Verify(pubKey, msg, sig.GetSignature().GetSignature()), "ensure the internal key does not verify")
+			}
+		}
+	}
+
+	// a wrong share of s is attributed to its sender
+	_, tssErr := runSigning(t, keys, signPIDs, msg, false, nil, func(msg tss.Message) tss.Message {
+		if r3msg, ok := msg.(tss.ParsedMessage).Content().(*SchnorrSignRound3Message); ok && msg.GetFrom().Index == 0 {
+			return NewSchnorrSignRound3Message(msg.GetFrom(), new(big.Int).Add(r3msg.UnmarshalS(), big.NewInt(1)))
+		}
+		return msg
+	})
+	if assert.NotNil(t, tssErr) && assert.Len(t, tssErr.Culprits(), 1) {
+		assert.Equal(t, signPIDs[0].Id, tssErr.Culprits()[0].Id)
+	}
+}
+
+func TestStartRejectsOtherCurves(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	keys[0].Curve = tss.Nist256p1
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := tss.NewParameters(p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	P := NewLocalParty([]byte{1}, params, keys[0], make(chan tss.Message, 1), make(chan *SchnorrSignatureData, 1))
+	if err := P.Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid key data")
+	}
+}
+
+// runSigning signs msg with the keys and returns the first signature or error; tamper, if not nil, may rewrite the messages that are sent
+func runSigning(
+	t *testing.T,
+	keys []keygen.LocalPartySaveData,
+	signPIDs tss.SortedPartyIDs,
+	msg []byte,
+	taproot bool,
+	merkleRoot []byte,
+	tamper func(tss.Message) tss.Message,
+) (*SchnorrSignatureData, *tss.Error) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *SchnorrSignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		if taproot {
+			parties = append(parties, NewTaprootLocalParty(msg, merkleRoot, params, keys[i], outCh, endCh))
+		} else {
+			parties = append(parties, NewLocalParty(msg, params, keys[i], outCh, endCh))
+		}
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go test.RunParties(parties, outCh, errCh, tamper, stop)
+
+	var sig *SchnorrSignatureData
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			common.Logger.Warnf("Error: %s", err)
+			return nil, err
+
+		case sig = <-endCh:
+			ended++
+		}
+	}
+	return sig, nil
+}
+
+// negateKeys returns the shares of the negated secret key, whose public key has the other parity of Y
+func negateKeys(keys []keygen.LocalPartySaveData) []keygen.LocalPartySaveData {
+	modN := common.ModInt(tss.EC().Params().N)
+	negated := make([]keygen.LocalPartySaveData, len(keys))
+	for i, key := range keys {
+		key.Xi = modN.Neg(key.Xi)
+		key.ECDSAPub = key.ECDSAPub.Neg()
+		key.BigXj = make([]*crypto.ECPoint, len(keys[i].BigXj))
+		for j, BigXj := range keys[i].BigXj {
+			key.BigXj[j] = BigXj.Neg()
+		}
+		key.Fingerprint = nil
+		negated[i] = key
+	}
+	return negated
+}
+
+// referenceSign is the default signing algorithm of BIP340 with a single secret key
+func referenceSign(secretKey, msg, auxRand []byte) []byte {
+	N := btcec.S256().N
+	modN := common.ModInt(N)
+	d := new(big.Int).SetBytes(secretKey)
+	P := crypto.ScalarBaseMult(btcec.S256(), d)
+	if P.Y().Bit(0) != 0 {
+		d = modN.Neg(d)
+	}
+	px, _ := P.XOnly()
+	t := d.FillBytes(make([]byte, 32))
+	for i, b := range TaggedHash("BIP0340/aux", auxRand) {
+		t[i] ^= b
+	}
+	k := new(big.Int).SetBytes(TaggedHash("BIP0340/nonce", t, px, msg))
+	k.Mod(k, N)
+	R := crypto.ScalarBaseMult(btcec.S256(), k)
+	if R.Y().Bit(0) != 0 {
+		k = modN.Neg(k)
+	}
+	rx, _ := R.XOnly()
+	s := modN.Add(k, modN.Mul(challenge(rx, px, msg), d))
+	return append(rx, s.FillBytes(make([]byte, 32))...)
+}
+
+func mustHex(s string) []byte {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
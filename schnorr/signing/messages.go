@@ -0,0 +1,125 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	cmt "github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/crypto/zkp"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into schnorr-signing.pb.go
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*SchnorrSignRound1Message)(nil),
+		(*SchnorrSignRound2Message)(nil),
+		(*SchnorrSignRound3Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewSchnorrSignRound1Message(
+	from *tss.PartyID,
+	commitment cmt.HashCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SchnorrSignRound1Message{
+		Commitment: commitment.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SchnorrSignRound1Message) ValidateBasic() bool {
+	return m.Commitment != nil &&
+		common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *SchnorrSignRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewSchnorrSignRound2Message(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	proof *zkp.DLogProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	content := &SchnorrSignRound2Message{
+		DeCommitment: dcBzs,
+		ProofAlpha:   proof.Alpha.ToProtobufPoint(),
+		ProofT:       proof.T.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SchnorrSignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		m.ProofAlpha != nil &&
+		common.NonEmptyMultiBytes(m.DeCommitment, 3) &&
+		m.ProofAlpha.ValidateBasic() &&
+		common.NonEmptyBytes(m.ProofT)
+}
+
+func (m *SchnorrSignRound2Message) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
+
+func (m *SchnorrSignRound2Message) UnmarshalZKProof() (*zkp.DLogProof, error) {
+	point, err := crypto.NewECPointFromProtobuf(m.GetProofAlpha())
+	if err != nil {
+		return nil, err
+	}
+	return &zkp.DLogProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(m.GetProofT()),
+	}, nil
+}
+
+// ----- //
+
+func NewSchnorrSignRound3Message(
+	from *tss.PartyID,
+	si *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SchnorrSignRound3Message{
+		S: si.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SchnorrSignRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.S)
+}
+
+func (m *SchnorrSignRound3Message) UnmarshalS() *big.Int {
+	return new(big.Int).SetBytes(m.S)
+}
@@ -0,0 +1,139 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/ecdsa/keygen"
+	ecdsasigning "github.com/zeta-chain/tss-lib/ecdsa/signing"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the signing part of the BIP340 Schnorr TSS spec
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *SchnorrSignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *SchnorrSignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// 1. select ki
+	ki := common.GetRandomPositiveInt(tss.EC().Params().N)
+
+	// 2. make commitment
+	pointKi := crypto.ScalarBaseMult(tss.EC(), ki)
+	cmt := commitments.NewHashCommitment(pointKi.X(), pointKi.Y())
+
+	// 3. store r1 message pieces
+	round.temp.ki = ki
+	round.temp.pointKi = pointKi
+	round.temp.deCommit = cmt.D
+
+	// 4. broadcast commitment
+	r1msg := NewSchnorrSignRound1Message(round.PartyID(), cmt.C)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SchnorrSignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to compute wi and the W_j, and the key that signs
+func (round *round1) prepare() error {
+	if !tss.SameCurve(tss.EC(), btcec.S256()) {
+		return errors.New("BIP340 signatures are only defined over secp256k1")
+	}
+	i := round.PartyID().Index
+	key := round.key
+	var err error
+	if key.IsWeighted() {
+		if allKs, _ := key.AllShareIDs(); round.Threshold()+1 > len(allKs) {
+			return fmt.Errorf("t+1=%d is not satisfied by the share count of %d", round.Threshold()+1, len(allKs))
+		}
+		ks, bigXs := make([][]*big.Int, len(key.Ks)), make([][]*crypto.ECPoint, len(key.Ks))
+		for j := range key.Ks {
+			ks[j] = append([]*big.Int{key.Ks[j]}, key.ExtraKs[j]...)
+			bigXs[j] = append([]*crypto.ECPoint{key.BigXj[j]}, key.ExtraBigXj[j]...)
+		}
+		xis := append([]*big.Int{key.Xi}, key.ExtraXi...)
+		round.temp.wi, round.temp.bigWs, err = ecdsasigning.PrepareForWeightedSigning(i, xis, ks, bigXs)
+	} else {
+		if round.Threshold()+1 > len(key.Ks) {
+			return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(key.Ks))
+		}
+		round.temp.wi, round.temp.bigWs, err = ecdsasigning.PrepareForSigning(i, len(key.Ks), key.Xi, key.Ks, key.BigXj)
+	}
+	if err != nil {
+		return err
+	}
+
+	// BIP340 signs with the key whose point has an even Y, so the shares are negated when ECDSAPub has an odd Y
+	pubKey, keySign, tweakTerm := key.ECDSAPub, 1, big.NewInt(0)
+	if pubKey.Y().Bit(0) != 0 {
+		keySign = -1
+	}
+	if round.temp.taproot {
+		// the output key Q = lift_x(P) + t*G signs with the secret d + t, negated when Q has an odd Y
+		outputKey, tweak, err := TaprootOutputKey(pubKey, round.temp.merkleRoot)
+		if err != nil {
+			return err
+		}
+		pubKey, tweakTerm = outputKey, tweak
+		if outputKey.Y().Bit(0) != 0 {
+			keySign = -keySign
+			tweakTerm = common.ModInt(tss.EC().Params().N).Neg(tweak)
+		}
+	}
+	if round.temp.pubKeyX, err = pubKey.XOnly(); err != nil {
+		return err
+	}
+	round.temp.keySign, round.temp.tweakTerm = keySign, tweakTerm
+	return nil
+}
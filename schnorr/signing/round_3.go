@@ -0,0 +1,128 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/zeta-chain/tss-lib/common"
+	"github.com/zeta-chain/tss-lib/crypto"
+	"github.com/zeta-chain/tss-lib/crypto/commitments"
+	"github.com/zeta-chain/tss-lib/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	modN := common.ModInt(tss.EC().Params().N)
+
+	// 1-5. de-commit and verify each Rj = kj*G, and compute R = sum(Rj)
+	round.temp.bigRjs[i] = round.temp.pointKi
+	R := round.temp.pointKi
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r2msg := round.temp.signRound2Messages[j].Content().(*SchnorrSignRound2Message)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
+		ok, coordinates := cmtDeCmt.DeCommit()
+		if !ok {
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj)
+		}
+		if len(coordinates) != 2 {
+			return round.WrapError(errors.New("length of de-commitment should be 2"), Pj)
+		}
+		Rj, err := crypto.NewECPoint(tss.EC(), coordinates[0], coordinates[1])
+		if err != nil {
+			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
+		}
+		proof, err := r2msg.UnmarshalZKProof()
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
+		}
+		if !proof.Verify(Rj) {
+			return round.WrapError(errors.New("failed to prove Rj"), Pj)
+		}
+		round.temp.bigRjs[j] = Rj
+		if R, err = R.Add(Rj); err != nil {
+			return round.WrapError(errors.Wrapf(err, "R.Add(Rj)"), Pj)
+		}
+	}
+
+	// 6. BIP340 takes the nonce whose point has an even Y, so every ki is negated when R has an odd Y
+	ki := round.temp.ki
+	if R.Y().Bit(0) != 0 {
+		ki = modN.Neg(ki)
+		for j, Rj := range round.temp.bigRjs {
+			round.temp.bigRjs[j] = Rj.Neg()
+		}
+	}
+
+	// 7. e = hash_BIP0340/challenge(R.x || Q.x || m)
+	rx, err := R.XOnly()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	e := challenge(rx, round.temp.pubKeyX, round.temp.m)
+
+	// 8. si = ki + e * wi, with wi negated along with the key
+	wi := round.temp.wi
+	if round.temp.keySign < 0 {
+		wi = modN.Neg(wi)
+	}
+	ewi := modN.Mul(e, wi)
+	si := modN.Add(ki, ewi)
+	common.WipeBigInt(ewi)
+	if ki != round.temp.ki {
+		common.WipeBigInt(ki)
+	}
+	if wi != round.temp.wi {
+		common.WipeBigInt(wi)
+	}
+
+	// 9. store r3 message pieces
+	round.temp.rx, round.temp.e = rx, e
+
+	// 10. broadcast si to other parties
+	r3msg := NewSchnorrSignRound3Message(round.PartyID(), si)
+	round.temp.signRound3Messages[i] = r3msg
+	round.out <- r3msg
+
+	return nil
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	for j, msg := range round.temp.signRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SchnorrSignRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -7,6 +7,8 @@
 package test
 
 import (
+	"math/big"
+
 	"github.com/zeta-chain/tss-lib/tss"
 )
 
@@ -29,3 +31,83 @@ func SharedPartyUpdater(party tss.Party, msg tss.Message, errCh chan<- *tss.Erro
 		errCh <- err
 	}
 }
+
+// RunParties starts the parties and delivers each message that they send on outCh, after tamper if it is not nil, to
+// every other party if it is a broadcast and only to the parties of msg.GetTo() otherwise. The errors of the parties go
+// to errCh. It returns, closing the parties, when stop is closed.
+func RunParties(
+	parties []tss.Party,
+	outCh <-chan tss.Message,
+	errCh chan<- *tss.Error,
+	tamper func(tss.Message) tss.Message,
+	stop <-chan struct{},
+) {
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	defer func() {
+		for _, P := range parties {
+			P.Close()
+		}
+	}()
+	for {
+		select {
+		case <-stop:
+			return
+
+		case msg := <-outCh:
+			if tamper != nil {
+				msg = tamper(msg)
+			}
+			if dest := msg.GetTo(); dest != nil {
+				for _, to := range dest {
+					if 0 <= to.Index && to.Index < len(parties) {
+						go SharedPartyUpdater(parties[to.Index], msg, errCh)
+					}
+				}
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go SharedPartyUpdater(P, msg, errCh)
+			}
+		}
+	}
+}
+
+// Wiped reports whether each of xs is nil or zero, with every word of its memory overwritten.
+func Wiped(xs ...*big.Int) bool {
+	for _, x := range xs {
+		if x == nil {
+			continue
+		}
+		if x.Sign() != 0 {
+			return false
+		}
+		words := x.Bits()
+		for _, word := range words[:cap(words)] {
+			if word != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// WipedBytes reports whether every byte of each of bzs is zero.
+func WipedBytes(bzs ...[]byte) bool {
+	for _, bz := range bzs {
+		for _, b := range bz {
+			if b != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -9,17 +9,70 @@ package tss
 import (
 	"crypto/elliptic"
 	"errors"
+	"reflect"
 
 	s256k1 "github.com/btcsuite/btcd/btcec"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+type CurveName string
+
+const (
+	Secp256k1 CurveName = "secp256k1"
+	Nist256p1 CurveName = "nist256p1" // NIST P-256
+	Ed25519   CurveName = "ed25519"
 )
 
 var (
-	ec elliptic.Curve
+	ec       elliptic.Curve
+	registry map[CurveName]elliptic.Curve
 )
 
 // Init default curve (secp256k1)
 func init() {
 	ec = s256k1.S256()
+
+	registry = make(map[CurveName]elliptic.Curve)
+	registry[Secp256k1] = s256k1.S256()
+	registry[Nist256p1] = elliptic.P256()
+	registry[Ed25519] = edwards.Edwards()
+}
+
+// RegisterCurve makes a curve known by name, i.e. to the JSON encoding of points. The names match the upstream bnb-chain tss-lib.
+func RegisterCurve(name CurveName, curve elliptic.Curve) {
+	if curve == nil {
+		panic(errors.New("RegisterCurve received a nil curve"))
+	}
+	registry[name] = curve
+}
+
+// GetCurveByName returns the curve registered under the name
+func GetCurveByName(name CurveName) (elliptic.Curve, bool) {
+	curve, ok := registry[name]
+	return curve, ok
+}
+
+// GetCurveName returns the name under which the curve is registered
+func GetCurveName(curve elliptic.Curve) (CurveName, bool) {
+	for name, c := range registry {
+		if SameCurve(c, curve) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// SameCurve reports whether two curve instances implement the same curve; some constructors such as edwards.Edwards() return a new instance each time
+func SameCurve(a, b elliptic.Curve) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	return reflect.TypeOf(a) == reflect.TypeOf(b) &&
+		a.Params().P.Cmp(b.Params().P) == 0 &&
+		a.Params().N.Cmp(b.Params().N) == 0
 }
 
 // EC returns the current elliptic curve in use. The default is secp256k1
@@ -8,6 +8,8 @@ package tss
 
 import (
 	"errors"
+	"fmt"
+	"runtime"
 	"time"
 
 	"github.com/zeta-chain/tss-lib/common"
@@ -20,9 +22,18 @@ type (
 		partyCount              int
 		threshold               int
 		safePrimeGenTimeout     time.Duration
+		minModulusBitLen        int
+		maxModulusBitLen        int
+		protocolVersion         ProtocolVersion
+		weights                 map[string]int
+		concurrency             int
+		sessionNonce            []byte
 		unsafeKGIgnoreH1H2Dupes bool
 	}
 
+	// ProtocolVersion selects the proofs of well-formedness exchanged for the Paillier modulus and NTilde during ECDSA keygen and re-sharing.
+	ProtocolVersion int
+
 	ReSharingParameters struct {
 		*Parameters
 		newParties    *PeerContext
@@ -31,8 +42,19 @@ type (
 	}
 )
 
+const (
+	// ProtocolV1 uses the GG18 Paillier proof (GG18Spec(6)) and the two dln proofs of h1, h2.
+	ProtocolV1 ProtocolVersion = 1
+	// ProtocolV2 uses the Paillier-Blum modulus proof and the Ring-Pedersen parameter proof of CGGMP21.
+	ProtocolV2 ProtocolVersion = 2
+)
+
 const (
 	defaultSafePrimeGenTimeout = 5 * time.Minute
+	// Using a modulus length of 2048 is recommended in the GG18 spec
+	defaultMinModulusBitLen = 2048
+	// Unless it is set, the largest bit length accepted is this multiple of the smallest one
+	defaultMaxModulusBitLenFactor = 2
 )
 
 // Exported, used in `tss` client
@@ -52,6 +74,9 @@ func NewParameters(ctx *PeerContext, partyID *PartyID, partyCount, threshold int
 		partyCount:          partyCount,
 		threshold:           threshold,
 		safePrimeGenTimeout: safePrimeGenTimeout,
+		minModulusBitLen:    defaultMinModulusBitLen,
+		protocolVersion:     ProtocolV1,
+		concurrency:         runtime.NumCPU(),
 	}
 }
 
@@ -75,6 +100,118 @@ func (params *Parameters) SafePrimeGenTimeout() time.Duration {
 	return params.safePrimeGenTimeout
 }
 
+// MinModulusBitLen returns the smallest bit length accepted for the Paillier modulus and NTilde of any party.
+func (params *Parameters) MinModulusBitLen() int {
+	return params.minModulusBitLen
+}
+
+// SetMinModulusBitLen raises the smallest bit length accepted for the Paillier modulus and NTilde of any party.
+// Pre-params generated during the rounds will also use this length. Values below 2048 are not allowed.
+func (params *Parameters) SetMinModulusBitLen(minModulusBitLen int) {
+	if minModulusBitLen < defaultMinModulusBitLen {
+		panic(fmt.Errorf("SetMinModulusBitLen: expected at least %d bits, got %d", defaultMinModulusBitLen, minModulusBitLen))
+	}
+	if params.maxModulusBitLen != 0 && minModulusBitLen > params.maxModulusBitLen {
+		panic(fmt.Errorf("SetMinModulusBitLen: expected at most the maximum of %d bits, got %d", params.maxModulusBitLen, minModulusBitLen))
+	}
+	params.minModulusBitLen = minModulusBitLen
+}
+
+// MaxModulusBitLen returns the largest bit length accepted for the Paillier modulus and NTilde of any party,
+// which bounds the work a party can cause the others by sending a huge modulus.
+func (params *Parameters) MaxModulusBitLen() int {
+	if params.maxModulusBitLen != 0 {
+		return params.maxModulusBitLen
+	}
+	return defaultMaxModulusBitLenFactor * params.minModulusBitLen
+}
+
+// SetMaxModulusBitLen sets the largest bit length accepted for the Paillier modulus and NTilde of any party.
+// It defaults to twice the minimum and may not be below it.
+func (params *Parameters) SetMaxModulusBitLen(maxModulusBitLen int) {
+	if maxModulusBitLen < params.minModulusBitLen {
+		panic(fmt.Errorf("SetMaxModulusBitLen: expected at least the minimum of %d bits, got %d", params.minModulusBitLen, maxModulusBitLen))
+	}
+	params.maxModulusBitLen = maxModulusBitLen
+}
+
+// ProtocolVersion returns the version of the Paillier and NTilde proofs used in ECDSA keygen and re-sharing.
+func (params *Parameters) ProtocolVersion() ProtocolVersion {
+	return params.protocolVersion
+}
+
+// SetProtocolVersion selects the version of the Paillier and NTilde proofs used in ECDSA keygen and re-sharing.
+// Every party in a round must use the same version. Save data produced by either version may be used with both.
+func (params *Parameters) SetProtocolVersion(version ProtocolVersion) {
+	if version != ProtocolV1 && version != ProtocolV2 {
+		panic(fmt.Errorf("SetProtocolVersion: unknown protocol version %d", version))
+	}
+	params.protocolVersion = version
+}
+
+// SessionNonce returns the nonce of this run of the protocol, or nil if none was set.
+func (params *Parameters) SessionNonce() []byte {
+	return params.sessionNonce
+}
+
+// SetSessionNonce sets a nonce that is unique to this run of the protocol and hashed into the session ID, so that the
+// proofs of one run cannot be replayed in another run with the same parties and threshold.
+// Every party of the run must set the same nonce, e.g. an ID chosen by the coordinator of the session.
+func (params *Parameters) SetSessionNonce(nonce []byte) {
+	if len(nonce) == 0 {
+		panic(errors.New("SetSessionNonce: expected a non-empty nonce"))
+	}
+	params.sessionNonce = append([]byte{}, nonce...)
+}
+
+// Concurrency returns the number of goroutines that compute or verify the MtA proofs of one party during ECDSA signing.
+func (params *Parameters) Concurrency() int {
+	return params.concurrency
+}
+
+// SetConcurrency bounds the number of goroutines that compute or verify the MtA proofs of one party during ECDSA signing.
+// It defaults to the number of CPUs; use a lower value when many parties share a machine.
+func (params *Parameters) SetConcurrency(concurrency int) {
+	if concurrency < 1 {
+		panic(fmt.Errorf("SetConcurrency: expected at least 1 goroutine, got %d", concurrency))
+	}
+	params.concurrency = concurrency
+}
+
+// PartyWeight returns the number of shares held by the party at index j in a weighted keygen.
+func (params *Parameters) PartyWeight(j int) int {
+	if weight, ok := params.weights[string(params.parties.IDs()[j].Key)]; ok {
+		return weight
+	}
+	return 1
+}
+
+// SetPartyWeight sets the number of shares held by a party in a weighted keygen, so that it counts as that many parties toward the threshold.
+// Parties have a weight of one by default. Every party in a keygen must set the same weights.
+func (params *Parameters) SetPartyWeight(partyID *PartyID, weight int) {
+	if weight < 1 {
+		panic(fmt.Errorf("SetPartyWeight: expected a weight of at least 1, got %d", weight))
+	}
+	if params.weights == nil {
+		params.weights = make(map[string]int)
+	}
+	params.weights[string(partyID.Key)] = weight
+}
+
+// TotalWeight returns the total number of shares held by all parties in a weighted keygen.
+func (params *Parameters) TotalWeight() int {
+	total := 0
+	for j := range params.parties.IDs() {
+		total += params.PartyWeight(j)
+	}
+	return total
+}
+
+// IsWeighted returns true if any party holds more than one share.
+func (params *Parameters) IsWeighted() bool {
+	return params.TotalWeight() != len(params.parties.IDs())
+}
+
 // Getter. The H1, H2 dupe check is disabled during some benchmarking scenarios to allow reuse of pre-params.
 func (params *Parameters) UNSAFE_KGIgnoreH1H2Dupes() bool {
 	return params.unsafeKGIgnoreH1H2Dupes
@@ -29,6 +29,9 @@ type Party interface {
 	WrapError(err error, culprits ...*PartyID) *Error
 	PartyID() *PartyID
 	String() string
+	// Close stops the party and overwrites the secrets in its temp data. Call it once the party has ended, failed or timed out.
+	// The data sent through the end channel is not modified. Start and Update fail after Close.
+	Close()
 
 	// Private lifecycle methods
 	setRound(Round) *Error
@@ -36,11 +39,14 @@ type Party interface {
 	advance()
 	lock()
 	unlock()
+	isClosed() bool
+	setClosed()
 }
 
 type BaseParty struct {
 	mtx        sync.Mutex
 	rnd        Round
+	closed     bool
 	FirstRound Round
 }
 
@@ -79,6 +85,9 @@ func (p *BaseParty) ValidateMessage(msg ParsedMessage) (bool, *Error) {
 }
 
 func (p *BaseParty) String() string {
+	if p.round() == nil {
+		return "round: none"
+	}
 	return fmt.Sprintf("round: %d", p.round().RoundNumber())
 }
 
@@ -109,11 +118,23 @@ func (p *BaseParty) unlock() {
 	p.mtx.Unlock()
 }
 
+func (p *BaseParty) isClosed() bool {
+	return p.closed
+}
+
+func (p *BaseParty) setClosed() {
+	p.closed = true
+	p.rnd = nil
+}
+
 // ----- //
 
 func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
 	p.lock()
 	defer p.unlock()
+	if p.isClosed() {
+		return p.WrapError(errors.New("could not start. this party has been closed"))
+	}
 	if p.PartyID() == nil || !p.PartyID().ValidateBasic() {
 		return p.WrapError(fmt.Errorf("could not start. this party has an invalid PartyID: %+v", p.PartyID()))
 	}
@@ -151,6 +172,9 @@ func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 		return ok, err
 	}
 	p.lock() // data is written to P state below
+	if p.isClosed() {
+		return r(false, p.WrapError(errors.New("could not update. this party has been closed")))
+	}
 	common.Logger.Debugf("party %s received message: %s", p.PartyID(), msg.String())
 	if p.round() != nil {
 		common.Logger.Debugf("party %s round %d update: %s", p.PartyID(), p.round().RoundNumber(), msg.String())
@@ -181,3 +205,15 @@ func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 	}
 	return r(true, nil)
 }
+
+// an implementation of Close that is shared across the different types of parties.
+// wipe overwrites the secrets of the party; it runs once, while the party is locked so that no round runs at the same time.
+func BaseClose(p Party, wipe func()) {
+	p.lock()
+	defer p.unlock()
+	if p.isClosed() {
+		return
+	}
+	p.setClosed()
+	wipe()
+}
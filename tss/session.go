@@ -0,0 +1,43 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"math/big"
+
+	"github.com/zeta-chain/tss-lib/common"
+)
+
+// SessionID identifies a protocol session by the curve, the keys and weights of the parties, the threshold and the
+// session nonce, if one was set. Every party of the session computes the same value.
+func (params *Parameters) SessionID() *big.Int {
+	curve := EC().Params()
+	in := []*big.Int{curve.P, curve.N, curve.Gx, curve.Gy, big.NewInt(int64(params.threshold))}
+	for j, Pj := range params.parties.IDs() {
+		in = append(in, Pj.KeyInt(), big.NewInt(int64(params.PartyWeight(j))))
+	}
+	if params.sessionNonce != nil {
+		in = append(in, new(big.Int).SetBytes(common.SHA512_256(params.sessionNonce)))
+	}
+	return common.SHA512_256i(in...)
+}
+
+// SessionID identifies a re-sharing session by the session of the old committee, the keys of the new committee and the new threshold.
+func (rgParams *ReSharingParameters) SessionID() *big.Int {
+	in := []*big.Int{rgParams.Parameters.SessionID(), big.NewInt(int64(rgParams.newThreshold))}
+	for _, Pj := range rgParams.newParties.IDs() {
+		in = append(in, Pj.KeyInt())
+	}
+	return common.SHA512_256i(in...)
+}
+
+// ProofSession binds the zero-knowledge proofs of the party prover to the session ssid and to any other public values
+// of the session, such as the public key, so that a proof cannot be replayed by another party or in another session.
+func ProofSession(ssid *big.Int, prover *PartyID, extra ...*big.Int) *big.Int {
+	in := append([]*big.Int{ssid, prover.KeyInt()}, extra...)
+	return common.SHA512_256i(in...)
+}